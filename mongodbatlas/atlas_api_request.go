@@ -0,0 +1,73 @@
+package mongodbatlas
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"go.mongodb.org/atlas-sdk/v20230201002/admin"
+)
+
+// atlasAPIError is an error answered by Atlas to a request sent with doAtlasAPIRequest.
+type atlasAPIError struct {
+	admin.ApiError
+}
+
+func (e *atlasAPIError) Error() string {
+	return fmt.Sprintf("HTTP %d %s (Error code: %q) Detail: %s", e.GetError(), e.GetReason(), e.GetErrorCode(), e.GetDetail())
+}
+
+// doAtlasAPIRequest sends a request of the version of the API identified by mediaType with the HTTP client of the
+// Atlas SDK, so that it's authenticated and retried the same way, and decodes the response into result if not nil.
+// It's meant for the endpoints and versions of the API the Atlas SDK used by the provider doesn't implement.
+func doAtlasAPIRequest(ctx context.Context, connV2 *admin.APIClient, mediaType, method, path string, body, result interface{}) (*http.Response, error) {
+	config := connV2.GetConfig()
+
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, config.Servers[0].URL+path, reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", mediaType)
+	req.Header.Set("User-Agent", config.UserAgent)
+	if body != nil {
+		req.Header.Set("Content-Type", mediaType)
+	}
+
+	resp, err := config.HTTPClient.Do(req)
+	if err != nil {
+		return resp, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp, err
+	}
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		apiError := new(atlasAPIError)
+		if err := json.Unmarshal(respBody, &apiError.ApiError); err != nil || !apiError.HasError() {
+			apiError.ApiError = admin.ApiError{Error: admin.PtrInt(resp.StatusCode), Reason: admin.PtrString(http.StatusText(resp.StatusCode))}
+		}
+		return resp, apiError
+	}
+
+	if result == nil || len(respBody) == 0 {
+		return resp, nil
+	}
+
+	return resp, json.Unmarshal(respBody, result)
+}
@@ -0,0 +1,36 @@
+package mongodbatlas
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestDoAtlasAPIRequest(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		mock      = newAtlasMockServer(t)
+		connV2    = mock.client().AtlasV2
+		projectID = mock.newProject("test-mock")
+		path      = mockAtlasV2Path + "/groups/" + projectID
+	)
+
+	project := struct {
+		Name string `json:"name"`
+	}{}
+	if _, err := doAtlasAPIRequest(ctx, connV2, "application/vnd.atlas.2023-01-01+json", http.MethodGet, path, nil, &project); err != nil {
+		t.Fatalf("Bad request: %s", err)
+	}
+	if project.Name != "test-mock" {
+		t.Errorf("Bad decoded response: got name %q, want %q", project.Name, "test-mock")
+	}
+
+	resp, err := doAtlasAPIRequest(ctx, connV2, "application/vnd.atlas.2023-01-01+json", http.MethodGet, mockAtlasV2Path+"/groups/"+newMockObjectID(), nil, nil)
+	apiError, ok := err.(*atlasAPIError)
+	if !ok {
+		t.Fatalf("Bad error: got %T %v, want *atlasAPIError", err, err)
+	}
+	if resp.StatusCode != http.StatusNotFound || apiError.GetError() != http.StatusNotFound || apiError.GetErrorCode() != "GROUP_NOT_FOUND" {
+		t.Errorf("Bad error: status %d, %s", resp.StatusCode, apiError)
+	}
+}
@@ -0,0 +1,131 @@
+package mongodbatlas
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+const (
+	atlasCLIServiceCloudGov = "cloudgov"
+	mongoDBGovBaseURL       = "https://cloud.mongodbgov.com"
+)
+
+// atlasCLIProfile holds the settings of a profile of the Atlas CLI (or MongoDB CLI) configuration file:
+//
+//	[default]
+//	  org_id = "..."
+//	  project_id = "..."
+//	  public_api_key = "..."
+//	  private_api_key = "..."
+//	  service = "cloud"
+type atlasCLIProfile struct {
+	Name          string `toml:"-"`
+	Service       string `toml:"service"`
+	PublicAPIKey  string `toml:"public_api_key"`
+	PrivateAPIKey string `toml:"private_api_key"`
+	ClientID      string `toml:"client_id"`
+	ClientSecret  string `toml:"client_secret"`
+	OpsManagerURL string `toml:"ops_manager_url"`
+	OrgID         string `toml:"org_id"`
+	ProjectID     string `toml:"project_id"`
+}
+
+// atlasCLIConfigFiles returns the locations of the configuration file of the Atlas CLI followed
+// by the legacy ones of the MongoDB CLI, in the order they are looked up.
+func atlasCLIConfigFiles() []string {
+	var files []string
+
+	if configDir, err := os.UserConfigDir(); err == nil {
+		files = append(files,
+			filepath.Join(configDir, "atlascli", "config.toml"),
+			filepath.Join(configDir, "mongocli", "config.toml"),
+		)
+	}
+
+	if home, err := os.UserHomeDir(); err == nil {
+		files = append(files, filepath.Join(home, ".config", "mongocli.toml"))
+	}
+
+	return files
+}
+
+// readAtlasCLIProfile reads the profile name of the CLI configuration file path. When path is empty,
+// the first configuration file found among the default locations of the CLIs is used.
+func readAtlasCLIProfile(path, name string) (*atlasCLIProfile, error) {
+	if path == "" {
+		for _, file := range atlasCLIConfigFiles() {
+			if _, err := os.Stat(file); err == nil {
+				path = file
+				break
+			}
+		}
+
+		if path == "" {
+			return nil, fmt.Errorf("no Atlas CLI configuration file found, looked for %s", strings.Join(atlasCLIConfigFiles(), ", "))
+		}
+	}
+
+	path, err := expandHomeDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// besides the profiles, the file holds top level settings of the CLI, so only the requested table is decoded.
+	var tables map[string]toml.Primitive
+	metadata, err := toml.DecodeFile(path, &tables)
+	if err != nil {
+		return nil, fmt.Errorf("error reading Atlas CLI configuration file %s: %w", path, err)
+	}
+
+	table, ok := tables[name]
+	if !ok {
+		return nil, fmt.Errorf("profile %q not found in Atlas CLI configuration file %s", name, path)
+	}
+
+	profile := &atlasCLIProfile{Name: name}
+	if err := metadata.PrimitiveDecode(table, profile); err != nil {
+		return nil, fmt.Errorf("error reading profile %q of Atlas CLI configuration file %s: %w", name, path, err)
+	}
+
+	return profile, nil
+}
+
+// baseURL returns the URL of the Atlas API the profile targets, empty for the default one.
+func (p *atlasCLIProfile) baseURL() string {
+	if p.OpsManagerURL != "" {
+		return p.OpsManagerURL
+	}
+
+	if p.Service == atlasCLIServiceCloudGov {
+		return mongoDBGovBaseURL
+	}
+
+	return ""
+}
+
+// apply sets the settings of config that weren't configured through the provider arguments or
+// their environment variables with the ones of the profile.
+func (p *atlasCLIProfile) apply(config *Config) {
+	if config.PublicKey == "" && config.PrivateKey == "" && config.ClientID == "" && config.ClientSecret == "" {
+		config.PublicKey = p.PublicAPIKey
+		config.PrivateKey = p.PrivateAPIKey
+		config.ClientID = p.ClientID
+		config.ClientSecret = p.ClientSecret
+	}
+
+	if config.BaseURL == "" {
+		config.BaseURL = p.baseURL()
+	}
+
+	if config.DefaultOrgID == "" {
+		config.DefaultOrgID = p.OrgID
+	}
+
+	if config.DefaultProjectID == "" {
+		config.DefaultProjectID = p.ProjectID
+	}
+}
@@ -0,0 +1,239 @@
+package mongodbatlas
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const testAtlasCLIConfig = `
+skip_update_check = true
+telemetry_enabled = false
+
+[default]
+  org_id = "5f3a2b1c4d5e6f7a8b9c0d1e"
+  output = "json"
+  private_api_key = "default-private-key"
+  project_id = "5f3a2b1c4d5e6f7a8b9c0d1f"
+  public_api_key = "default-public-key"
+  service = "cloud"
+
+[gov]
+  client_id = "mdb_sa_id_gov"
+  client_secret = "mdb_sa_sk_gov"
+  service = "cloudgov"
+`
+
+func TestReadAtlasCLIProfile(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.toml")
+	writeTestFile(t, configFile, testAtlasCLIConfig)
+
+	testCases := map[string]struct {
+		profile  string
+		expected *atlasCLIProfile
+		err      bool
+	}{
+		"api key": {
+			profile: "default",
+			expected: &atlasCLIProfile{
+				Name:          "default",
+				Service:       "cloud",
+				PublicAPIKey:  "default-public-key",
+				PrivateAPIKey: "default-private-key",
+				OrgID:         "5f3a2b1c4d5e6f7a8b9c0d1e",
+				ProjectID:     "5f3a2b1c4d5e6f7a8b9c0d1f",
+			},
+		},
+		"service account": {
+			profile: "gov",
+			expected: &atlasCLIProfile{
+				Name:         "gov",
+				Service:      "cloudgov",
+				ClientID:     "mdb_sa_id_gov",
+				ClientSecret: "mdb_sa_sk_gov",
+			},
+		},
+		"missing profile": {
+			profile: "prod",
+			err:     true,
+		},
+		"top level setting": {
+			profile: "telemetry_enabled",
+			err:     true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := readAtlasCLIProfile(configFile, tc.profile)
+			if tc.err {
+				if err == nil {
+					t.Fatalf("expected an error, got %#v", got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if diff := deep.Equal(tc.expected, got); diff != nil {
+				t.Fatalf("Bad readAtlasCLIProfile return \n got = %#v\nwant = %#v \ndiff = %#v", got, tc.expected, diff)
+			}
+		})
+	}
+}
+
+func TestReadAtlasCLIProfile_defaultConfigFile(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	t.Setenv("HOME", configDir)
+
+	if _, err := readAtlasCLIProfile("", "default"); err == nil {
+		t.Fatal("expected an error when no configuration file exists")
+	}
+
+	// the legacy MongoDB CLI configuration file is used when there is no Atlas CLI one.
+	if err := os.MkdirAll(filepath.Join(configDir, ".config"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	writeTestFile(t, filepath.Join(configDir, ".config", "mongocli.toml"), "[default]\npublic_api_key = \"mongocli\"\n")
+
+	profile, err := readAtlasCLIProfile("", "default")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if profile.PublicAPIKey != "mongocli" {
+		t.Fatalf("expected the MongoDB CLI profile, got %#v", profile)
+	}
+
+	if err := os.MkdirAll(filepath.Join(configDir, "atlascli"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	writeTestFile(t, filepath.Join(configDir, "atlascli", "config.toml"), "[default]\npublic_api_key = \"atlascli\"\n")
+
+	if profile, err = readAtlasCLIProfile("", "default"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if profile.PublicAPIKey != "atlascli" {
+		t.Fatalf("expected the Atlas CLI profile to take precedence, got %#v", profile)
+	}
+}
+
+func TestAtlasCLIProfileApply(t *testing.T) {
+	profile := &atlasCLIProfile{
+		PublicAPIKey:  "profile-public-key",
+		PrivateAPIKey: "profile-private-key",
+		OpsManagerURL: "https://atlas.example.com/",
+		OrgID:         "profile-org",
+		ProjectID:     "profile-project",
+	}
+
+	testCases := map[string]struct {
+		config   Config
+		expected Config
+	}{
+		"empty configuration": {
+			config: Config{},
+			expected: Config{
+				PublicKey:        "profile-public-key",
+				PrivateKey:       "profile-private-key",
+				BaseURL:          "https://atlas.example.com/",
+				DefaultOrgID:     "profile-org",
+				DefaultProjectID: "profile-project",
+			},
+		},
+		"provider arguments take precedence": {
+			config: Config{
+				ClientID:         "mdb_sa_id",
+				ClientSecret:     "mdb_sa_sk",
+				BaseURL:          "https://cloud.mongodb.com/",
+				DefaultProjectID: "provider-project",
+			},
+			expected: Config{
+				ClientID:         "mdb_sa_id",
+				ClientSecret:     "mdb_sa_sk",
+				BaseURL:          "https://cloud.mongodb.com/",
+				DefaultOrgID:     "profile-org",
+				DefaultProjectID: "provider-project",
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := tc.config
+			profile.apply(&config)
+
+			if diff := deep.Equal(tc.expected, config); diff != nil {
+				t.Fatalf("Bad apply result \n got = %#v\nwant = %#v \ndiff = %#v", config, tc.expected, diff)
+			}
+		})
+	}
+
+	config := Config{}
+	(&atlasCLIProfile{Service: atlasCLIServiceCloudGov}).apply(&config)
+	if config.BaseURL != mongoDBGovBaseURL {
+		t.Fatalf("expected the base URL of MongoDB Atlas for Government, got %q", config.BaseURL)
+	}
+}
+
+func TestProviderConfigure_profile(t *testing.T) {
+	mock := newAtlasMockServer(t)
+	projectID := mock.newProject("profile")
+
+	configFile := filepath.Join(t.TempDir(), "config.toml")
+	writeTestFile(t, configFile, fmt.Sprintf(`
+[ci]
+  client_id = %q
+  client_secret = %q
+  ops_manager_url = %q
+  project_id = %q
+`, mockOAuthClientID, mockOAuthClientSecret, mock.URL+"/", projectID))
+
+	for _, env := range []string{"MONGODB_ATLAS_PUBLIC_KEY", "MONGODB_ATLAS_PRIVATE_KEY", "MCLI_PUBLIC_API_KEY", "MCLI_PRIVATE_API_KEY",
+		"MONGODB_ATLAS_CLIENT_ID", "MONGODB_ATLAS_CLIENT_SECRET", "MONGODB_ATLAS_BASE_URL", "MCLI_OPS_MANAGER_URL"} {
+		t.Setenv(env, "")
+	}
+	t.Setenv("MCLI_PROFILE", "ci")
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"profile_config_file": configFile,
+	})
+
+	meta, diags := providerConfigure(context.Background(), d)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	client := meta.(*MongoDBClient)
+	if client.Config.DefaultProjectID != projectID {
+		t.Fatalf("expected the project of the profile to be the default one, got %q", client.Config.DefaultProjectID)
+	}
+
+	if _, _, err := client.Atlas.Projects.GetOneProject(context.Background(), projectID); err != nil {
+		t.Fatalf("expected the base URL and service account of the profile to be used: %s", err)
+	}
+
+	expected := []string{"Bearer mock-token-1"}
+	got := mock.authorizations(http.MethodGet, mockAtlasV1Path+"/groups/"+projectID)
+	if diff := deep.Equal(expected, got); diff != nil {
+		t.Fatalf("expected the service account of the profile to be used \n got = %#v\nwant = %#v \ndiff = %#v", got, expected, diff)
+	}
+
+	d = schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"profile":             "prod",
+		"profile_config_file": configFile,
+	})
+	if _, diags := providerConfigure(context.Background(), d); !diags.HasError() {
+		t.Fatal("expected an error when the profile doesn't exist")
+	}
+}
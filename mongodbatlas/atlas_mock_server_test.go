@@ -0,0 +1,902 @@
+package mongodbatlas
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const (
+	mockAtlasV1Path  = "/api/atlas/v1.0"
+	mockAtlasV15Path = "/api/atlas/v1.5"
+	mockAtlasV2Path  = "/api/atlas/v2"
+	mockGroupPath    = `/groups/([0-9a-f]{24})`
+	mockRealmPath    = "/api/admin/v3.0"
+	mockRealmAppPath = mockRealmPath + mockGroupPath + `/apps/([^/]+)`
+
+	mockOAuthTokenPath    = "/api/oauth/token"
+	mockOAuthClientID     = "mdb_sa_id_mock"
+	mockOAuthClientSecret = "mdb_sa_sk_mock"
+)
+
+// atlasMockServer is an in-memory fake of the subset of the Atlas Admin API used by the
+// provider resources. It understands both the legacy (v1.0/v1.5) and the versioned (v2) paths
+// so the matlas and AtlasV2 clients built by Config.NewClient can be pointed at it.
+type atlasMockServer struct {
+	*httptest.Server
+	t           testing.TB
+	actions     []*mockAction
+	collections []*mockCollection
+	singletons  []*mockSingleton
+	store       map[string]*mockBucket
+	docs        map[string]map[string]interface{}
+	requests    []string
+	bodies      []interface{}
+	auth        []string
+	failures    []*mockFailure
+	tokens      int
+	tokenTTL    int
+	mu          sync.Mutex
+}
+
+// mockFailure makes the next requests matching method and pathPrefix fail with status,
+// e.g. to emulate Atlas rate limiting.
+type mockFailure struct {
+	method     string
+	pathPrefix string
+	retryAfter string
+	status     int
+	remaining  int
+}
+
+// mockCollection describes a REST collection: POST/GET on the collection path and
+// GET/PATCH/PUT/DELETE on collection path + "/" + key.
+type mockCollection struct {
+	collection *regexp.Regexp
+	item       *regexp.Regexp
+	defaults   func(doc map[string]interface{})
+	key        func(doc map[string]interface{}) string
+	check      func(r *http.Request, doc map[string]interface{}) string
+	normalize  func(key string) string
+	bucket     string
+	idField    string
+	notFound   string
+	bareList   bool
+	upsert     bool
+}
+
+// mockAction describes an endpoint acting on the documents of the collections instead of storing its own,
+// e.g. the upgrade of a serverless instance to a cluster.
+type mockAction struct {
+	path   *regexp.Regexp
+	method string
+	serve  func(w http.ResponseWriter, match []string, body interface{})
+}
+
+// mockSingleton describes a document that always exists for its parent, e.g. project settings.
+// DELETE resets it to its defaults.
+type mockSingleton struct {
+	path     *regexp.Regexp
+	defaults func() map[string]interface{}
+}
+
+type mockBucket struct {
+	items map[string]map[string]interface{}
+	order []string
+}
+
+func newAtlasMockServer(tb testing.TB) *atlasMockServer {
+	tb.Helper()
+
+	m := &atlasMockServer{
+		t:     tb,
+		store: make(map[string]*mockBucket),
+		docs:  make(map[string]map[string]interface{}),
+	}
+	m.registerRoutes()
+	m.Server = httptest.NewServer(http.HandlerFunc(m.serveHTTP))
+	tb.Cleanup(m.Close)
+
+	return m
+}
+
+func (m *atlasMockServer) registerRoutes() {
+	m.actions = []*mockAction{
+		{
+			path:   regexp.MustCompile(`^` + mockAtlasV1Path + mockGroupPath + `/clusters/tenantUpgrade$`),
+			method: http.MethodPost,
+			serve:  m.serveTenantUpgrade,
+		},
+		{
+			path:   regexp.MustCompile(`^` + mockRealmPath + `/auth/providers/mongodb-cloud/login$`),
+			method: http.MethodPost,
+			serve:  m.serveRealmLogin,
+		},
+	}
+
+	m.singletons = []*mockSingleton{
+		{
+			path: regexp.MustCompile(`^` + mockAtlasV1Path + mockGroupPath + `/settings$`),
+			defaults: func() map[string]interface{} {
+				return map[string]interface{}{
+					"isCollectDatabaseSpecificsStatisticsEnabled": true,
+					"isDataExplorerEnabled":                       true,
+					"isExtendedStorageSizesEnabled":               false,
+					"isPerformanceAdvisorEnabled":                 true,
+					"isRealtimePerformancePanelEnabled":           true,
+					"isSchemaAdvisorEnabled":                      true,
+				}
+			},
+		},
+		{
+			path: regexp.MustCompile(`^` + mockAtlasV1Path + mockGroupPath + `/clusters/([^/]+)/processArgs$`),
+			defaults: func() map[string]interface{} {
+				return map[string]interface{}{
+					"failIndexKeyTooLong":              false,
+					"javascriptEnabled":                true,
+					"minimumEnabledTlsProtocol":        "TLS1_2",
+					"noTableScan":                      false,
+					"sampleSizeBIConnector":            1000,
+					"sampleRefreshIntervalBIConnector": 0,
+				}
+			},
+		},
+		{
+			path: regexp.MustCompile(`^` + mockAtlasV2Path + mockGroupPath + `/clusters/([^/]+)/backup/schedule$`),
+			defaults: func() map[string]interface{} {
+				return map[string]interface{}{
+					"clusterId":             newMockObjectID(),
+					"referenceHourOfDay":    0,
+					"referenceMinuteOfHour": 0,
+					"restoreWindowDays":     7,
+					"policies": []interface{}{
+						map[string]interface{}{"id": newMockObjectID(), "policyItems": []interface{}{}},
+					},
+				}
+			},
+		},
+	}
+
+	m.collections = []*mockCollection{
+		newMockCollection("searchIndexes", mockAtlasV1Path+mockGroupPath+`/clusters/([^/]+)/fts/indexes`, "indexID", func(doc map[string]interface{}) {
+			setDefault(doc, "status", "STEADY")
+		}),
+		newMockCollection("clusters", mockAtlasV1Path+mockGroupPath+`/clusters`, "id", mockClusterDefaults).
+			withKey(func(doc map[string]interface{}) string { return fmt.Sprint(doc["name"]) }).
+			withNotFound("CLUSTER_NOT_FOUND"),
+		newMockCollection("clusters", mockAtlasV15Path+mockGroupPath+`/clusters`, "id", mockClusterDefaults).
+			withKey(func(doc map[string]interface{}) string { return fmt.Sprint(doc["name"]) }).
+			withNotFound("CLUSTER_NOT_FOUND"),
+		newMockCollection("clusters", mockAtlasV2Path+mockGroupPath+`/clusters`, "id", mockClusterDefaults).
+			withKey(func(doc map[string]interface{}) string { return fmt.Sprint(doc["name"]) }).
+			withCheck(mockClusterVersionCheck).
+			withNotFound("CLUSTER_NOT_FOUND"),
+		newMockCollection("databaseUsers", mockAtlasV1Path+mockGroupPath+`/databaseUsers`, "", mockDatabaseUserDefaults).
+			withKey(mockDatabaseUserKey).
+			withSlashKeys().
+			withNotFound("USERNAME_NOT_FOUND"),
+		newMockCollection("databaseUsers", mockAtlasV2Path+mockGroupPath+`/databaseUsers`, "", mockDatabaseUserDefaults).
+			withKey(mockDatabaseUserKey).
+			withSlashKeys().
+			withNotFound("USERNAME_NOT_FOUND"),
+		newMockCollection("accessList", mockAtlasV1Path+mockGroupPath+`/accessList`, "", mockAccessListDefaults).
+			withKey(func(doc map[string]interface{}) string {
+				if v, ok := doc["cidrBlock"].(string); ok && v != "" {
+					return v
+				}
+				return fmt.Sprint(doc["awsSecurityGroup"])
+			}).
+			withSlashKeys().
+			withNormalize(func(key string) string {
+				if net.ParseIP(key) != nil {
+					return key + "/32"
+				}
+				return key
+			}).
+			withNotFound("ATLAS_NETWORK_PERMISSION_ENTRY_NOT_FOUND"),
+		newMockCollection("accessList", mockAtlasV2Path+mockGroupPath+`/accessList`, "", mockAccessListDefaults).
+			withKey(func(doc map[string]interface{}) string {
+				if v, ok := doc["cidrBlock"].(string); ok && v != "" {
+					return v
+				}
+				return fmt.Sprint(doc["awsSecurityGroup"])
+			}).
+			withSlashKeys().
+			withNormalize(func(key string) string {
+				if net.ParseIP(key) != nil {
+					return key + "/32"
+				}
+				return key
+			}).
+			withNotFound("ATLAS_NETWORK_PERMISSION_ENTRY_NOT_FOUND"),
+		newMockCollection("alertConfigs", mockAtlasV1Path+mockGroupPath+`/alertConfigs`, "id", mockAlertConfigDefaults).
+			withNotFound("ALERT_CONFIG_NOT_FOUND"),
+		newMockCollection("alertConfigs", mockAtlasV2Path+mockGroupPath+`/alertConfigs`, "id", mockAlertConfigDefaults).
+			withNotFound("ALERT_CONFIG_NOT_FOUND"),
+		newMockCollection("containers", mockAtlasV1Path+mockGroupPath+`/containers`, "id", nil),
+		newMockCollection("containers", mockAtlasV2Path+mockGroupPath+`/containers`, "id", nil),
+		newMockCollection("peers", mockAtlasV2Path+mockGroupPath+`/peers`, "id", nil),
+		newMockCollection("processes", mockAtlasV2Path+mockGroupPath+`/processes`, "id", nil),
+		newMockCollection("serverless", mockAtlasV2Path+mockGroupPath+`/serverless`, "id", nil).
+			withKey(func(doc map[string]interface{}) string { return fmt.Sprint(doc["name"]) }).
+			withNotFound("SERVERLESS_INSTANCE_NOT_FOUND"),
+		newMockCollection("providerRegions", mockAtlasV2Path+mockGroupPath+`/clusters/provider/regions`, "", nil).
+			withKey(func(doc map[string]interface{}) string { return fmt.Sprint(doc["provider"]) }),
+		newMockCollection("mongoDBVersions", mockAtlasV2Path+mockGroupPath+`/mongoDBVersions`, "", nil).
+			withKey(func(doc map[string]interface{}) string {
+				return fmt.Sprintf("%s-%s-%s", doc["cloudProvider"], doc["instanceSize"], doc["version"])
+			}),
+		newMockCollection("snapshots", mockAtlasV2Path+mockGroupPath+`/clusters/([^/]+)/backup/snapshots`, "id", func(doc map[string]interface{}) {
+			// on-demand snapshots complete right away.
+			setDefault(doc, "status", "completed")
+		}),
+		newMockCollection("snapshots", mockAtlasV2Path+mockGroupPath+`/clusters/([^/]+)/backup/snapshots/shardedCluster`, "id", nil),
+		newMockCollection("teams", mockAtlasV1Path+mockGroupPath+`/teams`, "", nil).
+			withKey(func(doc map[string]interface{}) string { return fmt.Sprint(doc["teamId"]) }),
+		newMockCollection("apiKeys", mockAtlasV1Path+mockGroupPath+`/apiKeys`, "id", nil),
+		newMockCollection("limits", mockAtlasV2Path+mockGroupPath+`/limits`, "", nil).
+			withKey(func(doc map[string]interface{}) string { return fmt.Sprint(doc["name"]) }).
+			withUpsert().
+			withBareList(),
+		newMockCollection("realmFunctions", mockRealmAppPath+`/functions`, "_id", nil).withBareList(),
+		newMockCollection("realmSecrets", mockRealmAppPath+`/secrets`, "_id", nil).withBareList(),
+		newMockCollection("realmValues", mockRealmAppPath+`/values`, "_id", nil).withBareList(),
+		newMockCollection("realmTriggers", mockRealmAppPath+`/triggers`, "_id", nil).withBareList(),
+		newMockCollection("groups", mockAtlasV1Path+`/groups`, "id", func(doc map[string]interface{}) {
+			setDefault(doc, "created", time.Now().UTC().Format(time.RFC3339))
+			setDefault(doc, "clusterCount", 0)
+		}).withNotFound("GROUP_NOT_FOUND"),
+		newMockCollection("groups", mockAtlasV2Path+`/groups`, "id", func(doc map[string]interface{}) {
+			setDefault(doc, "created", time.Now().UTC().Format(time.RFC3339))
+			setDefault(doc, "clusterCount", 0)
+		}).withNotFound("GROUP_NOT_FOUND"),
+	}
+}
+
+func newMockCollection(bucket, pattern, idField string, defaults func(doc map[string]interface{})) *mockCollection {
+	c := &mockCollection{
+		bucket:     bucket,
+		collection: regexp.MustCompile(`^` + pattern + `$`),
+		item:       regexp.MustCompile(`^` + pattern + `/([^/]+)$`),
+		idField:    idField,
+		defaults:   defaults,
+		notFound:   "RESOURCE_NOT_FOUND",
+	}
+	c.key = func(doc map[string]interface{}) string { return fmt.Sprint(doc[c.idField]) }
+	c.normalize = func(key string) string { return key }
+
+	return c
+}
+
+func (c *mockCollection) withKey(key func(doc map[string]interface{}) string) *mockCollection {
+	c.key = key
+	return c
+}
+
+// withSlashKeys allows item keys containing slashes, e.g. {databaseName}/{username} or a CIDR block.
+func (c *mockCollection) withSlashKeys() *mockCollection {
+	c.item = regexp.MustCompile(strings.TrimSuffix(c.collection.String(), "$") + `/(.+)$`)
+	return c
+}
+
+// withUpsert makes PATCH/PUT on a missing item create it instead of answering 404.
+func (c *mockCollection) withUpsert() *mockCollection {
+	c.upsert = true
+	return c
+}
+
+func (c *mockCollection) withNormalize(normalize func(key string) string) *mockCollection {
+	c.normalize = normalize
+	return c
+}
+
+func (c *mockCollection) withNotFound(errorCode string) *mockCollection {
+	c.notFound = errorCode
+	return c
+}
+
+// withCheck makes GET on an item answer 400 with the error code returned by check, if not empty.
+func (c *mockCollection) withCheck(check func(r *http.Request, doc map[string]interface{}) string) *mockCollection {
+	c.check = check
+	return c
+}
+
+func (c *mockCollection) withBareList() *mockCollection {
+	c.bareList = true
+	return c
+}
+
+func mockClusterDefaults(doc map[string]interface{}) {
+	name := fmt.Sprint(doc["name"])
+	setDefault(doc, "stateName", "IDLE")
+	setDefault(doc, "clusterType", "REPLICASET")
+	setDefault(doc, "mongoDBMajorVersion", "6.0")
+	setDefault(doc, "mongoDBVersion", "6.0.8")
+	setDefault(doc, "versionReleaseSystem", "LTS")
+	setDefault(doc, "backupEnabled", false)
+	setDefault(doc, "pitEnabled", false)
+	setDefault(doc, "paused", false)
+	setDefault(doc, "terminationProtectionEnabled", false)
+	setDefault(doc, "encryptionAtRestProvider", "NONE")
+	setDefault(doc, "rootCertType", "ISRGROOTX1")
+	setDefault(doc, "biConnector", map[string]interface{}{"enabled": false, "readPreference": "secondary"})
+	setDefault(doc, "createDate", time.Now().UTC().Format(time.RFC3339))
+	setDefault(doc, "connectionStrings", map[string]interface{}{
+		"standard":    fmt.Sprintf("mongodb://%s-shard-00-00.mock.mongodb.net:27017", name),
+		"standardSrv": fmt.Sprintf("mongodb+srv://%s.mock.mongodb.net", name),
+	})
+
+	if specs, ok := doc["replicationSpecs"].([]interface{}); ok {
+		for _, spec := range specs {
+			if s, ok := spec.(map[string]interface{}); ok {
+				setDefault(s, "id", newMockObjectID())
+				setDefault(s, "zoneName", "Zone 1")
+			}
+		}
+	}
+}
+
+// mockClusterVersionCheck rejects reading a cluster sharded independently through the 2023-02-01 version
+// of the API, a cluster is sharded independently when several of its replication specs share a zone.
+func mockClusterVersionCheck(r *http.Request, doc map[string]interface{}) string {
+	if !strings.Contains(r.Header.Get("Accept"), "2023-02-01") {
+		return ""
+	}
+
+	zones := make(map[interface{}]bool)
+	specs, _ := doc["replicationSpecs"].([]interface{})
+	for _, spec := range specs {
+		if s, ok := spec.(map[string]interface{}); ok {
+			if zones[s["zoneName"]] {
+				return "ASYMMETRIC_SHARD_UNSUPPORTED"
+			}
+			zones[s["zoneName"]] = true
+		}
+	}
+
+	return ""
+}
+
+func mockAlertConfigDefaults(doc map[string]interface{}) {
+	now := time.Now().UTC().Format(time.RFC3339)
+	setDefault(doc, "created", now)
+	doc["updated"] = now
+}
+
+func mockDatabaseUserDefaults(doc map[string]interface{}) {
+	delete(doc, "password")
+	setDefault(doc, "x509Type", "NONE")
+	setDefault(doc, "awsIAMType", "NONE")
+	setDefault(doc, "ldapAuthType", "NONE")
+	setDefault(doc, "oidcAuthType", "NONE")
+}
+
+func mockDatabaseUserKey(doc map[string]interface{}) string {
+	return fmt.Sprintf("%s/%s", doc["databaseName"], doc["username"])
+}
+
+func mockAccessListDefaults(doc map[string]interface{}) {
+	if ip, ok := doc["ipAddress"].(string); ok && ip != "" {
+		setDefault(doc, "cidrBlock", ip+"/32")
+	}
+}
+
+func setDefault(doc map[string]interface{}, key string, value interface{}) {
+	if v, ok := doc[key]; !ok || v == nil || v == "" {
+		doc[key] = value
+	}
+}
+
+func newMockObjectID() string {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+
+	return hex.EncodeToString(b)
+}
+
+func (m *atlasMockServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// the versioned SDK trims the trailing slash of the base URL and the legacy client does not.
+	path := strings.ReplaceAll(r.URL.Path, "//", "/")
+	m.requests = append(m.requests, fmt.Sprintf("%s %s", r.Method, path))
+	m.bodies = append(m.bodies, nil)
+	m.auth = append(m.auth, r.Header.Get("Authorization"))
+
+	for _, f := range m.failures {
+		if f.remaining > 0 && f.method == r.Method && strings.HasPrefix(path, f.pathPrefix) {
+			f.remaining--
+			if f.retryAfter != "" {
+				w.Header().Set("Retry-After", f.retryAfter)
+			}
+			m.writeError(w, f.status, http.StatusText(f.status), "injected failure")
+			return
+		}
+	}
+
+	if path == mockOAuthTokenPath {
+		m.serveToken(w, r)
+		return
+	}
+
+	var body interface{}
+	if r.Body != nil {
+		raw, err := io.ReadAll(r.Body)
+		if err != nil {
+			m.writeError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+			return
+		}
+		if len(raw) > 0 {
+			if err := json.Unmarshal(raw, &body); err != nil {
+				m.writeError(w, http.StatusBadRequest, "INVALID_JSON", err.Error())
+				return
+			}
+			// keep the body as sent, the collection defaults may modify the stored document.
+			_ = json.Unmarshal(raw, &m.bodies[len(m.bodies)-1])
+		}
+	}
+
+	for _, a := range m.actions {
+		if match := a.path.FindStringSubmatch(path); match != nil && a.method == r.Method {
+			a.serve(w, match, body)
+			return
+		}
+	}
+
+	for _, s := range m.singletons {
+		if s.path.MatchString(path) {
+			m.serveSingleton(w, r, path, s, body)
+			return
+		}
+	}
+
+	for _, c := range m.collections {
+		if match := c.collection.FindStringSubmatch(path); match != nil {
+			m.serveCollection(w, r, c, c.bucketKey(match[1:]), body)
+			return
+		}
+
+		if match := c.item.FindStringSubmatch(path); match != nil {
+			parents := match[1 : len(match)-1]
+			m.serveItem(w, r, c, c.bucketKey(parents), c.normalize(match[len(match)-1]), body)
+			return
+		}
+	}
+
+	m.writeError(w, http.StatusNotFound, "RESOURCE_NOT_FOUND", fmt.Sprintf("no mock route for %s %s", r.Method, path))
+}
+
+// serveTenantUpgrade upgrades the serverless instance named in the request to a single region cluster.
+func (m *atlasMockServer) serveTenantUpgrade(w http.ResponseWriter, match []string, body interface{}) {
+	request, _ := body.(map[string]interface{})
+	groupID, name := match[1], fmt.Sprint(request["name"])
+
+	if m.bucket("serverless:" + groupID).items[name] == nil {
+		m.writeError(w, http.StatusNotFound, "CLUSTER_NOT_FOUND", fmt.Sprintf("serverless %s not found", name))
+		return
+	}
+	m.remove("serverless:"+groupID, name)
+
+	settings, _ := request["providerSettings"].(map[string]interface{})
+	cluster := map[string]interface{}{
+		"name":             name,
+		"groupId":          groupID,
+		"clusterType":      "REPLICASET",
+		"providerSettings": settings,
+		"replicationSpecs": []interface{}{
+			map[string]interface{}{
+				"numShards": 1,
+				"regionConfigs": []interface{}{
+					map[string]interface{}{
+						"providerName":   settings["providerName"],
+						"regionName":     settings["regionName"],
+						"priority":       7,
+						"electableSpecs": map[string]interface{}{"instanceSize": settings["instanceSizeName"], "nodeCount": 3},
+					},
+				},
+			},
+		},
+	}
+
+	for _, c := range m.collections {
+		if c.bucket == "clusters" {
+			m.writeJSON(w, http.StatusOK, m.insert(c, "clusters:"+groupID, cluster))
+			return
+		}
+	}
+}
+
+// serveRealmLogin answers the login of the Realm client with an access token, whatever the API key.
+func (m *atlasMockServer) serveRealmLogin(w http.ResponseWriter, _ []string, _ interface{}) {
+	m.tokens++
+	m.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": fmt.Sprintf("mock-realm-token-%d", m.tokens),
+		"user_id":      newMockObjectID(),
+	})
+}
+
+// serveToken implements the client credentials grant of the Atlas service accounts.
+func (m *atlasMockServer) serveToken(w http.ResponseWriter, r *http.Request) {
+	clientID, clientSecret, ok := r.BasicAuth()
+	if r.Method != http.MethodPost || r.FormValue("grant_type") != "client_credentials" {
+		m.writeJSON(w, http.StatusBadRequest, map[string]interface{}{"error": "unsupported_grant_type"})
+		return
+	}
+
+	if !ok || clientID != mockOAuthClientID || clientSecret != mockOAuthClientSecret {
+		m.writeJSON(w, http.StatusUnauthorized, map[string]interface{}{"error": "invalid_client"})
+		return
+	}
+
+	ttl := m.tokenTTL
+	if ttl == 0 {
+		ttl = 3600
+	}
+
+	m.tokens++
+	m.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": fmt.Sprintf("mock-token-%d", m.tokens),
+		"token_type":   "Bearer",
+		"expires_in":   ttl,
+	})
+}
+
+// authorizations returns the Authorization headers sent with the requests matching method and a path prefix.
+func (m *atlasMockServer) authorizations(method, pathPrefix string) []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var headers []string
+	for i, r := range m.requests {
+		if strings.HasPrefix(r, method+" "+pathPrefix) {
+			headers = append(headers, m.auth[i])
+		}
+	}
+
+	return headers
+}
+
+func (c *mockCollection) bucketKey(parents []string) string {
+	return c.bucket + ":" + strings.Join(parents, "/")
+}
+
+func (m *atlasMockServer) serveSingleton(w http.ResponseWriter, r *http.Request, path string, s *mockSingleton, body interface{}) {
+	doc, ok := m.docs[path]
+	if !ok {
+		doc = s.defaults()
+		m.docs[path] = doc
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPatch, http.MethodPut:
+		if values, ok := body.(map[string]interface{}); ok {
+			for k, v := range values {
+				doc[k] = v
+			}
+		}
+	case http.MethodDelete:
+		doc = s.defaults()
+		m.docs[path] = doc
+	default:
+		m.writeError(w, http.StatusMethodNotAllowed, "INVALID_METHOD", r.Method)
+		return
+	}
+
+	m.writeJSON(w, http.StatusOK, doc)
+}
+
+func (m *atlasMockServer) serveCollection(w http.ResponseWriter, r *http.Request, c *mockCollection, bucketKey string, body interface{}) {
+	switch r.Method {
+	case http.MethodGet:
+		m.writeList(w, r, c, m.bucket(bucketKey))
+	case http.MethodPost:
+		// some endpoints (access list, teams) take a list of entries and answer with the whole list
+		if entries, ok := body.([]interface{}); ok {
+			for _, entry := range entries {
+				if doc, ok := entry.(map[string]interface{}); ok {
+					m.insert(c, bucketKey, doc)
+				}
+			}
+			m.writeList(w, r, c, m.bucket(bucketKey))
+			return
+		}
+
+		doc, ok := body.(map[string]interface{})
+		if !ok {
+			m.writeError(w, http.StatusBadRequest, "INVALID_JSON", "expected a JSON object")
+			return
+		}
+
+		if key := c.prepare(doc); m.bucket(bucketKey).items[key] != nil {
+			m.writeError(w, http.StatusConflict, "DUPLICATE", fmt.Sprintf("%s %s already exists", c.bucket, key))
+			return
+		}
+
+		m.writeJSON(w, http.StatusCreated, m.insert(c, bucketKey, doc))
+	default:
+		m.writeError(w, http.StatusMethodNotAllowed, "INVALID_METHOD", r.Method)
+	}
+}
+
+func (m *atlasMockServer) serveItem(w http.ResponseWriter, r *http.Request, c *mockCollection, bucketKey, key string, body interface{}) {
+	b := m.bucket(bucketKey)
+
+	doc, ok := b.items[key]
+	if !ok && !(c.upsert && (r.Method == http.MethodPatch || r.Method == http.MethodPut)) {
+		m.writeError(w, http.StatusNotFound, c.notFound, fmt.Sprintf("%s %s not found", c.bucket, key))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if c.check != nil {
+			if errorCode := c.check(r, doc); errorCode != "" {
+				m.writeError(w, http.StatusBadRequest, errorCode, fmt.Sprintf("%s %s can't be read with this version of the API", c.bucket, key))
+				return
+			}
+		}
+		m.writeJSON(w, http.StatusOK, doc)
+	case http.MethodPatch, http.MethodPut:
+		values, _ := body.(map[string]interface{})
+		if !ok {
+			m.writeJSON(w, http.StatusOK, m.insert(c, bucketKey, values))
+			return
+		}
+		if r.Method == http.MethodPut {
+			for k := range doc {
+				if k != c.idField {
+					delete(doc, k)
+				}
+			}
+		}
+		for k, v := range values {
+			doc[k] = v
+		}
+		if c.defaults != nil {
+			c.defaults(doc)
+		}
+		m.writeJSON(w, http.StatusOK, doc)
+	case http.MethodDelete:
+		m.remove(bucketKey, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		m.writeError(w, http.StatusMethodNotAllowed, "INVALID_METHOD", r.Method)
+	}
+}
+
+// prepare assigns the generated ID and the collection defaults to doc and returns its key.
+func (c *mockCollection) prepare(doc map[string]interface{}) string {
+	if c.idField != "" {
+		setDefault(doc, c.idField, newMockObjectID())
+	}
+	if c.defaults != nil {
+		c.defaults(doc)
+	}
+
+	return c.key(doc)
+}
+
+func (m *atlasMockServer) bucket(key string) *mockBucket {
+	b, ok := m.store[key]
+	if !ok {
+		b = &mockBucket{items: make(map[string]map[string]interface{})}
+		m.store[key] = b
+	}
+
+	return b
+}
+
+func (m *atlasMockServer) insert(c *mockCollection, bucketKey string, doc map[string]interface{}) map[string]interface{} {
+	if doc == nil {
+		doc = map[string]interface{}{}
+	}
+
+	b := m.bucket(bucketKey)
+	key := c.prepare(doc)
+	if _, exists := b.items[key]; !exists {
+		b.order = append(b.order, key)
+	}
+	b.items[key] = doc
+
+	return doc
+}
+
+func (m *atlasMockServer) remove(bucketKey, key string) {
+	b := m.bucket(bucketKey)
+	delete(b.items, key)
+
+	for i, k := range b.order {
+		if k == key {
+			b.order = append(b.order[:i], b.order[i+1:]...)
+			break
+		}
+	}
+}
+
+func (m *atlasMockServer) writeList(w http.ResponseWriter, r *http.Request, c *mockCollection, b *mockBucket) {
+	results := make([]interface{}, 0, len(b.order))
+	for _, k := range b.order {
+		results = append(results, b.items[k])
+	}
+
+	if c.bareList {
+		m.writeJSON(w, http.StatusOK, results)
+		return
+	}
+
+	m.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"links": []map[string]interface{}{
+			{"rel": "self", "href": fmt.Sprintf("%s%s?pageNum=1&itemsPerPage=%d", m.URL, r.URL.Path, len(results)+1)},
+		},
+		"results":    results,
+		"totalCount": len(results),
+	})
+}
+
+func (m *atlasMockServer) writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		m.t.Errorf("mock atlas server: error encoding response: %s", err)
+	}
+}
+
+func (m *atlasMockServer) writeError(w http.ResponseWriter, status int, errorCode, detail string) {
+	m.writeJSON(w, status, map[string]interface{}{
+		"error":     status,
+		"errorCode": errorCode,
+		"detail":    detail,
+		"reason":    http.StatusText(status),
+	})
+}
+
+// seed stores a document directly in the collection served under path, e.g.
+// seed("/api/atlas/v1.5/groups/<id>/clusters", cluster). It returns the stored document.
+func (m *atlasMockServer) seed(path string, doc map[string]interface{}) map[string]interface{} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, c := range m.collections {
+		if match := c.collection.FindStringSubmatch(path); match != nil {
+			return m.insert(c, c.bucketKey(match[1:]), doc)
+		}
+	}
+
+	m.t.Fatalf("mock atlas server: no collection for %s", path)
+
+	return nil
+}
+
+// get returns the document stored under path or nil if it does not exist.
+func (m *atlasMockServer) get(path string) map[string]interface{} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, c := range m.collections {
+		if match := c.item.FindStringSubmatch(path); match != nil {
+			parents := match[1 : len(match)-1]
+			return m.bucket(c.bucketKey(parents)).items[c.normalize(match[len(match)-1])]
+		}
+	}
+
+	return nil
+}
+
+// requestCount returns how many requests matched method and a path prefix.
+func (m *atlasMockServer) requestCount(method, pathPrefix string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	count := 0
+	for _, r := range m.requests {
+		if strings.HasPrefix(r, method+" "+pathPrefix) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// requestBodies returns the decoded bodies of the requests that matched method and a path prefix, in order,
+// e.g. to check values Atlas never returns such as passwords.
+func (m *atlasMockServer) requestBodies(method, pathPrefix string) []interface{} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var bodies []interface{}
+	for i, r := range m.requests {
+		if strings.HasPrefix(r, method+" "+pathPrefix) {
+			bodies = append(bodies, m.bodies[i])
+		}
+	}
+
+	return bodies
+}
+
+// failNext makes the next times requests matching method and pathPrefix fail with status,
+// retryAfter is sent as the Retry-After header when not empty.
+func (m *atlasMockServer) failNext(method, pathPrefix string, status, times int, retryAfter string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.failures = append(m.failures, &mockFailure{
+		method:     method,
+		pathPrefix: pathPrefix,
+		status:     status,
+		remaining:  times,
+		retryAfter: retryAfter,
+	})
+}
+
+// newProject creates a project in the mock and returns its ID.
+func (m *atlasMockServer) newProject(name string) string {
+	return m.seed(mockAtlasV1Path+"/groups", map[string]interface{}{
+		"name":  name,
+		"orgId": newMockObjectID(),
+	})["id"].(string)
+}
+
+// client returns a MongoDBClient configured against the mock server, configure can
+// adjust the remaining settings of the Config before the client is created.
+func (m *atlasMockServer) client(configure ...func(*Config)) *MongoDBClient {
+	m.t.Helper()
+
+	config := Config{
+		PublicKey:    "mock-public-key",
+		PrivateKey:   "mock-private-key",
+		BaseURL:      m.URL + "/",
+		RealmBaseURL: m.URL + mockRealmPath + "/",
+	}
+	for _, f := range configure {
+		f(&config)
+	}
+
+	client, diags := config.NewClient(context.Background())
+	if diags.HasError() {
+		m.t.Fatalf("mock atlas server: error creating client: %v", diags)
+	}
+
+	return client.(*MongoDBClient)
+}
+
+// testMockResourceUnitTest runs a resource.UnitTest against the mock server. Unlike the TestAcc
+// tests it needs no Atlas credentials, only a terraform CLI (from PATH, TF_ACC_TERRAFORM_PATH
+// or TF_ACC_TERRAFORM_VERSION).
+func testMockResourceUnitTest(t *testing.T, m *atlasMockServer, c resource.TestCase) {
+	t.Helper()
+
+	if _, err := exec.LookPath("terraform"); err != nil &&
+		os.Getenv("TF_ACC_TERRAFORM_PATH") == "" && os.Getenv("TF_ACC_TERRAFORM_VERSION") == "" {
+		t.Skip("terraform CLI must be available to run unit tests against the mock Atlas server")
+	}
+
+	t.Setenv("MONGODB_ATLAS_PUBLIC_KEY", "mock-public-key")
+	t.Setenv("MONGODB_ATLAS_PRIVATE_KEY", "mock-private-key")
+	t.Setenv("MONGODB_ATLAS_BASE_URL", m.URL+"/")
+	t.Setenv("MONGODB_REALM_BASE_URL", m.URL+mockRealmPath+"/")
+
+	c.ProviderFactories = map[string]func() (*schema.Provider, error){
+		ProviderNameMongoDBAtlas: func() (*schema.Provider, error) { return Provider(), nil },
+	}
+	resource.UnitTest(t, c)
+}
@@ -5,6 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
@@ -15,19 +18,36 @@ import (
 	matlasClient "go.mongodb.org/atlas/mongodbatlas"
 	realmAuth "go.mongodb.org/realm/auth"
 	"go.mongodb.org/realm/realm"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
 )
 
-const ToolName = "terraform-provider-mongodbatlas"
+const (
+	ToolName                = "terraform-provider-mongodbatlas"
+	defaultAtlasBaseURL     = "https://cloud.mongodb.com/"
+	serviceAccountTokenPath = "/api/oauth/token"
+)
 
 var userAgent = fmt.Sprintf("%s/%s", ToolName, version.ProviderVersion)
 
 // Config contains the configurations needed to use SDKs
 type Config struct {
-	AssumeRole   *AssumeRole
-	PublicKey    string
-	PrivateKey   string
-	BaseURL      string
-	RealmBaseURL string
+	AssumeRole            *AssumeRole
+	rateLimiter           *rateLimitTransport
+	PublicKey             string
+	PrivateKey            string
+	ClientID              string
+	ClientSecret          string
+	BaseURL               string
+	RealmBaseURL          string
+	MaxRetries            int
+	RetryWaitMin          time.Duration
+	RetryWaitMax          time.Duration
+	RequestsPerMinute     int
+	MaxConcurrentRequests int
+	DefaultOrgID          string
+	DefaultProjectID      string
+	DefaultLabels         []matlasClient.Label
 }
 
 // MongoDBClient contains the mongodbatlas clients and configurations
@@ -39,17 +59,25 @@ type MongoDBClient struct {
 
 // NewClient func...
 func (c *Config) NewClient(ctx context.Context) (interface{}, diag.Diagnostics) {
-	// setup a transport to handle digest
-	transport := digest.NewTransport(cast.ToString(c.PublicKey), cast.ToString(c.PrivateKey))
+	// the limits are shared by every client created from this configuration
+	c.rateLimiter = newRateLimitTransport(c.RequestsPerMinute, c.MaxConcurrentRequests)
+
+	var transport http.RoundTripper
+	if c.usesServiceAccount() {
+		// setup a transport to handle the OAuth access token of the service account
+		transport = c.newServiceAccountTransport()
+	} else {
+		// setup a transport to handle digest
+		digestTransport := digest.NewTransport(cast.ToString(c.PublicKey), cast.ToString(c.PrivateKey))
+		digestTransport.Transport = c.rateLimiter.wrap(digestTransport.Transport)
+		transport = digestTransport
+	}
 
 	// initialize the client
-	client, err := transport.Client()
-	if err != nil {
-		return nil, diag.FromErr(err)
+	client := &http.Client{
+		Transport: c.newRetryTransport(logging.NewTransport("MongoDB Atlas", transport)),
 	}
 
-	client.Transport = logging.NewTransport("MongoDB Atlas", transport)
-
 	optsAtlas := []matlasClient.ClientOpt{matlasClient.SetUserAgent(userAgent)}
 	if c.BaseURL != "" {
 		optsAtlas = append(optsAtlas, matlasClient.SetBaseURL(c.BaseURL))
@@ -75,6 +103,44 @@ func (c *Config) NewClient(ctx context.Context) (interface{}, diag.Diagnostics)
 	return clients, nil
 }
 
+// usesServiceAccount reports whether the clients authenticate with the client credentials of a service account
+// instead of a programmatic API key.
+func (c *Config) usesServiceAccount() bool {
+	return c.ClientID != "" || c.ClientSecret != ""
+}
+
+// newServiceAccountTransport returns a transport adding an OAuth access token to every request. The token is obtained
+// through the client credentials flow, cached and requested again shortly before it expires.
+func (c *Config) newServiceAccountTransport() http.RoundTripper {
+	base := c.rateLimiter.wrap(http.DefaultTransport)
+
+	tokenConfig := clientcredentials.Config{
+		ClientID:     c.ClientID,
+		ClientSecret: c.ClientSecret,
+		TokenURL:     c.serviceAccountTokenURL(),
+		AuthStyle:    oauth2.AuthStyleInHeader,
+	}
+
+	// the token source outlives the provider configuration, so it can't use the configure context.
+	tokenCtx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{
+		Transport: c.newRetryTransport(logging.NewTransport("MongoDB Atlas OAuth", base)),
+	})
+
+	return &oauth2.Transport{
+		Source: tokenConfig.TokenSource(tokenCtx),
+		Base:   base,
+	}
+}
+
+func (c *Config) serviceAccountTokenURL() string {
+	baseURL := c.BaseURL
+	if baseURL == "" {
+		baseURL = defaultAtlasBaseURL
+	}
+
+	return strings.TrimSuffix(baseURL, "/") + serviceAccountTokenPath
+}
+
 func (c *Config) newSDKV2Client(client *http.Client) (*atlasSDK.APIClient, error) {
 	opts := []atlasSDK.ClientModifier{
 		atlasSDK.UseHTTPClient(client),
@@ -91,24 +157,37 @@ func (c *Config) newSDKV2Client(client *http.Client) (*atlasSDK.APIClient, error
 	return sdkv2, nil
 }
 
+// newRetryTransport wraps next so that rate limited and transient failed requests are retried
+// following the retry settings of the provider, it's shared by the Atlas, Atlas SDK and Realm clients.
+func (c *Config) newRetryTransport(next http.RoundTripper) http.RoundTripper {
+	return newRetryTransport(next, c.MaxRetries, c.RetryWaitMin, c.RetryWaitMax)
+}
+
 func (c *MongoDBClient) GetRealmClient(ctx context.Context) (*realm.Client, error) {
 	// Realm
 	if c.Config.PublicKey == "" && c.Config.PrivateKey == "" {
-		return nil, errors.New("please set `public_key` and `private_key` in order to use the realm client")
+		return nil, errors.New("please set `public_key` and `private_key` in order to use the realm client, service accounts are not supported by the realm client")
 	}
 
 	optsRealm := []realm.ClientOpt{realm.SetUserAgent(userAgent)}
+	authConfig := realmAuth.NewConfig(nil)
 	if c.Config.BaseURL != "" && c.Config.RealmBaseURL != "" {
 		optsRealm = append(optsRealm, realm.SetBaseURL(c.Config.RealmBaseURL))
+
+		// the login endpoint is served by the same Realm Admin API as the other requests.
+		authURL, err := url.Parse(strings.TrimSuffix(c.Config.RealmBaseURL, "/") + "/auth/providers/mongodb-cloud/login")
+		if err != nil {
+			return nil, err
+		}
+		authConfig.AuthURL = authURL
 	}
-	authConfig := realmAuth.NewConfig(nil)
 	token, err := authConfig.NewTokenFromCredentials(ctx, c.Config.PublicKey, c.Config.PrivateKey)
 	if err != nil {
 		return nil, err
 	}
 
 	clientRealm := realmAuth.NewClient(realmAuth.BasicTokenSource(token))
-	clientRealm.Transport = logging.NewTransport("MongoDB Realm", clientRealm.Transport)
+	clientRealm.Transport = c.Config.newRetryTransport(logging.NewTransport("MongoDB Realm", c.Config.rateLimiter.wrap(clientRealm.Transport)))
 
 	// Initialize the MongoDB Realm API Client.
 	realmClient, err := realm.New(clientRealm, optsRealm...)
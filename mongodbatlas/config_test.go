@@ -0,0 +1,101 @@
+package mongodbatlas
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestConfigNewClient_serviceAccount(t *testing.T) {
+	mock := newAtlasMockServer(t)
+	projectID := mock.newProject("service-account")
+
+	client := mock.client(func(c *Config) {
+		c.PublicKey = ""
+		c.PrivateKey = ""
+		c.ClientID = mockOAuthClientID
+		c.ClientSecret = mockOAuthClientSecret
+	})
+
+	for i := 0; i < 2; i++ {
+		if _, _, err := client.Atlas.Projects.GetOneProject(context.Background(), projectID); err != nil {
+			t.Fatalf("unexpected error from the Atlas client: %s", err)
+		}
+
+		if _, _, err := client.AtlasV2.ProjectsApi.GetProject(context.Background(), projectID).Execute(); err != nil {
+			t.Fatalf("unexpected error from the Atlas SDK client: %s", err)
+		}
+	}
+
+	if got := mock.requestCount(http.MethodPost, mockOAuthTokenPath); got != 1 {
+		t.Fatalf("expected the access token to be requested once and cached, got %d token requests", got)
+	}
+
+	expected := []string{"Bearer mock-token-1", "Bearer mock-token-1"}
+	for _, path := range []string{mockAtlasV1Path + "/groups/" + projectID, mockAtlasV2Path + "/groups/" + projectID} {
+		got := mock.authorizations(http.MethodGet, path)
+		if diff := deep.Equal(expected, got); diff != nil {
+			t.Fatalf("Bad Authorization headers for %s \n got = %#v\nwant = %#v \ndiff = %#v", path, got, expected, diff)
+		}
+	}
+}
+
+func TestConfigNewClient_serviceAccountTokenRefresh(t *testing.T) {
+	mock := newAtlasMockServer(t)
+	projectID := mock.newProject("service-account-refresh")
+	// tokens expiring that soon are refreshed before every request.
+	mock.tokenTTL = 1
+
+	client := mock.client(func(c *Config) {
+		c.ClientID = mockOAuthClientID
+		c.ClientSecret = mockOAuthClientSecret
+	})
+
+	for i := 0; i < 2; i++ {
+		if _, _, err := client.Atlas.Projects.GetOneProject(context.Background(), projectID); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	expected := []string{"Bearer mock-token-1", "Bearer mock-token-2"}
+	got := mock.authorizations(http.MethodGet, mockAtlasV1Path+"/groups/"+projectID)
+	if diff := deep.Equal(expected, got); diff != nil {
+		t.Fatalf("Bad Authorization headers \n got = %#v\nwant = %#v \ndiff = %#v", got, expected, diff)
+	}
+}
+
+func TestConfigNewClient_serviceAccountInvalidCredentials(t *testing.T) {
+	mock := newAtlasMockServer(t)
+	projectID := mock.newProject("service-account-invalid")
+
+	client := mock.client(func(c *Config) {
+		c.ClientID = mockOAuthClientID
+		c.ClientSecret = "wrong"
+	})
+
+	if _, _, err := client.Atlas.Projects.GetOneProject(context.Background(), projectID); err == nil {
+		t.Fatal("expected an error when the client credentials are rejected")
+	}
+
+	if got := mock.requestCount(http.MethodGet, mockAtlasV1Path+"/groups/"+projectID); got != 0 {
+		t.Fatalf("expected no request to be sent without an access token, got %d", got)
+	}
+}
+
+func TestConfigServiceAccountTokenURL(t *testing.T) {
+	testCases := map[string]string{
+		"":                               "https://cloud.mongodb.com/api/oauth/token",
+		"https://cloud.mongodbgov.com":   "https://cloud.mongodbgov.com/api/oauth/token",
+		"https://cloud-dev.mongodb.com/": "https://cloud-dev.mongodb.com/api/oauth/token",
+		"http://localhost:8080/":         "http://localhost:8080/api/oauth/token",
+	}
+
+	for baseURL, expected := range testCases {
+		config := Config{BaseURL: baseURL}
+		if got := config.serviceAccountTokenURL(); got != expected {
+			t.Errorf("serviceAccountTokenURL() with base URL %q = %q, want %q", baseURL, got, expected)
+		}
+	}
+}
@@ -0,0 +1,471 @@
+package mongodbatlas
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	credentialsFileFormatJSON = "json"
+	credentialsFileFormatINI  = "ini"
+	defaultCredentialsProfile = "default"
+	defaultVaultMount         = "secret"
+)
+
+// credentialProvider resolves the credentials the provider authenticates with from a source external
+// to the provider configuration.
+type credentialProvider interface {
+	retrieve(ctx context.Context) (*SecretData, error)
+	String() string
+}
+
+func credentialsProviderSchema() *schema.Schema {
+	sources := []string{"credentials_provider.0.vault", "credentials_provider.0.file", "credentials_provider.0.env"}
+
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: []string{"assume_role"},
+		Description:   "External source of the API key or service account credentials",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"vault": {
+					Type:         schema.TypeList,
+					Optional:     true,
+					MaxItems:     1,
+					ExactlyOneOf: sources,
+					Description:  "Read the credentials from a HashiCorp Vault KV secret",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"address": {
+								Type:        schema.TypeString,
+								Optional:    true,
+								Description: "Address of the Vault server, defaults to the VAULT_ADDR environment variable",
+							},
+							"token": {
+								Type:        schema.TypeString,
+								Optional:    true,
+								Sensitive:   true,
+								Description: "Vault token, defaults to the VAULT_TOKEN environment variable",
+							},
+							"namespace": {
+								Type:        schema.TypeString,
+								Optional:    true,
+								Description: "Vault Enterprise namespace, defaults to the VAULT_NAMESPACE environment variable",
+							},
+							"mount": {
+								Type:        schema.TypeString,
+								Optional:    true,
+								Default:     defaultVaultMount,
+								Description: "Path where the KV secrets engine is mounted",
+							},
+							"path": {
+								Type:        schema.TypeString,
+								Required:    true,
+								Description: "Path of the secret within the KV secrets engine",
+							},
+							"kv_version": {
+								Type:         schema.TypeInt,
+								Optional:     true,
+								Default:      2,
+								ValidateFunc: validation.IntInSlice([]int{1, 2}),
+								Description:  "Version of the KV secrets engine",
+							},
+						},
+					},
+				},
+				"file": {
+					Type:         schema.TypeList,
+					Optional:     true,
+					MaxItems:     1,
+					ExactlyOneOf: sources,
+					Description:  "Read the credentials from a profile of a local JSON or INI credentials file",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"path": {
+								Type:        schema.TypeString,
+								Required:    true,
+								Description: "Path of the credentials file",
+							},
+							"profile": {
+								Type:        schema.TypeString,
+								Optional:    true,
+								Default:     defaultCredentialsProfile,
+								Description: "Name of the profile holding the credentials",
+							},
+							"format": {
+								Type:         schema.TypeString,
+								Optional:     true,
+								ValidateFunc: validation.StringInSlice([]string{credentialsFileFormatJSON, credentialsFileFormatINI}, false),
+								Description:  "Format of the credentials file, by default json for files with the .json extension and ini otherwise",
+							},
+						},
+					},
+				},
+				"env": {
+					Type:         schema.TypeList,
+					Optional:     true,
+					MaxItems:     1,
+					ExactlyOneOf: sources,
+					Description:  "Read the credentials from environment variables",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"public_key_var": {
+								Type:     schema.TypeString,
+								Optional: true,
+								Default:  "MONGODB_ATLAS_PUBLIC_KEY",
+							},
+							"private_key_var": {
+								Type:     schema.TypeString,
+								Optional: true,
+								Default:  "MONGODB_ATLAS_PRIVATE_KEY",
+							},
+							"client_id_var": {
+								Type:     schema.TypeString,
+								Optional: true,
+								Default:  "MONGODB_ATLAS_CLIENT_ID",
+							},
+							"client_secret_var": {
+								Type:     schema.TypeString,
+								Optional: true,
+								Default:  "MONGODB_ATLAS_CLIENT_SECRET",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// expandCredentialProvider returns the credential provider selected by the credentials_provider block, nil when it isn't set.
+func expandCredentialProvider(tfList []interface{}) credentialProvider {
+	if len(tfList) == 0 || tfList[0] == nil {
+		return nil
+	}
+
+	tfMap := tfList[0].(map[string]interface{})
+
+	if v, ok := tfMap["vault"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		vault := v[0].(map[string]interface{})
+		return &vaultCredentialProvider{
+			address:   valueOrEnv(vault["address"].(string), "VAULT_ADDR"),
+			token:     valueOrEnv(vault["token"].(string), "VAULT_TOKEN"),
+			namespace: valueOrEnv(vault["namespace"].(string), "VAULT_NAMESPACE"),
+			mount:     vault["mount"].(string),
+			path:      vault["path"].(string),
+			kvVersion: vault["kv_version"].(int),
+		}
+	}
+
+	if v, ok := tfMap["file"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		file := v[0].(map[string]interface{})
+		return &fileCredentialProvider{
+			path:    file["path"].(string),
+			profile: file["profile"].(string),
+			format:  file["format"].(string),
+		}
+	}
+
+	if v, ok := tfMap["env"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		env := v[0].(map[string]interface{})
+		return &envCredentialProvider{
+			publicKeyVar:    env["public_key_var"].(string),
+			privateKeyVar:   env["private_key_var"].(string),
+			clientIDVar:     env["client_id_var"].(string),
+			clientSecretVar: env["client_secret_var"].(string),
+		}
+	}
+
+	return nil
+}
+
+// configureCredentials replaces the credentials of config with the ones resolved by provider.
+func configureCredentials(ctx context.Context, config *Config, provider credentialProvider) error {
+	secretData, err := provider.retrieve(ctx)
+	if err != nil {
+		return fmt.Errorf("error reading credentials from %s: %w", provider, err)
+	}
+
+	if secretData.PublicKey == "" && secretData.PrivateKey == "" && secretData.ClientID == "" && secretData.ClientSecret == "" {
+		return fmt.Errorf("no credentials found in %s", provider)
+	}
+
+	config.PublicKey = secretData.PublicKey
+	config.PrivateKey = secretData.PrivateKey
+	config.ClientID = secretData.ClientID
+	config.ClientSecret = secretData.ClientSecret
+
+	return nil
+}
+
+func valueOrEnv(value, envVar string) string {
+	if value != "" {
+		return value
+	}
+
+	return os.Getenv(envVar)
+}
+
+// awsSecretsManagerCredentialProvider reads the API key or the service account from an AWS Secrets Manager secret
+// after assuming the role of the assume_role block.
+type awsSecretsManagerCredentialProvider struct {
+	config             *Config
+	secret             string
+	region             string
+	awsAccessKeyID     string
+	awsSecretAccessKey string
+	awsSessionToken    string
+	endpoint           string
+}
+
+func (p *awsSecretsManagerCredentialProvider) String() string {
+	return fmt.Sprintf("AWS Secrets Manager secret %s", p.secret)
+}
+
+func (p *awsSecretsManagerCredentialProvider) retrieve(ctx context.Context) (*SecretData, error) {
+	config, err := configureCredentialsSTS(p.config, p.secret, p.region, p.awsAccessKeyID, p.awsSecretAccessKey, p.awsSessionToken, p.endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return secretDataFromConfig(&config), nil
+}
+
+func secretDataFromConfig(config *Config) *SecretData {
+	return &SecretData{
+		PublicKey:    config.PublicKey,
+		PrivateKey:   config.PrivateKey,
+		ClientID:     config.ClientID,
+		ClientSecret: config.ClientSecret,
+	}
+}
+
+// vaultCredentialProvider reads the credentials from a secret of a Vault KV secrets engine (version 1 or 2).
+// The secret holds the same keys as the AWS Secrets Manager secret: public_key and private_key, or client_id and client_secret.
+type vaultCredentialProvider struct {
+	address   string
+	token     string
+	namespace string
+	mount     string
+	path      string
+	kvVersion int
+}
+
+func (p *vaultCredentialProvider) String() string {
+	return fmt.Sprintf("vault secret %s/%s", p.mount, p.path)
+}
+
+func (p *vaultCredentialProvider) retrieve(ctx context.Context) (*SecretData, error) {
+	if p.address == "" {
+		return nil, errors.New("`address` or the VAULT_ADDR environment variable must be set")
+	}
+
+	if p.token == "" {
+		return nil, errors.New("`token` or the VAULT_TOKEN environment variable must be set")
+	}
+
+	secretPath := strings.Trim(p.path, "/")
+	if p.kvVersion == 2 {
+		secretPath = "data/" + secretPath
+	}
+
+	url := fmt.Sprintf("%s/v1/%s/%s", strings.TrimSuffix(p.address, "/"), strings.Trim(p.mount, "/"), secretPath)
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("X-Vault-Token", p.token)
+	if p.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.namespace)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("vault returned status %d", resp.StatusCode)
+	}
+
+	var secret struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&secret); err != nil {
+		return nil, err
+	}
+
+	data := secret.Data
+	if p.kvVersion == 2 {
+		// the KV version 2 engine wraps the secret with its metadata.
+		var versioned struct {
+			Data json.RawMessage `json:"data"`
+		}
+		if err := json.Unmarshal(secret.Data, &versioned); err != nil {
+			return nil, err
+		}
+		data = versioned.Data
+	}
+
+	var secretData SecretData
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &secretData); err != nil {
+			return nil, err
+		}
+	}
+
+	return &secretData, nil
+}
+
+// fileCredentialProvider reads the credentials from a named profile of a local credentials file, either JSON:
+//
+//	{"default": {"public_key": "...", "private_key": "..."}}
+//
+// or INI:
+//
+//	[default]
+//	public_key  = ...
+//	private_key = ...
+type fileCredentialProvider struct {
+	path    string
+	profile string
+	format  string
+}
+
+func (p *fileCredentialProvider) String() string {
+	return fmt.Sprintf("profile %q of credentials file %s", p.profile, p.path)
+}
+
+func (p *fileCredentialProvider) retrieve(ctx context.Context) (*SecretData, error) {
+	path, err := expandHomeDir(p.path)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	format := p.format
+	if format == "" {
+		format = credentialsFileFormatINI
+		if strings.EqualFold(filepath.Ext(path), ".json") {
+			format = credentialsFileFormatJSON
+		}
+	}
+
+	var profiles map[string]SecretData
+	if format == credentialsFileFormatJSON {
+		err = json.Unmarshal(content, &profiles)
+	} else {
+		profiles, err = parseINICredentials(content)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	secretData, ok := profiles[p.profile]
+	if !ok {
+		return nil, fmt.Errorf("profile %q not found", p.profile)
+	}
+
+	return &secretData, nil
+}
+
+// parseINICredentials parses the sections of an INI file as credential profiles.
+func parseINICredentials(content []byte) (map[string]SecretData, error) {
+	profiles := make(map[string]SecretData)
+	profile := ""
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(text, "[") && strings.HasSuffix(text, "]") {
+			profile = strings.TrimSpace(text[1 : len(text)-1])
+			profiles[profile] = SecretData{}
+			continue
+		}
+
+		key, value, found := strings.Cut(text, "=")
+		if !found || profile == "" {
+			return nil, fmt.Errorf("invalid line %d", line)
+		}
+
+		secretData := profiles[profile]
+		value = strings.Trim(strings.TrimSpace(value), `"'`)
+		switch strings.TrimSpace(key) {
+		case "public_key":
+			secretData.PublicKey = value
+		case "private_key":
+			secretData.PrivateKey = value
+		case "client_id":
+			secretData.ClientID = value
+		case "client_secret":
+			secretData.ClientSecret = value
+		}
+		profiles[profile] = secretData
+	}
+
+	return profiles, scanner.Err()
+}
+
+func expandHomeDir(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, path[1:]), nil
+}
+
+// envCredentialProvider reads the credentials from environment variables with custom names,
+// e.g. to use different keys per workspace.
+type envCredentialProvider struct {
+	publicKeyVar    string
+	privateKeyVar   string
+	clientIDVar     string
+	clientSecretVar string
+}
+
+func (p *envCredentialProvider) String() string {
+	return "environment variables"
+}
+
+func (p *envCredentialProvider) retrieve(ctx context.Context) (*SecretData, error) {
+	return &SecretData{
+		PublicKey:    os.Getenv(p.publicKeyVar),
+		PrivateKey:   os.Getenv(p.privateKeyVar),
+		ClientID:     os.Getenv(p.clientIDVar),
+		ClientSecret: os.Getenv(p.clientSecretVar),
+	}, nil
+}
@@ -0,0 +1,392 @@
+package mongodbatlas
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// newVaultStandIn serves the KV secrets stored in secrets, keyed by path, for the token "vault-token".
+func newVaultStandIn(t *testing.T, secrets map[string]interface{}) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "vault-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		secret, ok := secrets[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": secret})
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestVaultCredentialProvider(t *testing.T) {
+	vault := newVaultStandIn(t, map[string]interface{}{
+		"/v1/secret/data/atlas/prod": map[string]interface{}{
+			"data":     map[string]interface{}{"public_key": "public", "private_key": "private"},
+			"metadata": map[string]interface{}{"version": 3},
+		},
+		"/v1/kv/atlas/sa": map[string]interface{}{"client_id": "id", "client_secret": "secret"},
+	})
+
+	testCases := map[string]struct {
+		provider    *vaultCredentialProvider
+		expected    *SecretData
+		expectError bool
+	}{
+		"kv version 2": {
+			provider: &vaultCredentialProvider{address: vault.URL, token: "vault-token", mount: "secret", path: "atlas/prod", kvVersion: 2},
+			expected: &SecretData{PublicKey: "public", PrivateKey: "private"},
+		},
+		"kv version 1": {
+			provider: &vaultCredentialProvider{address: vault.URL + "/", token: "vault-token", mount: "kv", path: "/atlas/sa", kvVersion: 1},
+			expected: &SecretData{ClientID: "id", ClientSecret: "secret"},
+		},
+		"missing secret": {
+			provider:    &vaultCredentialProvider{address: vault.URL, token: "vault-token", mount: "secret", path: "atlas/dev", kvVersion: 2},
+			expectError: true,
+		},
+		"invalid token": {
+			provider:    &vaultCredentialProvider{address: vault.URL, token: "wrong", mount: "secret", path: "atlas/prod", kvVersion: 2},
+			expectError: true,
+		},
+		"missing token": {
+			provider:    &vaultCredentialProvider{address: vault.URL, mount: "secret", path: "atlas/prod", kvVersion: 2},
+			expectError: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := tc.provider.retrieve(context.Background())
+			if tc.expectError {
+				if err == nil {
+					t.Fatalf("expected an error, got %#v", got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if diff := deep.Equal(tc.expected, got); diff != nil {
+				t.Fatalf("Bad retrieve return \n got = %#v\nwant = %#v \ndiff = %#v", got, tc.expected, diff)
+			}
+		})
+	}
+}
+
+func TestFileCredentialProvider(t *testing.T) {
+	dir := t.TempDir()
+
+	jsonFile := filepath.Join(dir, "credentials.json")
+	writeTestFile(t, jsonFile, `{
+  "default": {"public_key": "public", "private_key": "private"},
+  "service-account": {"client_id": "id", "client_secret": "secret"}
+}`)
+
+	iniFile := filepath.Join(dir, "credentials")
+	writeTestFile(t, iniFile, `# Atlas credentials
+[default]
+public_key  = public
+private_key = "private"
+
+; service account
+[service-account]
+client_id=id
+client_secret='secret'
+`)
+
+	invalidFile := filepath.Join(dir, "invalid")
+	writeTestFile(t, invalidFile, "public_key = public\n")
+
+	testCases := map[string]struct {
+		provider    *fileCredentialProvider
+		expected    *SecretData
+		expectError bool
+	}{
+		"json default profile": {
+			provider: &fileCredentialProvider{path: jsonFile, profile: "default"},
+			expected: &SecretData{PublicKey: "public", PrivateKey: "private"},
+		},
+		"json named profile": {
+			provider: &fileCredentialProvider{path: jsonFile, profile: "service-account"},
+			expected: &SecretData{ClientID: "id", ClientSecret: "secret"},
+		},
+		"ini default profile": {
+			provider: &fileCredentialProvider{path: iniFile, profile: "default"},
+			expected: &SecretData{PublicKey: "public", PrivateKey: "private"},
+		},
+		"ini named profile": {
+			provider: &fileCredentialProvider{path: iniFile, profile: "service-account"},
+			expected: &SecretData{ClientID: "id", ClientSecret: "secret"},
+		},
+		"explicit format": {
+			provider:    &fileCredentialProvider{path: iniFile, profile: "default", format: credentialsFileFormatJSON},
+			expectError: true,
+		},
+		"missing profile": {
+			provider:    &fileCredentialProvider{path: iniFile, profile: "prod"},
+			expectError: true,
+		},
+		"missing file": {
+			provider:    &fileCredentialProvider{path: filepath.Join(dir, "missing.json"), profile: "default"},
+			expectError: true,
+		},
+		"key outside of a profile": {
+			provider:    &fileCredentialProvider{path: invalidFile, profile: "default"},
+			expectError: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := tc.provider.retrieve(context.Background())
+			if tc.expectError {
+				if err == nil {
+					t.Fatalf("expected an error, got %#v", got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if diff := deep.Equal(tc.expected, got); diff != nil {
+				t.Fatalf("Bad retrieve return \n got = %#v\nwant = %#v \ndiff = %#v", got, tc.expected, diff)
+			}
+		})
+	}
+}
+
+func TestExpandHomeDir(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip(err)
+	}
+
+	testCases := map[string]string{
+		"~/.config/atlas/credentials": filepath.Join(home, ".config/atlas/credentials"),
+		"/etc/atlas/credentials":      "/etc/atlas/credentials",
+		"~other/credentials":          "~other/credentials",
+	}
+
+	for path, expected := range testCases {
+		if got, _ := expandHomeDir(path); got != expected {
+			t.Errorf("expandHomeDir(%q) = %q, want %q", path, got, expected)
+		}
+	}
+}
+
+func TestEnvCredentialProvider(t *testing.T) {
+	t.Setenv("TEAM_ATLAS_PUBLIC_KEY", "public")
+	t.Setenv("TEAM_ATLAS_PRIVATE_KEY", "private")
+
+	provider := &envCredentialProvider{
+		publicKeyVar:    "TEAM_ATLAS_PUBLIC_KEY",
+		privateKeyVar:   "TEAM_ATLAS_PRIVATE_KEY",
+		clientIDVar:     "TEAM_ATLAS_CLIENT_ID",
+		clientSecretVar: "TEAM_ATLAS_CLIENT_SECRET",
+	}
+
+	got, err := provider.retrieve(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := &SecretData{PublicKey: "public", PrivateKey: "private"}
+	if diff := deep.Equal(expected, got); diff != nil {
+		t.Fatalf("Bad retrieve return \n got = %#v\nwant = %#v \ndiff = %#v", got, expected, diff)
+	}
+}
+
+func TestAWSSecretsManagerCredentialProvider_clientOnlySecret(t *testing.T) {
+	secretConfig, err := configureCredentialsFromSecret(&Config{}, `{"client_id": "id", "client_secret": "secret"}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	got := secretDataFromConfig(&secretConfig)
+	expected := &SecretData{ClientID: "id", ClientSecret: "secret"}
+	if diff := deep.Equal(expected, got); diff != nil {
+		t.Fatalf("Bad retrieve return \n got = %#v\nwant = %#v \ndiff = %#v", got, expected, diff)
+	}
+
+	if diags := validateCredentials(&secretConfig); len(diags) != 0 {
+		t.Fatalf("expected the service account of the secret to be valid, got %v", diags)
+	}
+}
+
+func TestExpandCredentialProvider(t *testing.T) {
+	t.Setenv("VAULT_ADDR", "https://vault.example.com:8200")
+	t.Setenv("VAULT_TOKEN", "vault-token")
+
+	testCases := map[string]struct {
+		raw      map[string]interface{}
+		expected credentialProvider
+	}{
+		"not set": {
+			raw: map[string]interface{}{},
+		},
+		"vault with defaults": {
+			raw: map[string]interface{}{
+				"credentials_provider": []interface{}{map[string]interface{}{
+					"vault": []interface{}{map[string]interface{}{"path": "atlas/prod"}},
+				}},
+			},
+			expected: &vaultCredentialProvider{
+				address:   "https://vault.example.com:8200",
+				token:     "vault-token",
+				mount:     "secret",
+				path:      "atlas/prod",
+				kvVersion: 2,
+			},
+		},
+		"file with defaults": {
+			raw: map[string]interface{}{
+				"credentials_provider": []interface{}{map[string]interface{}{
+					"file": []interface{}{map[string]interface{}{"path": "~/.atlas/credentials"}},
+				}},
+			},
+			expected: &fileCredentialProvider{path: "~/.atlas/credentials", profile: "default"},
+		},
+		"env with defaults": {
+			raw: map[string]interface{}{
+				"credentials_provider": []interface{}{map[string]interface{}{
+					"env": []interface{}{map[string]interface{}{"public_key_var": "TEAM_ATLAS_PUBLIC_KEY"}},
+				}},
+			},
+			expected: &envCredentialProvider{
+				publicKeyVar:    "TEAM_ATLAS_PUBLIC_KEY",
+				privateKeyVar:   "MONGODB_ATLAS_PRIVATE_KEY",
+				clientIDVar:     "MONGODB_ATLAS_CLIENT_ID",
+				clientSecretVar: "MONGODB_ATLAS_CLIENT_SECRET",
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, Provider().Schema, tc.raw)
+
+			got := expandCredentialProvider(d.Get("credentials_provider").([]interface{}))
+			if diff := deep.Equal(tc.expected, got); diff != nil {
+				t.Fatalf("Bad expandCredentialProvider return \n got = %#v\nwant = %#v \ndiff = %#v", got, tc.expected, diff)
+			}
+		})
+	}
+}
+
+func TestProviderConfigure_credentialsProvider(t *testing.T) {
+	mock := newAtlasMockServer(t)
+	projectID := mock.newProject("credentials-provider")
+
+	vault := newVaultStandIn(t, map[string]interface{}{
+		"/v1/secret/data/atlas": map[string]interface{}{
+			"data": map[string]interface{}{"client_id": mockOAuthClientID, "client_secret": mockOAuthClientSecret},
+		},
+	})
+
+	t.Setenv("MONGODB_ATLAS_PUBLIC_KEY", "")
+	t.Setenv("MONGODB_ATLAS_PRIVATE_KEY", "")
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"base_url": mock.URL + "/",
+		"credentials_provider": []interface{}{map[string]interface{}{
+			"vault": []interface{}{map[string]interface{}{
+				"address": vault.URL,
+				"token":   "vault-token",
+				"path":    "atlas",
+			}},
+		}},
+	})
+
+	meta, diags := providerConfigure(context.Background(), d)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	client := meta.(*MongoDBClient)
+	if _, _, err := client.Atlas.Projects.GetOneProject(context.Background(), projectID); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []string{"Bearer mock-token-1"}
+	got := mock.authorizations(http.MethodGet, mockAtlasV1Path+"/groups/"+projectID)
+	if diff := deep.Equal(expected, got); diff != nil {
+		t.Fatalf("expected the service account read from vault to be used \n got = %#v\nwant = %#v \ndiff = %#v", got, expected, diff)
+	}
+}
+
+func TestMockProviderCredentialsProvider_file(t *testing.T) {
+	var (
+		mock            = newAtlasMockServer(t)
+		credentialsFile = filepath.Join(t.TempDir(), "credentials")
+		orgID           = newMockObjectID()
+	)
+
+	writeTestFile(t, credentialsFile, fmt.Sprintf("[ci]\nclient_id = %s\nclient_secret = %s\n", mockOAuthClientID, mockOAuthClientSecret))
+
+	testMockResourceUnitTest(t, mock, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					provider "mongodbatlas" {
+						credentials_provider {
+							file {
+								path    = %[1]q
+								profile = "ci"
+							}
+						}
+					}
+
+					resource "mongodbatlas_project" "test" {
+						name   = "test-credentials-provider"
+						org_id = %[2]q
+					}
+				`, credentialsFile, orgID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodbatlas_project.test", "name", "test-credentials-provider"),
+					func(*terraform.State) error {
+						for _, header := range mock.authorizations(http.MethodPost, mockAtlasV1Path+"/groups") {
+							if !strings.HasPrefix(header, "Bearer ") {
+								return fmt.Errorf("expected the service account of the credentials file to be used, got %q", header)
+							}
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
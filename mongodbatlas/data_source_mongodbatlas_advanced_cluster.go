@@ -228,10 +228,11 @@ func dataSourceMongoDBAtlasAdvancedCluster() *schema.Resource {
 func dataSourceMongoDBAtlasAdvancedClusterRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// Get client connection.
 	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 	projectID := d.Get("project_id").(string)
 	clusterName := d.Get("name").(string)
 
-	cluster, resp, err := conn.AdvancedClusters.Get(ctx, projectID, clusterName)
+	cluster, shards, resp, err := getAdvancedCluster(ctx, connV2, projectID, clusterName, false)
 	if err != nil {
 		if resp != nil && resp.StatusCode == http.StatusNotFound {
 			return nil
@@ -240,78 +241,79 @@ func dataSourceMongoDBAtlasAdvancedClusterRead(ctx context.Context, d *schema.Re
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedRead, clusterName, err))
 	}
 
-	if err := d.Set("backup_enabled", cluster.BackupEnabled); err != nil {
+	if err := d.Set("backup_enabled", cluster.GetBackupEnabled()); err != nil {
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedSetting, "backup_enabled", clusterName, err))
 	}
 
-	if err := d.Set("bi_connector_config", flattenBiConnectorConfig(cluster.BiConnector)); err != nil {
+	if err := d.Set("bi_connector_config", flattenAdvancedBiConnectorConfig(cluster.BiConnector)); err != nil {
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedSetting, "bi_connector_config", clusterName, err))
 	}
 
-	if err := d.Set("cluster_type", cluster.ClusterType); err != nil {
+	if err := d.Set("cluster_type", cluster.GetClusterType()); err != nil {
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedSetting, "cluster_type", clusterName, err))
 	}
 
-	if err := d.Set("connection_strings", flattenConnectionStrings(cluster.ConnectionStrings)); err != nil {
+	if err := d.Set("connection_strings", flattenAdvancedConnectionStrings(cluster.ConnectionStrings)); err != nil {
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedSetting, "connection_strings", clusterName, err))
 	}
 
-	if err := d.Set("create_date", cluster.CreateDate); err != nil {
+	if err := d.Set("create_date", timeToString(cluster.CreateDate)); err != nil {
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedSetting, "create_date", clusterName, err))
 	}
 
-	if err := d.Set("disk_size_gb", cluster.DiskSizeGB); err != nil {
+	if err := d.Set("disk_size_gb", cluster.GetDiskSizeGB()); err != nil {
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedSetting, "disk_size_gb", clusterName, err))
 	}
 
-	if err := d.Set("encryption_at_rest_provider", cluster.EncryptionAtRestProvider); err != nil {
+	if err := d.Set("encryption_at_rest_provider", cluster.GetEncryptionAtRestProvider()); err != nil {
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedSetting, "encryption_at_rest_provider", clusterName, err))
 	}
 
-	if err := d.Set("labels", flattenLabels(removeLabel(cluster.Labels, defaultLabel))); err != nil {
+	if err := d.Set("labels", flattenLabels(removeLabel(fromComponentLabels(cluster.Labels), defaultLabel))); err != nil {
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedSetting, "labels", clusterName, err))
 	}
 
-	if err := d.Set("mongo_db_major_version", cluster.MongoDBMajorVersion); err != nil {
+	if err := d.Set("mongo_db_major_version", cluster.GetMongoDBMajorVersion()); err != nil {
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedSetting, "mongo_db_major_version", clusterName, err))
 	}
 
-	if err := d.Set("mongo_db_version", cluster.MongoDBVersion); err != nil {
+	if err := d.Set("mongo_db_version", cluster.GetMongoDBVersion()); err != nil {
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedSetting, "mongo_db_version", clusterName, err))
 	}
 
-	if err := d.Set("name", cluster.Name); err != nil {
+	if err := d.Set("name", cluster.GetName()); err != nil {
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedSetting, "name", clusterName, err))
 	}
 
-	if err := d.Set("paused", cluster.Paused); err != nil {
+	if err := d.Set("paused", cluster.GetPaused()); err != nil {
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedSetting, "paused", clusterName, err))
 	}
 
-	if err := d.Set("pit_enabled", cluster.PitEnabled); err != nil {
+	if err := d.Set("pit_enabled", cluster.GetPitEnabled()); err != nil {
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedSetting, "pit_enabled", clusterName, err))
 	}
 
-	replicationSpecs, err := flattenAdvancedReplicationSpecs(ctx, cluster.ReplicationSpecs, d.Get("replication_specs").(*schema.Set).List(), d, conn)
+	replicationSpecs, err := flattenAdvancedReplicationSpecs(ctx, cluster.ReplicationSpecs, d.Get("replication_specs").(*schema.Set).List(), d, connV2)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedSetting, "replication_specs", clusterName, err))
 	}
+	flattenAdvancedClusterShardDiskSizes(replicationSpecs, shards)
 
 	if err := d.Set("replication_specs", replicationSpecs); err != nil {
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedSetting, "replication_specs", clusterName, err))
 	}
 
-	if err := d.Set("root_cert_type", cluster.RootCertType); err != nil {
+	if err := d.Set("root_cert_type", cluster.GetRootCertType()); err != nil {
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedSetting, "state_name", clusterName, err))
 	}
 
-	if err := d.Set("state_name", cluster.StateName); err != nil {
+	if err := d.Set("state_name", cluster.GetStateName()); err != nil {
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedSetting, "state_name", clusterName, err))
 	}
-	if err := d.Set("termination_protection_enabled", cluster.TerminationProtectionEnabled); err != nil {
+	if err := d.Set("termination_protection_enabled", cluster.GetTerminationProtectionEnabled()); err != nil {
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedSetting, "termination_protection_enabled", clusterName, err))
 	}
-	if err := d.Set("version_release_system", cluster.VersionReleaseSystem); err != nil {
+	if err := d.Set("version_release_system", cluster.GetVersionReleaseSystem()); err != nil {
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedSetting, "version_release_system", clusterName, err))
 	}
 
@@ -327,7 +329,7 @@ func dataSourceMongoDBAtlasAdvancedClusterRead(ctx context.Context, d *schema.Re
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedSetting, "advanced_configuration", clusterName, err))
 	}
 
-	d.SetId(cluster.ID)
+	d.SetId(cluster.GetId())
 
 	return nil
 }
@@ -0,0 +1,367 @@
+package mongodbatlas
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/spf13/cast"
+	"github.com/zclconf/go-cty/cty"
+	"go.mongodb.org/atlas-sdk/v20230201002/admin"
+)
+
+const (
+	errorClusterMigrationRead    = "error converting the cluster (%s) to an advanced cluster: %s"
+	errorClusterMigrationSetting = "error setting `%s` for the conversion of the cluster (%s): %s"
+)
+
+// clusterMigrationAddressFormat matches the address of a resource of a type, in the root module or in a child module.
+const clusterMigrationAddressFormat = `^(module\.[A-Za-z_][A-Za-z0-9_-]*\.)*%s\.([A-Za-z_][A-Za-z0-9_-]*)$`
+
+func dataSourceMongoDBAtlasAdvancedClusterMigration() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMongoDBAtlasAdvancedClusterMigrationRead,
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"cluster_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"from": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringMatch(clusterMigrationAddress("mongodbatlas_cluster"), "must be the address of a mongodbatlas_cluster resource"),
+			},
+			"to": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringMatch(clusterMigrationAddress("mongodbatlas_advanced_cluster"), "must be the address of a mongodbatlas_advanced_cluster resource"),
+			},
+			"resource_hcl": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"import_hcl": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"commands": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceMongoDBAtlasAdvancedClusterMigrationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	projectID := d.Get("project_id").(string)
+	clusterName := d.Get("cluster_name").(string)
+
+	// the cluster is read the way `terraform import` reads a mongodbatlas_cluster, which gives the state the
+	// legacy resource has once it is refreshed.
+	r := resourceMongoDBAtlasCluster()
+	legacy := r.Data(nil)
+	legacy.SetId(fmt.Sprintf("%s-%s", projectID, clusterName))
+
+	if _, err := r.Importer.StateContext(ctx, legacy, meta); err != nil {
+		return diag.FromErr(fmt.Errorf(errorClusterMigrationRead, clusterName, err))
+	}
+
+	if diags := r.ReadWithoutTimeout(ctx, legacy, meta); diags.HasError() {
+		return diags
+	}
+
+	if legacy.Id() == "" {
+		return diag.FromErr(fmt.Errorf(errorClusterMigrationRead, clusterName, "the cluster doesn't exist"))
+	}
+
+	from := d.Get("from").(string)
+	if from == "" {
+		from = "mongodbatlas_cluster." + organizationHCLLabel(clusterName)
+	}
+
+	// by default the advanced cluster takes the place of the cluster, in the same module and with the same label.
+	to := d.Get("to").(string)
+	if to == "" {
+		to = from[:strings.LastIndex(from, "mongodbatlas_cluster.")] + "mongodbatlas_advanced_cluster." + clusterMigrationLabel(from)
+	}
+
+	cluster := convertClusterToAdvancedCluster(legacy)
+	if err := validateAdvancedClusterTopology(cluster.GetClusterType(), cluster.ReplicationSpecs); err != nil {
+		return diag.FromErr(fmt.Errorf(errorClusterMigrationRead, clusterName, err))
+	}
+
+	resourceHCL := hclwrite.NewEmptyFile()
+	resource := resourceHCL.Body().AppendNewBlock("resource", []string{"mongodbatlas_advanced_cluster", clusterMigrationLabel(to)}).Body()
+	resource.SetAttributeValue("project_id", cty.StringVal(projectID))
+	appendAdvancedClusterHcl(resource, cluster)
+	appendBlockWithCtyValues(resource, "advanced_configuration", []string{}, convertAdvancedConfigurationToCtyValues(firstMap(legacy.Get("advanced_configuration"))))
+
+	importID := fmt.Sprintf("%s-%s", projectID, clusterName)
+	importHCL, err := clusterMigrationImportHcl(from, to, importID)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf(errorClusterMigrationRead, clusterName, err))
+	}
+
+	commands := []string{
+		fmt.Sprintf("terraform state rm '%s'", from),
+		fmt.Sprintf("terraform import '%s' '%s'", to, importID),
+	}
+
+	for k, v := range map[string]interface{}{
+		"from":         from,
+		"to":           to,
+		"resource_hcl": string(resourceHCL.Bytes()),
+		"import_hcl":   importHCL,
+		"commands":     commands,
+	} {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(fmt.Errorf(errorClusterMigrationSetting, k, clusterName, err))
+		}
+	}
+
+	d.SetId(encodeStateID(map[string]string{
+		"project_id":   projectID,
+		"cluster_name": clusterName,
+	}))
+
+	return nil
+}
+
+// convertClusterToAdvancedCluster maps the state of a mongodbatlas_cluster resource onto the cluster described the
+// way the mongodbatlas_advanced_cluster resource describes it.
+func convertClusterToAdvancedCluster(d *schema.ResourceData) *admin.AdvancedClusterDescription {
+	cluster := &admin.AdvancedClusterDescription{
+		Name:                         admin.PtrString(d.Get("name").(string)),
+		ClusterType:                  admin.PtrString(d.Get("cluster_type").(string)),
+		BackupEnabled:                admin.PtrBool(d.Get("cloud_backup").(bool) || d.Get("provider_backup_enabled").(bool)),
+		PitEnabled:                   admin.PtrBool(d.Get("pit_enabled").(bool)),
+		Paused:                       admin.PtrBool(d.Get("paused").(bool)),
+		TerminationProtectionEnabled: admin.PtrBool(d.Get("termination_protection_enabled").(bool)),
+		VersionReleaseSystem:         admin.PtrString(d.Get("version_release_system").(string)),
+		EncryptionAtRestProvider:     admin.PtrString(d.Get("encryption_at_rest_provider").(string)),
+	}
+
+	if v := d.Get("mongo_db_major_version").(string); v != "" {
+		cluster.MongoDBMajorVersion = admin.PtrString(formatMongoDBMajorVersion(v))
+	}
+
+	if cluster.GetClusterType() == "" {
+		cluster.ClusterType = admin.PtrString("REPLICASET")
+		if d.Get("num_shards").(int) > 1 {
+			cluster.ClusterType = admin.PtrString("SHARDED")
+		}
+	}
+
+	if v := d.Get("disk_size_gb").(float64); v > 0 {
+		cluster.DiskSizeGB = admin.PtrFloat64(v)
+	}
+
+	if biConnector := firstMap(d.Get("bi_connector_config")); cast.ToBool(biConnector["enabled"]) {
+		cluster.BiConnector = &admin.BiConnector{
+			Enabled:        admin.PtrBool(true),
+			ReadPreference: admin.PtrString(cast.ToString(biConnector["read_preference"])),
+		}
+	}
+
+	if labels, ok := d.Get("labels").(*schema.Set); ok {
+		for _, l := range labels.List() {
+			label := l.(map[string]interface{})
+			cluster.Labels = append(cluster.Labels, admin.ComponentLabel{
+				Key:   admin.PtrString(cast.ToString(label["key"])),
+				Value: admin.PtrString(cast.ToString(label["value"])),
+			})
+		}
+		sort.Slice(cluster.Labels, func(i, j int) bool { return cluster.Labels[i].GetKey() < cluster.Labels[j].GetKey() })
+	}
+
+	specs := d.Get("replication_specs").(*schema.Set).List()
+	if len(specs) == 0 {
+		// without replication_specs, the cluster is described by the region of the provider.
+		specs = []interface{}{
+			map[string]interface{}{
+				"num_shards": d.Get("num_shards"),
+				"regions_config": []interface{}{
+					map[string]interface{}{
+						"region_name":     d.Get("provider_region_name"),
+						"electable_nodes": d.Get("replication_factor"),
+						"priority":        7,
+					},
+				},
+			},
+		}
+	}
+
+	for _, s := range specs {
+		spec := s.(map[string]interface{})
+		replicationSpec := admin.ReplicationSpec{
+			NumShards: admin.PtrInt(cast.ToInt(spec["num_shards"])),
+		}
+		if v := cast.ToString(spec["zone_name"]); v != "" {
+			replicationSpec.ZoneName = admin.PtrString(v)
+		}
+
+		regions, _ := spec["regions_config"].([]interface{})
+		if set, ok := spec["regions_config"].(*schema.Set); ok {
+			regions = set.List()
+		}
+		sort.Slice(regions, func(i, j int) bool {
+			ri, rj := regions[i].(map[string]interface{}), regions[j].(map[string]interface{})
+			if pi, pj := cast.ToInt(ri["priority"]), cast.ToInt(rj["priority"]); pi != pj {
+				return pi > pj
+			}
+			return cast.ToString(ri["region_name"]) < cast.ToString(rj["region_name"])
+		})
+
+		for _, region := range regions {
+			replicationSpec.RegionConfigs = append(replicationSpec.RegionConfigs, convertClusterRegionConfig(d, region.(map[string]interface{})))
+		}
+
+		cluster.ReplicationSpecs = append(cluster.ReplicationSpecs, replicationSpec)
+	}
+
+	sort.SliceStable(cluster.ReplicationSpecs, func(i, j int) bool {
+		return cluster.ReplicationSpecs[i].GetZoneName() < cluster.ReplicationSpecs[j].GetZoneName()
+	})
+
+	return cluster
+}
+
+// convertClusterRegionConfig maps a regions_config of a mongodbatlas_cluster onto a region_configs of a mongodbatlas_advanced_cluster,
+// the hardware and auto-scaling of the legacy cluster being the same in all its regions.
+func convertClusterRegionConfig(d *schema.ResourceData, region map[string]interface{}) admin.CloudRegionConfig {
+	providerName := d.Get("provider_name").(string)
+	instanceSize := d.Get("provider_instance_size_name").(string)
+	regionName, _ := valRegion(region["region_name"])
+
+	regionConfig := admin.CloudRegionConfig{
+		ProviderName: admin.PtrString(providerName),
+		RegionName:   admin.PtrString(strings.ToUpper(regionName)),
+		Priority:     admin.PtrInt(cast.ToInt(region["priority"])),
+	}
+
+	if providerName == "TENANT" {
+		regionConfig.BackingProviderName = admin.PtrString(d.Get("backing_provider_name").(string))
+		regionConfig.ElectableSpecs = &admin.HardwareSpec{InstanceSize: admin.PtrString(instanceSize)}
+
+		return regionConfig
+	}
+
+	hardwareSpec := func(nodeCount int) *admin.HardwareSpec {
+		spec := &admin.HardwareSpec{
+			InstanceSize: admin.PtrString(instanceSize),
+			NodeCount:    admin.PtrInt(nodeCount),
+		}
+		if v := d.Get("provider_volume_type").(string); v != "" {
+			spec.EbsVolumeType = admin.PtrString(v)
+			spec.DiskIOPS = admin.PtrInt(d.Get("provider_disk_iops").(int))
+		}
+
+		return spec
+	}
+
+	if v := cast.ToInt(region["electable_nodes"]); v > 0 {
+		regionConfig.ElectableSpecs = hardwareSpec(v)
+	}
+
+	if v := cast.ToInt(region["read_only_nodes"]); v > 0 {
+		regionConfig.ReadOnlySpecs = dedicatedFromHardwareSpec(hardwareSpec(v))
+	}
+
+	if v := cast.ToInt(region["analytics_nodes"]); v > 0 {
+		regionConfig.AnalyticsSpecs = dedicatedFromHardwareSpec(hardwareSpec(v))
+	}
+
+	regionConfig.AutoScaling = &admin.AdvancedAutoScalingSettings{
+		DiskGB: &admin.DiskGBAutoScaling{Enabled: admin.PtrBool(d.Get("auto_scaling_disk_gb_enabled").(bool))},
+		Compute: &admin.AdvancedComputeAutoScaling{
+			Enabled:          admin.PtrBool(d.Get("auto_scaling_compute_enabled").(bool)),
+			ScaleDownEnabled: admin.PtrBool(d.Get("auto_scaling_compute_scale_down_enabled").(bool)),
+			MinInstanceSize:  admin.PtrString(d.Get("provider_auto_scaling_compute_min_instance_size").(string)),
+			MaxInstanceSize:  admin.PtrString(d.Get("provider_auto_scaling_compute_max_instance_size").(string)),
+		},
+	}
+
+	return regionConfig
+}
+
+// convertAdvancedConfigurationToCtyValues returns the options of advanced_configuration that are set.
+func convertAdvancedConfigurationToCtyValues(advancedConfiguration map[string]interface{}) map[string]cty.Value {
+	values := make(map[string]cty.Value)
+
+	for k, v := range advancedConfiguration {
+		switch v := v.(type) {
+		case bool:
+			values[k] = cty.BoolVal(v)
+		case string:
+			if v != "" {
+				values[k] = cty.StringVal(v)
+			}
+		case int:
+			if v != 0 {
+				values[k] = cty.NumberIntVal(int64(v))
+			}
+		case float64:
+			if v != 0 {
+				values[k] = cty.NumberFloatVal(v)
+			}
+		}
+	}
+
+	return values
+}
+
+// clusterMigrationImportHcl returns the blocks that remove the cluster from the state of the mongodbatlas_cluster
+// resource without destroying it and import it in the mongodbatlas_advanced_cluster resource. moved blocks can't
+// be used since the provider can't move a resource between resource types.
+func clusterMigrationImportHcl(from, to, importID string) (string, error) {
+	fromTraversal, diags := hclsyntax.ParseTraversalAbs([]byte(from), "from", hcl.InitialPos)
+	if diags.HasErrors() {
+		return "", diags
+	}
+
+	toTraversal, diags := hclsyntax.ParseTraversalAbs([]byte(to), "to", hcl.InitialPos)
+	if diags.HasErrors() {
+		return "", diags
+	}
+
+	f := hclwrite.NewEmptyFile()
+	root := f.Body()
+
+	removed := root.AppendNewBlock("removed", nil).Body()
+	removed.SetAttributeTraversal("from", fromTraversal)
+	removed.AppendNewline()
+	removed.AppendNewBlock("lifecycle", nil).Body().SetAttributeValue("destroy", cty.False)
+
+	root.AppendNewline()
+	imported := root.AppendNewBlock("import", nil).Body()
+	imported.SetAttributeTraversal("to", toTraversal)
+	imported.SetAttributeValue("id", cty.StringVal(importID))
+
+	return string(f.Bytes()), nil
+}
+
+func clusterMigrationAddress(resourceType string) *regexp.Regexp {
+	return regexp.MustCompile(fmt.Sprintf(clusterMigrationAddressFormat, regexp.QuoteMeta(resourceType)))
+}
+
+// clusterMigrationLabel returns the label of the resource at address.
+func clusterMigrationLabel(address string) string {
+	return address[strings.LastIndex(address, ".")+1:]
+}
@@ -0,0 +1,418 @@
+package mongodbatlas
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestConvertClusterToAdvancedCluster(t *testing.T) {
+	testCases := map[string]struct {
+		legacy   map[string]interface{}
+		expected string
+	}{
+		"replica set": {
+			legacy: map[string]interface{}{
+				"name":                                            "replica-set",
+				"cluster_type":                                    "REPLICASET",
+				"cloud_backup":                                    true,
+				"mongo_db_major_version":                          "6.0",
+				"provider_name":                                   "AWS",
+				"provider_instance_size_name":                     "M10",
+				"provider_volume_type":                            "STANDARD",
+				"auto_scaling_disk_gb_enabled":                    true,
+				"auto_scaling_compute_enabled":                    true,
+				"auto_scaling_compute_scale_down_enabled":         true,
+				"provider_auto_scaling_compute_min_instance_size": "M10",
+				"provider_auto_scaling_compute_max_instance_size": "M40",
+				"disk_size_gb":                                    40,
+				"labels": []interface{}{
+					map[string]interface{}{"key": "env", "value": "prod"},
+				},
+				"replication_specs": []interface{}{
+					map[string]interface{}{
+						"num_shards": 1,
+						"regions_config": []interface{}{
+							map[string]interface{}{"region_name": "EU_WEST_1", "electable_nodes": 2, "priority": 6, "read_only_nodes": 0, "analytics_nodes": 1},
+							map[string]interface{}{"region_name": "US_EAST_1", "electable_nodes": 3, "priority": 7, "read_only_nodes": 2, "analytics_nodes": 0},
+						},
+					},
+				},
+			},
+			expected: `resource "mongodbatlas_advanced_cluster" "test" {
+  name                           = "replica-set"
+  cluster_type                   = "REPLICASET"
+  mongo_db_major_version         = "6.0"
+  backup_enabled                 = true
+  pit_enabled                    = false
+  termination_protection_enabled = false
+
+  labels {
+    key   = "env"
+    value = "prod"
+  }
+
+  replication_specs {
+    num_shards = 1
+
+    region_configs {
+      provider_name = "AWS"
+      region_name   = "US_EAST_1"
+      priority      = 7
+
+      electable_specs {
+        instance_size = "M10"
+        node_count    = 3
+      }
+
+      read_only_specs {
+        instance_size = "M10"
+        node_count    = 2
+      }
+
+      auto_scaling {
+        compute_enabled            = true
+        compute_max_instance_size  = "M40"
+        compute_min_instance_size  = "M10"
+        compute_scale_down_enabled = true
+        disk_gb_enabled            = true
+      }
+    }
+
+    region_configs {
+      provider_name = "AWS"
+      region_name   = "EU_WEST_1"
+      priority      = 6
+
+      electable_specs {
+        instance_size = "M10"
+        node_count    = 2
+      }
+
+      analytics_specs {
+        instance_size = "M10"
+        node_count    = 1
+      }
+
+      auto_scaling {
+        compute_enabled            = true
+        compute_max_instance_size  = "M40"
+        compute_min_instance_size  = "M10"
+        compute_scale_down_enabled = true
+        disk_gb_enabled            = true
+      }
+    }
+  }
+}
+`,
+		},
+		"sharded": {
+			legacy: map[string]interface{}{
+				"name":                         "sharded",
+				"cluster_type":                 "SHARDED",
+				"mongo_db_major_version":       "5.0",
+				"provider_name":                "AWS",
+				"provider_instance_size_name":  "M30",
+				"provider_volume_type":         "PROVISIONED",
+				"provider_disk_iops":           3000,
+				"disk_size_gb":                 100,
+				"auto_scaling_disk_gb_enabled": false,
+				"replication_specs": []interface{}{
+					map[string]interface{}{
+						"num_shards": 3,
+						"regions_config": []interface{}{
+							map[string]interface{}{"region_name": "US_EAST_1", "electable_nodes": 3, "priority": 7},
+						},
+					},
+				},
+			},
+			expected: `resource "mongodbatlas_advanced_cluster" "test" {
+  name                           = "sharded"
+  cluster_type                   = "SHARDED"
+  mongo_db_major_version         = "5.0"
+  disk_size_gb                   = 100
+  backup_enabled                 = false
+  pit_enabled                    = false
+  termination_protection_enabled = false
+
+  replication_specs {
+    num_shards = 3
+
+    region_configs {
+      provider_name = "AWS"
+      region_name   = "US_EAST_1"
+      priority      = 7
+
+      electable_specs {
+        disk_iops       = 3000
+        ebs_volume_type = "PROVISIONED"
+        instance_size   = "M30"
+        node_count      = 3
+      }
+
+      auto_scaling {
+        compute_enabled = false
+        disk_gb_enabled = false
+      }
+    }
+  }
+}
+`,
+		},
+		"geosharded": {
+			legacy: map[string]interface{}{
+				"name":                         "geosharded",
+				"cluster_type":                 "GEOSHARDED",
+				"mongo_db_major_version":       "6.0",
+				"provider_name":                "GCP",
+				"provider_instance_size_name":  "M30",
+				"auto_scaling_disk_gb_enabled": true,
+				"replication_specs": []interface{}{
+					map[string]interface{}{
+						"num_shards": 2,
+						"zone_name":  "Zone 2",
+						"regions_config": []interface{}{
+							map[string]interface{}{"region_name": "WESTERN_EUROPE", "electable_nodes": 3, "priority": 7},
+						},
+					},
+					map[string]interface{}{
+						"num_shards": 1,
+						"zone_name":  "Zone 1",
+						"regions_config": []interface{}{
+							map[string]interface{}{"region_name": "CENTRAL_US", "electable_nodes": 3, "priority": 7},
+						},
+					},
+				},
+			},
+			expected: `resource "mongodbatlas_advanced_cluster" "test" {
+  name                           = "geosharded"
+  cluster_type                   = "GEOSHARDED"
+  mongo_db_major_version         = "6.0"
+  backup_enabled                 = false
+  pit_enabled                    = false
+  termination_protection_enabled = false
+
+  replication_specs {
+    num_shards = 1
+    zone_name  = "Zone 1"
+
+    region_configs {
+      provider_name = "GCP"
+      region_name   = "CENTRAL_US"
+      priority      = 7
+
+      electable_specs {
+        instance_size = "M30"
+        node_count    = 3
+      }
+
+      auto_scaling {
+        compute_enabled = false
+        disk_gb_enabled = true
+      }
+    }
+  }
+
+  replication_specs {
+    num_shards = 2
+    zone_name  = "Zone 2"
+
+    region_configs {
+      provider_name = "GCP"
+      region_name   = "WESTERN_EUROPE"
+      priority      = 7
+
+      electable_specs {
+        instance_size = "M30"
+        node_count    = 3
+      }
+
+      auto_scaling {
+        compute_enabled = false
+        disk_gb_enabled = true
+      }
+    }
+  }
+}
+`,
+		},
+		"shared tier without replication_specs": {
+			legacy: map[string]interface{}{
+				"name":                        "shared",
+				"provider_name":               "TENANT",
+				"backing_provider_name":       "AWS",
+				"provider_instance_size_name": "M5",
+				"provider_region_name":        "us-east-1",
+				"replication_factor":          3,
+				"num_shards":                  1,
+			},
+			expected: `resource "mongodbatlas_advanced_cluster" "test" {
+  name                           = "shared"
+  cluster_type                   = "REPLICASET"
+  backup_enabled                 = false
+  pit_enabled                    = false
+  termination_protection_enabled = false
+
+  replication_specs {
+    num_shards = 1
+
+    region_configs {
+      provider_name         = "TENANT"
+      backing_provider_name = "AWS"
+      region_name           = "US_EAST_1"
+      priority              = 7
+
+      electable_specs {
+        instance_size = "M5"
+      }
+    }
+  }
+}
+`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceMongoDBAtlasCluster().Schema, tc.legacy)
+
+			cluster := convertClusterToAdvancedCluster(d)
+			if err := validateAdvancedClusterTopology(cluster.GetClusterType(), cluster.ReplicationSpecs); err != nil {
+				t.Fatalf("Bad converted cluster, it doesn't pass the validation of mongodbatlas_advanced_cluster: %s", err)
+			}
+
+			f := hclwrite.NewEmptyFile()
+			appendAdvancedClusterHcl(f.Body().AppendNewBlock("resource", []string{"mongodbatlas_advanced_cluster", "test"}).Body(), cluster)
+
+			if got := string(f.Bytes()); got != tc.expected {
+				t.Fatalf("Bad converted cluster \n got = %s\nwant = %s", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestClusterMigrationImportHcl(t *testing.T) {
+	expected := `removed {
+  from = module.db.mongodbatlas_cluster.main
+
+  lifecycle {
+    destroy = false
+  }
+}
+
+import {
+  to = module.db.mongodbatlas_advanced_cluster.main
+  id = "5d0f1f73cf09a29120e173cf-main"
+}
+`
+
+	got, err := clusterMigrationImportHcl("module.db.mongodbatlas_cluster.main", "module.db.mongodbatlas_advanced_cluster.main", "5d0f1f73cf09a29120e173cf-main")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got != expected {
+		t.Fatalf("Bad clusterMigrationImportHcl return \n got = %s\nwant = %s", got, expected)
+	}
+
+	for address, valid := range map[string]bool{
+		"mongodbatlas_cluster.main":                    true,
+		"module.db.module.prod.mongodbatlas_cluster.c": true,
+		"mongodbatlas_advanced_cluster.main":           false,
+		"module.db[0].mongodbatlas_cluster.main":       false,
+		"mongodbatlas_cluster":                         false,
+	} {
+		if got := clusterMigrationAddress("mongodbatlas_cluster").MatchString(address); got != valid {
+			t.Errorf("Bad clusterMigrationAddress match of %s: got %t, want %t", address, got, valid)
+		}
+	}
+}
+
+func TestMockClusterDSAdvancedClusterMigration_basic(t *testing.T) {
+	var (
+		mock           = newAtlasMockServer(t)
+		projectID      = mock.newProject("test-mock-migration")
+		dataSourceName = "data.mongodbatlas_advanced_cluster_migration.test"
+	)
+
+	mock.seed(fmt.Sprintf("%s/groups/%s/clusters", mockAtlasV1Path, projectID), map[string]interface{}{
+		"name":                  "legacy-cluster",
+		"groupId":               projectID,
+		"clusterType":           "REPLICASET",
+		"providerBackupEnabled": true,
+		"diskSizeGB":            10,
+		"autoScaling": map[string]interface{}{
+			"diskGBEnabled": false,
+			"compute":       map[string]interface{}{"enabled": false, "scaleDownEnabled": false},
+		},
+		"providerSettings": map[string]interface{}{
+			"providerName":     "AWS",
+			"instanceSizeName": "M10",
+			"regionName":       "US_EAST_1",
+			"volumeType":       "STANDARD",
+			"autoScaling": map[string]interface{}{
+				"compute": map[string]interface{}{"minInstanceSize": "", "maxInstanceSize": ""},
+			},
+		},
+		"replicationSpecs": []interface{}{
+			map[string]interface{}{
+				"id":        newMockObjectID(),
+				"numShards": 1,
+				"zoneName":  "Zone 1",
+				"regionsConfig": map[string]interface{}{
+					"US_EAST_1": map[string]interface{}{"electableNodes": 3, "priority": 7, "readOnlyNodes": 0, "analyticsNodes": 0},
+				},
+			},
+		},
+	})
+
+	var resourceHCL string
+	testMockResourceUnitTest(t, mock, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "mongodbatlas_advanced_cluster_migration" "test" {
+						project_id   = %q
+						cluster_name = "legacy-cluster"
+						from         = "module.db.mongodbatlas_cluster.main"
+					}
+				`, projectID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "to", "module.db.mongodbatlas_advanced_cluster.main"),
+					resource.TestCheckResourceAttr(dataSourceName, "commands.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "commands.0", "terraform state rm 'module.db.mongodbatlas_cluster.main'"),
+					resource.TestCheckResourceAttr(dataSourceName, "commands.1",
+						fmt.Sprintf("terraform import 'module.db.mongodbatlas_advanced_cluster.main' '%s-legacy-cluster'", projectID)),
+					resource.TestCheckResourceAttrWith(dataSourceName, "import_hcl", func(value string) error {
+						if !strings.Contains(value, "from = module.db.mongodbatlas_cluster.main") {
+							return fmt.Errorf("expected a removed block of the mongodbatlas_cluster, got %s", value)
+						}
+						return nil
+					}),
+					resource.TestCheckResourceAttrWith(dataSourceName, "resource_hcl", func(value string) error {
+						resourceHCL = value
+						return nil
+					}),
+				),
+			},
+		},
+	})
+
+	for _, want := range []string{
+		`resource "mongodbatlas_advanced_cluster" "main" {`,
+		fmt.Sprintf(`project_id                     = %q`, projectID),
+		`backup_enabled                 = true`,
+		`zone_name  = "Zone 1"`,
+		`node_count    = 3`,
+		`advanced_configuration {`,
+		`minimum_enabled_tls_protocol = "TLS1_2"`,
+	} {
+		if !strings.Contains(resourceHCL, want) {
+			t.Errorf("Bad resource_hcl, expected it to contain %s:\n%s", want, resourceHCL)
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"go.mongodb.org/atlas-sdk/v20230201002/admin"
 	matlas "go.mongodb.org/atlas/mongodbatlas"
 )
 
@@ -239,10 +240,11 @@ func dataSourceMongoDBAtlasAdvancedClusters() *schema.Resource {
 func dataSourceMongoDBAtlasAdvancedClustersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// Get client connection.
 	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 	projectID := d.Get("project_id").(string)
 	d.SetId(id.UniqueId())
 
-	clusters, resp, err := conn.AdvancedClusters.List(ctx, projectID, nil)
+	clusters, resp, err := connV2.MultiCloudClustersApi.ListClusters(ctx, projectID).Execute()
 	if err != nil {
 		if resp != nil && resp.StatusCode == http.StatusNotFound {
 			return nil
@@ -251,46 +253,46 @@ func dataSourceMongoDBAtlasAdvancedClustersRead(ctx context.Context, d *schema.R
 		return diag.FromErr(fmt.Errorf("error reading advanced cluster list for project(%s): %s", projectID, err))
 	}
 
-	if err := d.Set("results", flattenAdvancedClusters(ctx, conn, clusters.Results, d)); err != nil {
+	if err := d.Set("results", flattenAdvancedClusters(ctx, conn, connV2, clusters.Results, d)); err != nil {
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedSetting, "results", d.Id(), err))
 	}
 
 	return nil
 }
 
-func flattenAdvancedClusters(ctx context.Context, conn *matlas.Client, clusters []*matlas.AdvancedCluster, d *schema.ResourceData) []map[string]interface{} {
+func flattenAdvancedClusters(ctx context.Context, conn *matlas.Client, connV2 *admin.APIClient, clusters []admin.AdvancedClusterDescription, d *schema.ResourceData) []map[string]interface{} {
 	results := make([]map[string]interface{}, 0)
 
 	for i := range clusters {
-		processArgs, _, err := conn.Clusters.GetProcessArgs(ctx, clusters[i].GroupID, clusters[i].Name)
+		processArgs, _, err := conn.Clusters.GetProcessArgs(ctx, clusters[i].GetGroupId(), clusters[i].GetName())
 		if err != nil {
-			log.Printf("[WARN] Error setting `advanced_configuration` for the cluster(%s): %s", clusters[i].ID, err)
+			log.Printf("[WARN] Error setting `advanced_configuration` for the cluster(%s): %s", clusters[i].GetId(), err)
 		}
-		replicationSpecs, err := flattenAdvancedReplicationSpecs(ctx, clusters[i].ReplicationSpecs, nil, d, conn)
+		replicationSpecs, err := flattenAdvancedReplicationSpecs(ctx, clusters[i].ReplicationSpecs, nil, d, connV2)
 		if err != nil {
-			log.Printf("[WARN] Error setting `replication_specs` for the cluster(%s): %s", clusters[i].ID, err)
+			log.Printf("[WARN] Error setting `replication_specs` for the cluster(%s): %s", clusters[i].GetId(), err)
 		}
 
 		result := map[string]interface{}{
 			"advanced_configuration":         flattenProcessArgs(processArgs),
-			"backup_enabled":                 clusters[i].BackupEnabled,
-			"bi_connector_config":            flattenBiConnectorConfig(clusters[i].BiConnector),
-			"cluster_type":                   clusters[i].ClusterType,
-			"create_date":                    clusters[i].CreateDate,
-			"connection_strings":             flattenConnectionStrings(clusters[i].ConnectionStrings),
-			"disk_size_gb":                   clusters[i].DiskSizeGB,
-			"encryption_at_rest_provider":    clusters[i].EncryptionAtRestProvider,
-			"labels":                         flattenLabels(clusters[i].Labels),
-			"mongo_db_major_version":         clusters[i].MongoDBMajorVersion,
-			"mongo_db_version":               clusters[i].MongoDBVersion,
-			"name":                           clusters[i].Name,
-			"paused":                         clusters[i].Paused,
-			"pit_enabled":                    clusters[i].PitEnabled,
+			"backup_enabled":                 clusters[i].GetBackupEnabled(),
+			"bi_connector_config":            flattenAdvancedBiConnectorConfig(clusters[i].BiConnector),
+			"cluster_type":                   clusters[i].GetClusterType(),
+			"create_date":                    timeToString(clusters[i].CreateDate),
+			"connection_strings":             flattenAdvancedConnectionStrings(clusters[i].ConnectionStrings),
+			"disk_size_gb":                   clusters[i].GetDiskSizeGB(),
+			"encryption_at_rest_provider":    clusters[i].GetEncryptionAtRestProvider(),
+			"labels":                         flattenLabels(fromComponentLabels(clusters[i].Labels)),
+			"mongo_db_major_version":         clusters[i].GetMongoDBMajorVersion(),
+			"mongo_db_version":               clusters[i].GetMongoDBVersion(),
+			"name":                           clusters[i].GetName(),
+			"paused":                         clusters[i].GetPaused(),
+			"pit_enabled":                    clusters[i].GetPitEnabled(),
 			"replication_specs":              replicationSpecs,
-			"root_cert_type":                 clusters[i].RootCertType,
-			"state_name":                     clusters[i].StateName,
-			"termination_protection_enabled": clusters[i].TerminationProtectionEnabled,
-			"version_release_system":         clusters[i].VersionReleaseSystem,
+			"root_cert_type":                 clusters[i].GetRootCertType(),
+			"state_name":                     clusters[i].GetStateName(),
+			"termination_protection_enabled": clusters[i].GetTerminationProtectionEnabled(),
+			"version_release_system":         clusters[i].GetVersionReleaseSystem(),
 		}
 		results = append(results, result)
 	}
@@ -8,8 +8,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/spf13/cast"
 	"github.com/zclconf/go-cty/cty"
-	matlas "go.mongodb.org/atlas/mongodbatlas"
+	"go.mongodb.org/atlas-sdk/v20230201002/admin"
 )
 
 func dataSourceMongoDBAtlasAlertConfiguration() *schema.Resource {
@@ -263,24 +264,24 @@ func dataSourceMongoDBAtlasAlertConfiguration() *schema.Resource {
 
 func dataSourceMongoDBAtlasAlertConfigurationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// Get client connection.
-	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 	projectID := d.Get("project_id").(string)
 	alertID := getEncodedID(d.Get("alert_configuration_id").(string), "id")
 
-	alert, _, err := conn.AlertConfigurations.GetAnAlertConfig(ctx, projectID, alertID)
+	alert, _, err := connV2.AlertConfigurationsApi.GetAlertConfiguration(ctx, projectID, alertID).Execute()
 	if err != nil {
 		return diag.FromErr(fmt.Errorf(errorReadAlertConf, err))
 	}
 
-	if err := d.Set("event_type", alert.EventTypeName); err != nil {
+	if err := d.Set("event_type", alert.GetEventTypeName()); err != nil {
 		return diag.FromErr(fmt.Errorf(errorAlertConfSetting, "event_type", projectID, err))
 	}
 
-	if err := d.Set("created", alert.Created); err != nil {
+	if err := d.Set("created", timeToString(alert.Created)); err != nil {
 		return diag.FromErr(fmt.Errorf(errorAlertConfSetting, "created", projectID, err))
 	}
 
-	if err := d.Set("updated", alert.Updated); err != nil {
+	if err := d.Set("updated", timeToString(alert.Updated)); err != nil {
 		return diag.FromErr(fmt.Errorf(errorAlertConfSetting, "updated", projectID, err))
 	}
 
@@ -309,20 +310,20 @@ func dataSourceMongoDBAtlasAlertConfigurationRead(ctx context.Context, d *schema
 	}
 
 	if dOutput := d.Get("output"); dOutput != nil {
-		if err := d.Set("output", computeAlertConfigurationOutput(alert, dOutput.([]interface{}), alert.EventTypeName)); err != nil {
+		if err := d.Set("output", computeAlertConfigurationOutput(alert, dOutput.([]interface{}), alert.GetEventTypeName())); err != nil {
 			return diag.FromErr(fmt.Errorf(errorAlertConfSetting, "output", projectID, err))
 		}
 	}
 
 	d.SetId(encodeStateID(map[string]string{
-		"id":         alert.ID,
+		"id":         alert.GetId(),
 		"project_id": projectID,
 	}))
 
 	return nil
 }
 
-func computeAlertConfigurationOutput(alert *matlas.AlertConfiguration, outputConfigurations []interface{}, defaultLabel string) []map[string]interface{} {
+func computeAlertConfigurationOutput(alert *admin.GroupAlertsConfig, outputConfigurations []interface{}, defaultLabel string) []map[string]interface{} {
 	output := make([]map[string]interface{}, 0)
 
 	for i := 0; i < len(outputConfigurations); i++ {
@@ -347,7 +348,7 @@ func computeAlertConfigurationOutput(alert *matlas.AlertConfiguration, outputCon
 	return output
 }
 
-func outputAlertConfiguration(alert *matlas.AlertConfiguration, outputType, resourceLabel string) string {
+func outputAlertConfiguration(alert *admin.GroupAlertsConfig, outputType, resourceLabel string) string {
 	if outputType == "resource_hcl" {
 		return outputAlertConfigurationResourceHcl(resourceLabel, alert)
 	}
@@ -358,13 +359,17 @@ func outputAlertConfiguration(alert *matlas.AlertConfiguration, outputType, reso
 	return ""
 }
 
-func outputAlertConfigurationResourceHcl(label string, alert *matlas.AlertConfiguration) string {
+func outputAlertConfigurationResourceHcl(label string, alert *admin.GroupAlertsConfig) string {
 	f := hclwrite.NewEmptyFile()
-	root := f.Body()
-	resource := root.AppendNewBlock("resource", []string{"mongodbatlas_alert_configuration", label}).Body()
+	appendAlertConfigurationResourceHcl(f.Body().AppendNewBlock("resource", []string{"mongodbatlas_alert_configuration", label}).Body(), alert)
 
-	resource.SetAttributeValue("project_id", cty.StringVal(alert.GroupID))
-	resource.SetAttributeValue("event_type", cty.StringVal(alert.EventTypeName))
+	return string(f.Bytes())
+}
+
+// appendAlertConfigurationResourceHcl writes the arguments of the mongodbatlas_alert_configuration resource of alert to resource.
+func appendAlertConfigurationResourceHcl(resource *hclwrite.Body, alert *admin.GroupAlertsConfig) {
+	resource.SetAttributeValue("project_id", cty.StringVal(alert.GetGroupId()))
+	resource.SetAttributeValue("event_type", cty.StringVal(alert.GetEventTypeName()))
 
 	if alert.Enabled != nil {
 		resource.SetAttributeValue("enabled", cty.BoolVal(*alert.Enabled))
@@ -393,101 +398,91 @@ func outputAlertConfigurationResourceHcl(label string, alert *matlas.AlertConfig
 
 		appendBlockWithCtyValues(resource, "notification", []string{}, values)
 	}
-
-	return string(f.Bytes())
 }
 
-func outputAlertConfigurationResourceImport(label string, alert *matlas.AlertConfiguration) string {
-	return fmt.Sprintf("terraform import mongodbatlas_alert_configuration.%s %s-%s\n", label, alert.GroupID, alert.ID)
+func outputAlertConfigurationResourceImport(label string, alert *admin.GroupAlertsConfig) string {
+	return fmt.Sprintf("terraform import mongodbatlas_alert_configuration.%s %s-%s\n", label, alert.GetGroupId(), alert.GetId())
 }
 
-func convertMatcherToCtyValues(matcher matlas.Matcher) map[string]cty.Value {
+func convertMatcherToCtyValues(matcher map[string]interface{}) map[string]cty.Value {
 	return map[string]cty.Value{
-		"field_name": cty.StringVal(matcher.FieldName),
-		"operator":   cty.StringVal(matcher.Operator),
-		"value":      cty.StringVal(matcher.Value),
+		"field_name": cty.StringVal(cast.ToString(matcher["fieldName"])),
+		"operator":   cty.StringVal(cast.ToString(matcher["operator"])),
+		"value":      cty.StringVal(cast.ToString(matcher["value"])),
 	}
 }
 
-func convertMetricThresholdToCtyValues(metric matlas.MetricThreshold) map[string]cty.Value {
+func convertMetricThresholdToCtyValues(metric admin.ServerlessMetricThreshold) map[string]cty.Value {
 	return map[string]cty.Value{
-		"metric_name": cty.StringVal(metric.MetricName),
-		"operator":    cty.StringVal(metric.Operator),
-		"threshold":   cty.NumberFloatVal(metric.Threshold),
-		"units":       cty.StringVal(metric.Units),
-		"mode":        cty.StringVal(metric.Mode),
+		"metric_name": cty.StringVal(metric.GetMetricName()),
+		"operator":    cty.StringVal(metric.GetOperator()),
+		"threshold":   cty.NumberFloatVal(metric.GetThreshold()),
+		"units":       cty.StringVal(metric.GetUnits()),
+		"mode":        cty.StringVal(metric.GetMode()),
 	}
 }
 
-func convertThresholdToCtyValues(threshold matlas.Threshold) map[string]cty.Value {
+func convertThresholdToCtyValues(threshold admin.GreaterThanRawThreshold) map[string]cty.Value {
 	return map[string]cty.Value{
-		"operator":  cty.StringVal(threshold.Operator),
-		"units":     cty.StringVal(threshold.Units),
-		"threshold": cty.NumberFloatVal(threshold.Threshold),
+		"operator":  cty.StringVal(threshold.GetOperator()),
+		"units":     cty.StringVal(threshold.GetUnits()),
+		"threshold": cty.NumberFloatVal(float64(threshold.GetThreshold())),
 	}
 }
 
-func convertNotificationToCtyValues(notification *matlas.Notification) map[string]cty.Value {
+func convertNotificationToCtyValues(notification *admin.AlertsNotificationRootForGroup) map[string]cty.Value {
 	values := map[string]cty.Value{}
 
-	if notification.ChannelName != "" {
-		values["channel_name"] = cty.StringVal(notification.ChannelName)
-	}
-
-	if notification.DatadogRegion != "" {
-		values["datadog_region"] = cty.StringVal(notification.DatadogRegion)
-	}
-
-	if notification.EmailAddress != "" {
-		values["email_address"] = cty.StringVal(notification.EmailAddress)
+	if notification.GetChannelName() != "" {
+		values["channel_name"] = cty.StringVal(notification.GetChannelName())
 	}
 
-	if notification.FlowName != "" {
-		values["flow_name"] = cty.StringVal(notification.FlowName)
+	if notification.GetDatadogRegion() != "" {
+		values["datadog_region"] = cty.StringVal(notification.GetDatadogRegion())
 	}
 
-	if notification.IntervalMin > 0 {
-		values["interval_min"] = cty.NumberIntVal(int64(notification.IntervalMin))
+	if notification.GetEmailAddress() != "" {
+		values["email_address"] = cty.StringVal(notification.GetEmailAddress())
 	}
 
-	if notification.MobileNumber != "" {
-		values["mobile_number"] = cty.StringVal(notification.MobileNumber)
+	if notification.GetIntervalMin() > 0 {
+		values["interval_min"] = cty.NumberIntVal(int64(notification.GetIntervalMin()))
 	}
 
-	if notification.OpsGenieRegion != "" {
-		values["ops_genie_region"] = cty.StringVal(notification.OpsGenieRegion)
+	if notification.GetMobileNumber() != "" {
+		values["mobile_number"] = cty.StringVal(notification.GetMobileNumber())
 	}
 
-	if notification.OrgName != "" {
-		values["org_name"] = cty.StringVal(notification.OrgName)
+	if notification.GetOpsGenieRegion() != "" {
+		values["ops_genie_region"] = cty.StringVal(notification.GetOpsGenieRegion())
 	}
 
-	if notification.TeamID != "" {
-		values["team_id"] = cty.StringVal(notification.TeamID)
+	if notification.GetTeamId() != "" {
+		values["team_id"] = cty.StringVal(notification.GetTeamId())
 	}
 
-	if notification.TeamName != "" {
-		values["team_name"] = cty.StringVal(notification.TeamName)
+	if notification.GetTeamName() != "" {
+		values["team_name"] = cty.StringVal(notification.GetTeamName())
 	}
 
-	if notification.TypeName != "" {
-		values["type_name"] = cty.StringVal(notification.TypeName)
+	if notification.GetTypeName() != "" {
+		values["type_name"] = cty.StringVal(notification.GetTypeName())
 	}
 
-	if notification.Username != "" {
-		values["username"] = cty.StringVal(notification.Username)
+	if notification.GetUsername() != "" {
+		values["username"] = cty.StringVal(notification.GetUsername())
 	}
 
-	if notification.DelayMin != nil && *notification.DelayMin > 0 {
-		values["delay_min"] = cty.NumberIntVal(int64(*notification.DelayMin))
+	if notification.GetDelayMin() > 0 {
+		values["delay_min"] = cty.NumberIntVal(int64(notification.GetDelayMin()))
 	}
 
-	if notification.EmailEnabled != nil && *notification.EmailEnabled {
-		values["email_enabled"] = cty.BoolVal(*notification.EmailEnabled)
+	if notification.GetEmailEnabled() {
+		values["email_enabled"] = cty.BoolVal(notification.GetEmailEnabled())
 	}
 
-	if notification.SMSEnabled != nil && *notification.SMSEnabled {
-		values["sms_enabled"] = cty.BoolVal(*notification.SMSEnabled)
+	if notification.GetSmsEnabled() {
+		values["sms_enabled"] = cty.BoolVal(notification.GetSmsEnabled())
 	}
 
 	if len(notification.Roles) > 0 {
@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"go.mongodb.org/atlas-sdk/v20230201002/admin"
 	matlas "go.mongodb.org/atlas/mongodbatlas"
 )
 
@@ -89,17 +90,25 @@ func dataSourceMongoDBAtlasAlertConfigurations() *schema.Resource {
 
 func dataSourceMongoDBAtlasAlertConfigurationsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// Get client connection.
-	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 	projectID := d.Get("project_id").(string)
 	listOptions := d.Get("list_options").([]interface{})
+	options := readListOptions(listOptions)
 
-	alerts, _, err := conn.AlertConfigurations.List(ctx, projectID, readListOptions(listOptions))
+	request := connV2.AlertConfigurationsApi.ListAlertConfigurations(ctx, projectID).
+		ItemsPerPage(options.ItemsPerPage).
+		IncludeCount(options.IncludeCount)
+	if options.PageNum > 0 {
+		request = request.PageNum(options.PageNum)
+	}
+
+	alerts, _, err := request.Execute()
 
 	if err != nil {
 		return diag.FromErr(fmt.Errorf(errorReadAlertConf, err))
 	}
 
-	results := flattenAlertConfigurations(ctx, conn, alerts, d)
+	results := flattenAlertConfigurations(alerts.Results, d)
 
 	if err := d.Set("results", results); err != nil {
 		return diag.FromErr(fmt.Errorf(errorAlertConfSetting, "results", projectID, err))
@@ -116,7 +125,7 @@ func dataSourceMongoDBAtlasAlertConfigurationsRead(ctx context.Context, d *schem
 	return nil
 }
 
-func flattenAlertConfigurations(ctx context.Context, conn *matlas.Client, alerts []matlas.AlertConfiguration, d *schema.ResourceData) []map[string]interface{} {
+func flattenAlertConfigurations(alerts []admin.GroupAlertsConfig, d *schema.ResourceData) []map[string]interface{} {
 	var outputConfigurations []interface{}
 
 	results := make([]map[string]interface{}, 0)
@@ -130,14 +139,14 @@ func flattenAlertConfigurations(ctx context.Context, conn *matlas.Client, alerts
 	}
 
 	for i := 0; i < len(alerts); i++ {
-		label := fmt.Sprintf("%s_%d", alerts[i].EventTypeName, i)
+		label := fmt.Sprintf("%s_%d", alerts[i].GetEventTypeName(), i)
 
 		results = append(results, map[string]interface{}{
-			"alert_configuration_id":  alerts[i].ID,
-			"event_type":              alerts[i].EventTypeName,
-			"created":                 alerts[i].Created,
-			"updated":                 alerts[i].Updated,
-			"enabled":                 alerts[i].Enabled,
+			"alert_configuration_id":  alerts[i].GetId(),
+			"event_type":              alerts[i].GetEventTypeName(),
+			"created":                 timeToString(alerts[i].Created),
+			"updated":                 timeToString(alerts[i].Updated),
+			"enabled":                 alerts[i].GetEnabled(),
 			"matcher":                 flattenAlertConfigurationMatchers(alerts[i].Matchers),
 			"metric_threshold_config": flattenAlertConfigurationMetricThresholdConfig(alerts[i].MetricThreshold),
 			"threshold_config":        flattenAlertConfigurationThresholdConfig(alerts[i].Threshold),
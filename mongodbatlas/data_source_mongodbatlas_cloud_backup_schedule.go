@@ -219,42 +219,42 @@ func dataSourceMongoDBAtlasCloudBackupSchedule() *schema.Resource {
 // Almost the same as dataSourceMongoDBAtlasCloudProviderSnapshotBackupPolicyRead
 // just do not save the update_snapshots because is not specified in the DS
 func dataSourceMongoDBAtlasCloudBackupScheduleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 
 	projectID := d.Get("project_id").(string)
 	clusterName := d.Get("cluster_name").(string)
 
-	backupPolicy, _, err := conn.CloudProviderSnapshotBackupPolicies.Get(ctx, projectID, clusterName)
+	backupPolicy, _, err := connV2.CloudBackupsApi.GetBackupSchedule(ctx, projectID, clusterName).Execute()
 	if err != nil {
 		return diag.Errorf(errorSnapshotBackupPolicyRead, clusterName, err)
 	}
 
-	if err := d.Set("cluster_id", backupPolicy.ClusterID); err != nil {
+	if err := d.Set("cluster_id", backupPolicy.GetClusterId()); err != nil {
 		return diag.Errorf(errorSnapshotBackupScheduleSetting, "cluster_id", clusterName, err)
 	}
 
-	if err := d.Set("reference_hour_of_day", backupPolicy.ReferenceHourOfDay); err != nil {
+	if err := d.Set("reference_hour_of_day", backupPolicy.GetReferenceHourOfDay()); err != nil {
 		return diag.Errorf(errorSnapshotBackupScheduleSetting, "reference_hour_of_day", clusterName, err)
 	}
 
-	if err := d.Set("reference_minute_of_hour", backupPolicy.ReferenceMinuteOfHour); err != nil {
+	if err := d.Set("reference_minute_of_hour", backupPolicy.GetReferenceMinuteOfHour()); err != nil {
 		return diag.Errorf(errorSnapshotBackupScheduleSetting, "reference_minute_of_hour", clusterName, err)
 	}
 
-	if err := d.Set("restore_window_days", backupPolicy.RestoreWindowDays); err != nil {
+	if err := d.Set("restore_window_days", backupPolicy.GetRestoreWindowDays()); err != nil {
 		return diag.Errorf(errorSnapshotBackupScheduleSetting, "restore_window_days", clusterName, err)
 	}
 
-	if err := d.Set("next_snapshot", backupPolicy.NextSnapshot); err != nil {
+	if err := d.Set("next_snapshot", timeToString(backupPolicy.NextSnapshot)); err != nil {
 		return diag.Errorf(errorSnapshotBackupScheduleSetting, "next_snapshot", clusterName, err)
 	}
-	if err := d.Set("use_org_and_group_names_in_export_prefix", backupPolicy.UseOrgAndGroupNamesInExportPrefix); err != nil {
+	if err := d.Set("use_org_and_group_names_in_export_prefix", backupPolicy.GetUseOrgAndGroupNamesInExportPrefix()); err != nil {
 		return diag.Errorf(errorSnapshotBackupScheduleSetting, "use_org_and_group_names_in_export_prefix", clusterName, err)
 	}
-	if err := d.Set("auto_export_enabled", backupPolicy.AutoExportEnabled); err != nil {
+	if err := d.Set("auto_export_enabled", backupPolicy.GetAutoExportEnabled()); err != nil {
 		return diag.Errorf(errorSnapshotBackupScheduleSetting, "auto_export_enabled", clusterName, err)
 	}
-	if err := d.Set("id_policy", backupPolicy.Policies[0].ID); err != nil {
+	if err := d.Set("id_policy", backupPolicy.Policies[0].GetId()); err != nil {
 		return diag.Errorf(errorSnapshotBackupScheduleSetting, "id_policy", clusterName, err)
 	}
 	if err := d.Set("export", flattenExport(backupPolicy)); err != nil {
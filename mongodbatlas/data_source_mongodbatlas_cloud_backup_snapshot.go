@@ -6,7 +6,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	matlas "go.mongodb.org/atlas/mongodbatlas"
 )
 
 func dataSourceMongoDBAtlasCloudBackupSnapshot() *schema.Resource {
@@ -101,56 +100,50 @@ func dataSourceMongoDBAtlasCloudBackupSnapshot() *schema.Resource {
 }
 
 func dataSourceMongoDBAtlasCloudBackupSnapshotRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 
-	requestParameters := &matlas.SnapshotReqPathParameters{
-		SnapshotID:  d.Get("snapshot_id").(string),
-		GroupID:     d.Get("project_id").(string),
-		ClusterName: d.Get("cluster_name").(string),
-	}
-
-	snapshot, _, err := conn.CloudProviderSnapshots.GetOneCloudProviderSnapshot(ctx, requestParameters)
+	snapshot, _, err := getCloudBackupSnapshot(ctx, connV2, d.Get("project_id").(string), d.Get("cluster_name").(string), d.Get("snapshot_id").(string))
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error getting cloudProviderSnapshot Information: %s", err))
 	}
 
-	if err = d.Set("created_at", snapshot.CreatedAt); err != nil {
+	if err = d.Set("created_at", timeToString(snapshot.CreatedAt)); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `created_at` for cloudProviderSnapshot (%s): %s", d.Id(), err))
 	}
 
-	if err = d.Set("description", snapshot.Description); err != nil {
+	if err = d.Set("description", snapshot.GetDescription()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `description` for cloudProviderSnapshot (%s): %s", d.Id(), err))
 	}
 
-	if err = d.Set("expires_at", snapshot.ExpiresAt); err != nil {
+	if err = d.Set("expires_at", timeToString(snapshot.ExpiresAt)); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `expires_at` for cloudProviderSnapshot (%s): %s", d.Id(), err))
 	}
 
-	if err = d.Set("master_key_uuid", snapshot.MasterKeyUUID); err != nil {
+	if err = d.Set("master_key_uuid", snapshot.GetMasterKeyUUID()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `master_key_uuid` for cloudProviderSnapshot (%s): %s", d.Id(), err))
 	}
 
-	if err = d.Set("mongod_version", snapshot.MongodVersion); err != nil {
+	if err = d.Set("mongod_version", snapshot.GetMongodVersion()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `mongod_version` for cloudProviderSnapshot (%s): %s", d.Id(), err))
 	}
 
-	if err = d.Set("snapshot_type", snapshot.SnapshotType); err != nil {
+	if err = d.Set("snapshot_type", snapshot.GetSnapshotType()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `snapshot_type` for cloudProviderSnapshot (%s): %s", d.Id(), err))
 	}
 
-	if err = d.Set("status", snapshot.Status); err != nil {
+	if err = d.Set("status", snapshot.GetStatus()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `status` for cloudProviderSnapshot (%s): %s", d.Id(), err))
 	}
 
-	if err = d.Set("storage_size_bytes", snapshot.StorageSizeBytes); err != nil {
+	if err = d.Set("storage_size_bytes", snapshot.GetStorageSizeBytes()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `storage_size_bytes` for cloudProviderSnapshot (%s): %s", d.Id(), err))
 	}
 
-	if err = d.Set("type", snapshot.Type); err != nil {
+	if err = d.Set("type", snapshot.GetType()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `type` for cloudProviderSnapshot (%s): %s", d.Id(), err))
 	}
 
-	if err = d.Set("cloud_provider", snapshot.CloudProvider); err != nil {
+	if err = d.Set("cloud_provider", snapshot.GetCloudProvider()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `cloud_provider` for snapshot (%s): %s", d.Id(), err))
 	}
 
@@ -158,15 +151,15 @@ func dataSourceMongoDBAtlasCloudBackupSnapshotRead(ctx context.Context, d *schem
 		return diag.FromErr(fmt.Errorf("error setting `members` for snapshot (%s): %s", d.Id(), err))
 	}
 
-	if err = d.Set("replica_set_name", snapshot.ReplicaSetName); err != nil {
+	if err = d.Set("replica_set_name", snapshot.GetReplicaSetName()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `replica_set_name` for snapshot (%s): %s", d.Id(), err))
 	}
 
-	if err = d.Set("snapshot_ids", snapshot.SnapshotsIds); err != nil {
+	if err = d.Set("snapshot_ids", snapshot.SnapshotIds); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `snapshot_ids` for snapshot (%s): %s", d.Id(), err))
 	}
 
-	d.SetId(snapshot.ID)
+	d.SetId(snapshot.GetId())
 
 	return nil
 }
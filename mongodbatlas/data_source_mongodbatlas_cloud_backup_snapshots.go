@@ -7,7 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	matlas "go.mongodb.org/atlas/mongodbatlas"
+	"go.mongodb.org/atlas-sdk/v20230201002/admin"
 )
 
 func dataSourceMongoDBAtlasCloudBackupSnapshots() *schema.Resource {
@@ -123,27 +123,37 @@ func dataSourceMongoDBAtlasCloudBackupSnapshots() *schema.Resource {
 
 func dataSourceMongoDBAtlasCloudBackupSnapshotsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// Get client connection.
-	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
+	projectID := d.Get("project_id").(string)
+	clusterName := d.Get("cluster_name").(string)
 
-	requestParameters := &matlas.SnapshotReqPathParameters{
-		GroupID:     d.Get("project_id").(string),
-		ClusterName: d.Get("cluster_name").(string),
+	request := connV2.CloudBackupsApi.ListReplicaSetBackups(ctx, projectID, clusterName).IncludeCount(true)
+	if pageNum := d.Get("page_num").(int); pageNum > 0 {
+		request = request.PageNum(pageNum)
 	}
-	options := &matlas.ListOptions{
-		PageNum:      d.Get("page_num").(int),
-		ItemsPerPage: d.Get("items_per_page").(int),
+	if itemsPerPage := d.Get("items_per_page").(int); itemsPerPage > 0 {
+		request = request.ItemsPerPage(itemsPerPage)
 	}
 
-	cloudProviderSnapshots, _, err := conn.CloudProviderSnapshots.GetAllCloudProviderSnapshots(ctx, requestParameters, options)
+	cloudProviderSnapshots, _, err := request.Execute()
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error getting cloudProviderSnapshots information: %s", err))
 	}
 
-	if err := d.Set("results", flattenCloudBackupSnapshots(cloudProviderSnapshots.Results)); err != nil {
+	snapshots := make([]admin.DiskBackupSnapshot, 0, len(cloudProviderSnapshots.Results))
+	for i := range cloudProviderSnapshots.Results {
+		snapshot, err := newCloudBackupSnapshot(ctx, connV2, projectID, clusterName, &cloudProviderSnapshots.Results[i])
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error getting cloudProviderSnapshots information: %s", err))
+		}
+		snapshots = append(snapshots, *snapshot)
+	}
+
+	if err := d.Set("results", flattenCloudBackupSnapshots(snapshots)); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `results`: %s", err))
 	}
 
-	if err := d.Set("total_count", cloudProviderSnapshots.TotalCount); err != nil {
+	if err := d.Set("total_count", cloudProviderSnapshots.GetTotalCount()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `total_count`: %s", err))
 	}
 
@@ -152,28 +162,29 @@ func dataSourceMongoDBAtlasCloudBackupSnapshotsRead(ctx context.Context, d *sche
 	return nil
 }
 
-func flattenCloudBackupSnapshots(cloudProviderSnapshots []*matlas.CloudProviderSnapshot) []map[string]interface{} {
+func flattenCloudBackupSnapshots(cloudProviderSnapshots []admin.DiskBackupSnapshot) []map[string]interface{} {
 	var results []map[string]interface{}
 
 	if len(cloudProviderSnapshots) > 0 {
 		results = make([]map[string]interface{}, len(cloudProviderSnapshots))
 
-		for k, cloudProviderSnapshot := range cloudProviderSnapshots {
+		for k := range cloudProviderSnapshots {
+			cloudProviderSnapshot := &cloudProviderSnapshots[k]
 			results[k] = map[string]interface{}{
-				"id":                 cloudProviderSnapshot.ID,
-				"created_at":         cloudProviderSnapshot.CreatedAt,
-				"description":        cloudProviderSnapshot.Description,
-				"expires_at":         cloudProviderSnapshot.ExpiresAt,
-				"master_key_uuid":    cloudProviderSnapshot.MasterKeyUUID,
-				"mongod_version":     cloudProviderSnapshot.MongodVersion,
-				"snapshot_type":      cloudProviderSnapshot.SnapshotType,
-				"status":             cloudProviderSnapshot.Status,
-				"storage_size_bytes": cloudProviderSnapshot.StorageSizeBytes,
-				"type":               cloudProviderSnapshot.Type,
-				"cloud_provider":     cloudProviderSnapshot.CloudProvider,
+				"id":                 cloudProviderSnapshot.GetId(),
+				"created_at":         timeToString(cloudProviderSnapshot.CreatedAt),
+				"description":        cloudProviderSnapshot.GetDescription(),
+				"expires_at":         timeToString(cloudProviderSnapshot.ExpiresAt),
+				"master_key_uuid":    cloudProviderSnapshot.GetMasterKeyUUID(),
+				"mongod_version":     cloudProviderSnapshot.GetMongodVersion(),
+				"snapshot_type":      cloudProviderSnapshot.GetSnapshotType(),
+				"status":             cloudProviderSnapshot.GetStatus(),
+				"storage_size_bytes": cloudProviderSnapshot.GetStorageSizeBytes(),
+				"type":               cloudProviderSnapshot.GetType(),
+				"cloud_provider":     cloudProviderSnapshot.GetCloudProvider(),
 				"members":            flattenCloudMembers(cloudProviderSnapshot.Members),
-				"replica_set_name":   cloudProviderSnapshot.ReplicaSetName,
-				"snapshot_ids":       cloudProviderSnapshot.SnapshotsIds,
+				"replica_set_name":   cloudProviderSnapshot.GetReplicaSetName(),
+				"snapshot_ids":       cloudProviderSnapshot.SnapshotIds,
 			}
 		}
 	}
@@ -0,0 +1,151 @@
+package mongodbatlas
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"go.mongodb.org/atlas-sdk/v20230201002/admin"
+)
+
+const (
+	errorCloudProviderRegionsRead    = "error reading the cloud provider regions of the project (%s): %s"
+	errorCloudProviderRegionsSetting = "error setting `%s` for the cloud provider regions of the project (%s): %s"
+)
+
+func dataSourceMongoDBAtlasCloudProviderRegions() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMongoDBAtlasCloudProviderRegionsRead,
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"provider_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"AWS", "AZURE", "GCP"}, false),
+			},
+			"instance_size": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"results": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"provider_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"instance_sizes": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"available_regions": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"name": {
+													Type:     schema.TypeString,
+													Computed: true,
+												},
+												"default": {
+													Type:     schema.TypeBool,
+													Computed: true,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceMongoDBAtlasCloudProviderRegionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	connV2 := meta.(*MongoDBClient).AtlasV2
+
+	projectID := d.Get("project_id").(string)
+	providerName := d.Get("provider_name").(string)
+	instanceSize := d.Get("instance_size").(string)
+
+	var providers []admin.CloudProviderRegions
+
+	for pageNum := 1; ; pageNum++ {
+		request := connV2.ClustersApi.ListCloudProviderRegions(ctx, projectID).PageNum(pageNum).ItemsPerPage(listItemsPerPage)
+		if providerName != "" {
+			request = request.Providers([]string{providerName})
+		}
+		if instanceSize != "" {
+			request = request.Tier(instanceSize)
+		}
+
+		page, _, err := request.Execute()
+		if err != nil {
+			return diag.FromErr(fmt.Errorf(errorCloudProviderRegionsRead, projectID, err))
+		}
+
+		providers = append(providers, page.Results...)
+
+		if len(page.Results) < listItemsPerPage {
+			break
+		}
+	}
+
+	if err := d.Set("results", flattenCloudProviderRegions(providers)); err != nil {
+		return diag.FromErr(fmt.Errorf(errorCloudProviderRegionsSetting, "results", projectID, err))
+	}
+
+	d.SetId(encodeStateID(map[string]string{
+		"project_id":    projectID,
+		"provider_name": providerName,
+		"instance_size": instanceSize,
+	}))
+
+	return nil
+}
+
+func flattenCloudProviderRegions(providers []admin.CloudProviderRegions) []map[string]interface{} {
+	results := make([]map[string]interface{}, len(providers))
+
+	for i := range providers {
+		instanceSizes := make([]map[string]interface{}, len(providers[i].InstanceSizes))
+		for j := range providers[i].InstanceSizes {
+			instanceSize := &providers[i].InstanceSizes[j]
+
+			regions := make([]map[string]interface{}, len(instanceSize.AvailableRegions))
+			for k := range instanceSize.AvailableRegions {
+				regions[k] = map[string]interface{}{
+					"name":    instanceSize.AvailableRegions[k].GetName(),
+					"default": instanceSize.AvailableRegions[k].GetDefault(),
+				}
+			}
+
+			instanceSizes[j] = map[string]interface{}{
+				"name":              instanceSize.GetName(),
+				"available_regions": regions,
+			}
+		}
+
+		results[i] = map[string]interface{}{
+			"provider_name":  providers[i].GetProvider(),
+			"instance_sizes": instanceSizes,
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,91 @@
+package mongodbatlas
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestMockConfigDSCloudProviderRegions_basic(t *testing.T) {
+	var (
+		mock           = newAtlasMockServer(t)
+		projectID      = mock.newProject("test-mock-regions")
+		dataSourceName = "data.mongodbatlas_cloud_provider_regions.test"
+	)
+
+	mock.seed(fmt.Sprintf("%s/groups/%s/clusters/provider/regions", mockAtlasV2Path, projectID), map[string]interface{}{
+		"provider": "AWS",
+		"instanceSizes": []interface{}{
+			map[string]interface{}{
+				"name": "M10",
+				"availableRegions": []interface{}{
+					map[string]interface{}{"name": "US_EAST_1", "default": true},
+					map[string]interface{}{"name": "EU_WEST_1", "default": false},
+				},
+			},
+			map[string]interface{}{
+				"name": "M30",
+				"availableRegions": []interface{}{
+					map[string]interface{}{"name": "US_EAST_1", "default": true},
+				},
+			},
+		},
+	})
+
+	testMockResourceUnitTest(t, mock, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "mongodbatlas_cloud_provider_regions" "test" {
+						project_id    = %q
+						provider_name = "AWS"
+					}
+				`, projectID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "results.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "results.0.provider_name", "AWS"),
+					resource.TestCheckResourceAttr(dataSourceName, "results.0.instance_sizes.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "results.0.instance_sizes.0.name", "M10"),
+					resource.TestCheckResourceAttr(dataSourceName, "results.0.instance_sizes.0.available_regions.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "results.0.instance_sizes.0.available_regions.0.name", "US_EAST_1"),
+					resource.TestCheckResourceAttr(dataSourceName, "results.0.instance_sizes.0.available_regions.0.default", "true"),
+					resource.TestCheckResourceAttr(dataSourceName, "results.0.instance_sizes.0.available_regions.1.name", "EU_WEST_1"),
+					resource.TestCheckResourceAttr(dataSourceName, "results.0.instance_sizes.1.name", "M30"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccConfigDSCloudProviderRegions_basic(t *testing.T) {
+	SkipTestForCI(t)
+	var (
+		dataSourceName = "data.mongodbatlas_cloud_provider_regions.test"
+		projectID      = os.Getenv("MONGODB_ATLAS_PROJECT_ID")
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheckBasic(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "mongodbatlas_cloud_provider_regions" "test" {
+						project_id    = %q
+						provider_name = "AWS"
+						instance_size = "M10"
+					}
+				`, projectID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "results.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "results.0.provider_name", "AWS"),
+					resource.TestCheckResourceAttr(dataSourceName, "results.0.instance_sizes.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "results.0.instance_sizes.0.name", "M10"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "results.0.instance_sizes.0.available_regions.0.name"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,240 @@
+package mongodbatlas
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"go.mongodb.org/atlas-sdk/v20230201002/admin"
+)
+
+const (
+	errorClusterProcessesRead    = "error reading the processes of the cluster (%s): %s"
+	errorClusterProcessesSetting = "error setting `%s` for the processes of the cluster (%s): %s"
+)
+
+func dataSourceMongoDBAtlasClusterProcesses() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMongoDBAtlasClusterProcessesRead,
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"cluster_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"results": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"hostname": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"user_alias": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"port": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"replica_set_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"shard_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"role": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"last_ping": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceMongoDBAtlasClusterProcessesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	connV2 := meta.(*MongoDBClient).AtlasV2
+
+	projectID := d.Get("project_id").(string)
+	clusterName := d.Get("cluster_name").(string)
+
+	cluster, _, _, err := getAdvancedCluster(ctx, connV2, projectID, clusterName, false)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf(errorClusterProcessesRead, clusterName, err))
+	}
+
+	connectionStrings := cluster.GetConnectionStrings()
+	processes, err := listClusterProcesses(ctx, connV2, projectID, connectionStrings.GetStandardSrv())
+	if err != nil {
+		return diag.FromErr(fmt.Errorf(errorClusterProcessesRead, clusterName, err))
+	}
+
+	if err := d.Set("results", flattenClusterProcesses(processes)); err != nil {
+		return diag.FromErr(fmt.Errorf(errorClusterProcessesSetting, "results", clusterName, err))
+	}
+
+	d.SetId(encodeStateID(map[string]string{
+		"project_id":   projectID,
+		"cluster_name": clusterName,
+	}))
+
+	return nil
+}
+
+// listClusterProcesses lists the processes of the project that belong to the cluster whose SRV connection
+// string is standardSrv, sorted by replica set and host. Atlas lists the processes of all the clusters of a
+// project together, the processes of a cluster are the ones whose host is named after the SRV host of the
+// cluster, e.g. cluster0-shard-00-00.abcde.mongodb.net for mongodb+srv://cluster0.abcde.mongodb.net.
+func listClusterProcesses(ctx context.Context, connV2 *admin.APIClient, projectID, standardSrv string) ([]admin.ApiHostViewAtlas, error) {
+	// the cluster doesn't have any process until its first deployment completes.
+	if standardSrv == "" {
+		return nil, nil
+	}
+
+	var processes []admin.ApiHostViewAtlas
+
+	for pageNum := 1; ; pageNum++ {
+		page, _, err := connV2.MonitoringAndLogsApi.ListAtlasProcesses(ctx, projectID).PageNum(pageNum).ItemsPerPage(listItemsPerPage).Execute()
+		if err != nil {
+			return nil, fmt.Errorf("error listing processes: %s", err)
+		}
+
+		for i := range page.Results {
+			if isClusterProcess(standardSrv, &page.Results[i]) {
+				processes = append(processes, page.Results[i])
+			}
+		}
+
+		if len(page.Results) < listItemsPerPage {
+			break
+		}
+	}
+
+	sort.SliceStable(processes, func(i, j int) bool {
+		if processes[i].GetReplicaSetName() != processes[j].GetReplicaSetName() {
+			return processes[i].GetReplicaSetName() < processes[j].GetReplicaSetName()
+		}
+		if clusterProcessHost(&processes[i]) != clusterProcessHost(&processes[j]) {
+			return clusterProcessHost(&processes[i]) < clusterProcessHost(&processes[j])
+		}
+		return processes[i].GetPort() < processes[j].GetPort()
+	})
+
+	return processes, nil
+}
+
+// isClusterProcess returns true when the process is a shard or config server member of the cluster whose
+// SRV connection string is standardSrv. The mongos of a sharded cluster run on the hosts of the shards.
+func isClusterProcess(standardSrv string, process *admin.ApiHostViewAtlas) bool {
+	srv, err := url.Parse(standardSrv)
+	if err != nil || srv.Hostname() == "" {
+		return false
+	}
+
+	prefix, domain, ok := strings.Cut(strings.ToLower(srv.Hostname()), ".")
+	if !ok {
+		return false
+	}
+
+	label, processDomain, ok := strings.Cut(strings.ToLower(clusterProcessHost(process)), ".")
+	if !ok || processDomain != domain {
+		return false
+	}
+
+	// the prefix of another cluster can start with the prefix of this one, e.g. app-shard-1 and app.
+	return regexp.MustCompile(`^` + regexp.QuoteMeta(prefix) + `-(shard|config)-\d+-\d+$`).MatchString(label)
+}
+
+// clusterProcessHost returns the host the clients of the cluster connect to, Atlas falls back to
+// the internal hostname of the process when it doesn't have a user alias.
+func clusterProcessHost(process *admin.ApiHostViewAtlas) string {
+	if alias := process.GetUserAlias(); alias != "" {
+		return alias
+	}
+
+	return process.GetHostname()
+}
+
+// clusterProcessRole simplifies the type of a process to its role in the cluster:
+// primary, secondary, config, mongos, arbiter or the lower case type for the other types.
+func clusterProcessRole(typeName string) string {
+	switch {
+	case strings.HasPrefix(typeName, "SHARD_CONFIG"):
+		return "config"
+	case typeName == "SHARD_MONGOS":
+		return "mongos"
+	case typeName == "REPLICA_ARBITER":
+		return "arbiter"
+	case strings.HasSuffix(typeName, "_PRIMARY"):
+		return "primary"
+	case strings.HasSuffix(typeName, "_SECONDARY"):
+		return "secondary"
+	default:
+		return strings.ToLower(typeName)
+	}
+}
+
+func flattenClusterProcesses(processes []admin.ApiHostViewAtlas) []map[string]interface{} {
+	results := make([]map[string]interface{}, len(processes))
+
+	for i := range processes {
+		process := &processes[i]
+		results[i] = map[string]interface{}{
+			"id":               process.GetId(),
+			"hostname":         process.GetHostname(),
+			"user_alias":       clusterProcessHost(process),
+			"port":             process.GetPort(),
+			"replica_set_name": process.GetReplicaSetName(),
+			"shard_name":       process.GetShardName(),
+			"type_name":        process.GetTypeName(),
+			"role":             clusterProcessRole(process.GetTypeName()),
+			"version":          process.GetVersion(),
+			"created":          "",
+			"last_ping":        "",
+		}
+
+		if process.Created != nil {
+			results[i]["created"] = process.GetCreated().Format(time.RFC3339)
+		}
+		if process.LastPing != nil {
+			results[i]["last_ping"] = process.GetLastPing().Format(time.RFC3339)
+		}
+	}
+
+	return results
+}
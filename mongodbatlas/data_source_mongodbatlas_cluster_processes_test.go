@@ -0,0 +1,188 @@
+package mongodbatlas
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"go.mongodb.org/atlas-sdk/v20230201002/admin"
+)
+
+func TestIsClusterProcess(t *testing.T) {
+	const standardSrv = "mongodb+srv://cluster0.abcde.mongodb.net"
+
+	testCases := map[string]struct {
+		process  admin.ApiHostViewAtlas
+		expected bool
+	}{
+		"shard member": {
+			process:  admin.ApiHostViewAtlas{UserAlias: admin.PtrString("cluster0-shard-00-01.abcde.mongodb.net")},
+			expected: true,
+		},
+		"config server": {
+			process:  admin.ApiHostViewAtlas{UserAlias: admin.PtrString("cluster0-config-00-00.abcde.mongodb.net")},
+			expected: true,
+		},
+		"hostname without user alias": {
+			process:  admin.ApiHostViewAtlas{Hostname: admin.PtrString("Cluster0-Shard-00-00.abcde.mongodb.net")},
+			expected: true,
+		},
+		"cluster with the same prefix": {
+			process:  admin.ApiHostViewAtlas{UserAlias: admin.PtrString("cluster01-shard-00-00.abcde.mongodb.net")},
+			expected: false,
+		},
+		"cluster named after a shard": {
+			process:  admin.ApiHostViewAtlas{UserAlias: admin.PtrString("cluster0-shard-1-shard-00-00.abcde.mongodb.net")},
+			expected: false,
+		},
+		"cluster named after a config server": {
+			process:  admin.ApiHostViewAtlas{UserAlias: admin.PtrString("cluster0-config-00-config-00-00.abcde.mongodb.net")},
+			expected: false,
+		},
+		"cluster of another project": {
+			process:  admin.ApiHostViewAtlas{UserAlias: admin.PtrString("cluster0-shard-00-00.fghij.mongodb.net")},
+			expected: false,
+		},
+		"internal hostname": {
+			process: admin.ApiHostViewAtlas{
+				Hostname:  admin.PtrString("atlas-xyz-shard-00-00.abcde.mongodb.net"),
+				UserAlias: admin.PtrString("cluster1-shard-00-00.abcde.mongodb.net"),
+			},
+			expected: false,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if actual := isClusterProcess(standardSrv, &tc.process); actual != tc.expected {
+				t.Errorf("Bad isClusterProcess, expected %t, got %t", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestClusterProcessRole(t *testing.T) {
+	for typeName, expected := range map[string]string{
+		"REPLICA_PRIMARY":        "primary",
+		"SHARD_PRIMARY":          "primary",
+		"REPLICA_SECONDARY":      "secondary",
+		"SHARD_SECONDARY":        "secondary",
+		"SHARD_CONFIG_PRIMARY":   "config",
+		"SHARD_CONFIG_SECONDARY": "config",
+		"SHARD_MONGOS":           "mongos",
+		"REPLICA_ARBITER":        "arbiter",
+		"RECOVERING":             "recovering",
+	} {
+		if actual := clusterProcessRole(typeName); actual != expected {
+			t.Errorf("Bad clusterProcessRole(%s), expected %s, got %s", typeName, expected, actual)
+		}
+	}
+}
+
+func TestMockClusterDSClusterProcesses_basic(t *testing.T) {
+	var (
+		mock           = newAtlasMockServer(t)
+		projectID      = mock.newProject("test-mock-processes")
+		dataSourceName = "data.mongodbatlas_cluster_processes.test"
+		processesPath  = fmt.Sprintf("%s/groups/%s/processes", mockAtlasV2Path, projectID)
+	)
+
+	// orders-shard-1 is another cluster of the project whose name starts like the hosts of orders.
+	for _, name := range []string{"orders", "orders-shard-1"} {
+		mock.seed(fmt.Sprintf("%s/groups/%s/clusters", mockAtlasV2Path, projectID), map[string]interface{}{
+			"name":    name,
+			"groupId": projectID,
+			"replicationSpecs": []interface{}{
+				map[string]interface{}{"numShards": 1, "regionConfigs": []interface{}{}},
+			},
+		})
+	}
+
+	for _, process := range []map[string]interface{}{
+		{"userAlias": "orders-shard-00-01.mock.mongodb.net", "port": 27017, "replicaSetName": "atlas-abc-shard-0", "typeName": "REPLICA_SECONDARY"},
+		{"userAlias": "orders-shard-00-00.mock.mongodb.net", "port": 27017, "replicaSetName": "atlas-abc-shard-0", "typeName": "REPLICA_PRIMARY"},
+		{"userAlias": "ordersarchive-shard-00-00.mock.mongodb.net", "port": 27017, "replicaSetName": "atlas-def-shard-0", "typeName": "REPLICA_PRIMARY"},
+		{"userAlias": "orders-shard-1-shard-00-00.mock.mongodb.net", "port": 27017, "replicaSetName": "atlas-jkl-shard-0", "typeName": "REPLICA_PRIMARY"},
+		{"userAlias": "orders-shard-1-shard-00-01.mock.mongodb.net", "port": 27017, "replicaSetName": "atlas-jkl-shard-0", "typeName": "REPLICA_SECONDARY"},
+		{"userAlias": "billing-shard-00-00.mock.mongodb.net", "port": 27017, "replicaSetName": "atlas-ghi-shard-0", "typeName": "REPLICA_PRIMARY"},
+	} {
+		process["hostname"] = "atlas-" + process["userAlias"].(string)
+		process["groupId"] = projectID
+		process["version"] = "6.0.8"
+		mock.seed(processesPath, process)
+	}
+
+	testMockResourceUnitTest(t, mock, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "mongodbatlas_cluster_processes" "test" {
+						project_id   = %q
+						cluster_name = "orders"
+					}
+				`, projectID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "results.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "results.0.user_alias", "orders-shard-00-00.mock.mongodb.net"),
+					resource.TestCheckResourceAttr(dataSourceName, "results.0.hostname", "atlas-orders-shard-00-00.mock.mongodb.net"),
+					resource.TestCheckResourceAttr(dataSourceName, "results.0.port", "27017"),
+					resource.TestCheckResourceAttr(dataSourceName, "results.0.replica_set_name", "atlas-abc-shard-0"),
+					resource.TestCheckResourceAttr(dataSourceName, "results.0.type_name", "REPLICA_PRIMARY"),
+					resource.TestCheckResourceAttr(dataSourceName, "results.0.role", "primary"),
+					resource.TestCheckResourceAttr(dataSourceName, "results.0.version", "6.0.8"),
+					resource.TestCheckResourceAttr(dataSourceName, "results.1.user_alias", "orders-shard-00-01.mock.mongodb.net"),
+					resource.TestCheckResourceAttr(dataSourceName, "results.1.role", "secondary"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "mongodbatlas_cluster_processes" "test" {
+						project_id   = %q
+						cluster_name = "orders-shard-1"
+					}
+				`, projectID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "results.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "results.0.user_alias", "orders-shard-1-shard-00-00.mock.mongodb.net"),
+					resource.TestCheckResourceAttr(dataSourceName, "results.1.user_alias", "orders-shard-1-shard-00-01.mock.mongodb.net"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccClusterDSClusterProcesses_basic(t *testing.T) {
+	SkipTestForCI(t)
+	var (
+		dataSourceName = "data.mongodbatlas_cluster_processes.test"
+		orgID          = os.Getenv("MONGODB_ATLAS_ORG_ID")
+		projectName    = acctest.RandomWithPrefix("test-acc")
+		rName          = acctest.RandomWithPrefix("test-acc")
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheckBasic(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckMongoDBAtlasAdvancedClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongoDBAtlasAdvancedClusterConfigSingleProvider(orgID, projectName, rName) + `
+data "mongodbatlas_cluster_processes" "test" {
+	project_id   = mongodbatlas_advanced_cluster.test.project_id
+	cluster_name = mongodbatlas_advanced_cluster.test.name
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "results.#", "4"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "results.0.user_alias"),
+					resource.TestCheckResourceAttr(dataSourceName, "results.0.port", "27017"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "results.0.replica_set_name"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "results.0.type_name"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "results.0.version"),
+				),
+			},
+		},
+	})
+}
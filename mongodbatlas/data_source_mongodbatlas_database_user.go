@@ -48,6 +48,10 @@ func dataSourceMongoDBAtlasDatabaseUser() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"delete_after_date": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"roles": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -106,7 +110,7 @@ func dataSourceMongoDBAtlasDatabaseUser() *schema.Resource {
 
 func dataSourceMongoDBAtlasDatabaseUserRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// Get client connection.
-	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 	projectID := d.Get("project_id").(string)
 	username := d.Get("username").(string)
 
@@ -124,7 +128,7 @@ func dataSourceMongoDBAtlasDatabaseUserRead(ctx context.Context, d *schema.Resou
 		authDatabaseName = authDBName.(string)
 	}
 
-	dbUser, _, err := conn.DatabaseUsers.Get(ctx, authDatabaseName, projectID, username)
+	dbUser, _, err := connV2.DatabaseUsersApi.GetDatabaseUser(ctx, projectID, authDatabaseName, username).Execute()
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error getting database user information: %s", err))
 	}
@@ -143,19 +147,19 @@ func dataSourceMongoDBAtlasDatabaseUserRead(ctx context.Context, d *schema.Resou
 		}
 	}
 
-	if err := d.Set("x509_type", dbUser.X509Type); err != nil {
+	if err := d.Set("x509_type", dbUser.GetX509Type()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `x509_type` for database user (%s): %s", d.Id(), err))
 	}
 
-	if err := d.Set("aws_iam_type", dbUser.AWSIAMType); err != nil {
+	if err := d.Set("aws_iam_type", dbUser.GetAwsIAMType()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `aws_iam_type` for database user (%s): %s", d.Id(), err))
 	}
 
-	if err := d.Set("oidc_auth_type", dbUser.OIDCAuthType); err != nil {
+	if err := d.Set("oidc_auth_type", dbUser.GetOidcAuthType()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `oidc_auth_type` for database user (%s): %s", d.Id(), err))
 	}
 
-	if err := d.Set("ldap_auth_type", dbUser.LDAPAuthType); err != nil {
+	if err := d.Set("ldap_auth_type", dbUser.GetLdapAuthType()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `ldap_auth_type` for database user (%s): %s", d.Id(), err))
 	}
 
@@ -163,7 +167,7 @@ func dataSourceMongoDBAtlasDatabaseUserRead(ctx context.Context, d *schema.Resou
 		return diag.FromErr(fmt.Errorf("error setting `roles` for database user (%s): %s", d.Id(), err))
 	}
 
-	if err := d.Set("labels", flattenLabels(dbUser.Labels)); err != nil {
+	if err := d.Set("labels", flattenLabels(fromComponentLabels(dbUser.Labels))); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `labels` for database user (%s): %s", d.Id(), err))
 	}
 
@@ -171,6 +175,10 @@ func dataSourceMongoDBAtlasDatabaseUserRead(ctx context.Context, d *schema.Resou
 		return diag.FromErr(fmt.Errorf("error setting `scopes` for database user (%s): %s", d.Id(), err))
 	}
 
+	if err := d.Set("delete_after_date", timeToString(dbUser.DeleteAfterDate)); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting `delete_after_date` for database user (%s): %s", d.Id(), err))
+	}
+
 	d.SetId(encodeStateID(map[string]string{
 		"project_id":         projectID,
 		"username":           username,
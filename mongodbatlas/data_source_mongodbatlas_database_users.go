@@ -9,7 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
-	matlas "go.mongodb.org/atlas/mongodbatlas"
+	"go.mongodb.org/atlas-sdk/v20230201002/admin"
 )
 
 func dataSourceMongoDBAtlasDatabaseUsers() *schema.Resource {
@@ -54,6 +54,10 @@ func dataSourceMongoDBAtlasDatabaseUsers() *schema.Resource {
 							Type:     schema.TypeString,
 							Computed: true,
 						},
+						"delete_after_date": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
 						"roles": {
 							Type:     schema.TypeList,
 							Computed: true,
@@ -115,13 +119,21 @@ func dataSourceMongoDBAtlasDatabaseUsers() *schema.Resource {
 
 func dataSourceMongoDBAtlasDatabaseUsersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// Get client connection.
-	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 
 	projectID := d.Get("project_id").(string)
 
-	dbUsers, _, err := conn.DatabaseUsers.List(ctx, projectID, nil)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error getting database users information: %s", err))
+	var dbUsers []admin.CloudDatabaseUser
+	for pageNum := 1; ; pageNum++ {
+		page, _, err := connV2.DatabaseUsersApi.ListDatabaseUsers(ctx, projectID).PageNum(pageNum).ItemsPerPage(listItemsPerPage).Execute()
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error getting database users information: %s", err))
+		}
+
+		dbUsers = append(dbUsers, page.Results...)
+		if len(page.Results) < listItemsPerPage {
+			break
+		}
 	}
 
 	if err := d.Set("results", flattenDBUsers(dbUsers)); err != nil {
@@ -133,7 +145,7 @@ func dataSourceMongoDBAtlasDatabaseUsersRead(ctx context.Context, d *schema.Reso
 	return nil
 }
 
-func flattenDBUsers(dbUsers []matlas.DatabaseUser) []map[string]interface{} {
+func flattenDBUsers(dbUsers []admin.CloudDatabaseUser) []map[string]interface{} {
 	var dbUsersMap []map[string]interface{}
 
 	if len(dbUsers) > 0 {
@@ -143,14 +155,15 @@ func flattenDBUsers(dbUsers []matlas.DatabaseUser) []map[string]interface{} {
 			dbUsersMap[i] = map[string]interface{}{
 				"roles":              flattenRoles(dbUsers[i].Roles),
 				"username":           dbUsers[i].Username,
-				"project_id":         dbUsers[i].GroupID,
+				"project_id":         dbUsers[i].GroupId,
 				"auth_database_name": dbUsers[i].DatabaseName,
-				"x509_type":          dbUsers[i].X509Type,
-				"aws_iam_type":       dbUsers[i].AWSIAMType,
-				"oidc_auth_type":     dbUsers[i].OIDCAuthType,
-				"ldap_auth_type":     dbUsers[i].LDAPAuthType,
-				"labels":             flattenLabels(dbUsers[i].Labels),
+				"x509_type":          dbUsers[i].GetX509Type(),
+				"aws_iam_type":       dbUsers[i].GetAwsIAMType(),
+				"oidc_auth_type":     dbUsers[i].GetOidcAuthType(),
+				"ldap_auth_type":     dbUsers[i].GetLdapAuthType(),
+				"labels":             flattenLabels(fromComponentLabels(dbUsers[i].Labels)),
 				"scopes":             flattenScopes(dbUsers[i].Scopes),
+				"delete_after_date":  timeToString(dbUsers[i].DeleteAfterDate),
 			}
 		}
 	}
@@ -0,0 +1,205 @@
+package mongodbatlas
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"go.mongodb.org/atlas-sdk/v20230201002/admin"
+)
+
+const (
+	errorMongoDBVersionsRead    = "error reading the MongoDB versions of the project (%s): %s"
+	errorMongoDBVersionsSetting = "error setting `%s` for the MongoDB versions of the project (%s): %s"
+
+	// mongoDBVersionsMediaType is the version of the API listing the MongoDB versions available in a project,
+	// which the Atlas SDK used by the provider doesn't implement.
+	mongoDBVersionsMediaType = "application/vnd.atlas.2023-01-01+json"
+)
+
+// mongoDBAvailableVersion is a MongoDB version available in a project for a cloud provider and instance size.
+type mongoDBAvailableVersion struct {
+	CloudProvider string `json:"cloudProvider"`
+	DefaultStatus string `json:"defaultStatus"`
+	InstanceSize  string `json:"instanceSize"`
+	Version       string `json:"version"`
+}
+
+type mongoDBAvailableVersionsPage struct {
+	Results []mongoDBAvailableVersion `json:"results,omitempty"`
+}
+
+func dataSourceMongoDBAtlasMongoDBVersions() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMongoDBAtlasMongoDBVersionsRead,
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"provider_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"AWS", "AZURE", "GCP"}, false),
+			},
+			"instance_size": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"results": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"provider_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"instance_size": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"default_status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"versions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"default_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceMongoDBAtlasMongoDBVersionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	connV2 := meta.(*MongoDBClient).AtlasV2
+
+	projectID := d.Get("project_id").(string)
+	providerName := d.Get("provider_name").(string)
+	instanceSize := d.Get("instance_size").(string)
+
+	versions, err := listMongoDBAvailableVersions(ctx, connV2, projectID, providerName, instanceSize)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf(errorMongoDBVersionsRead, projectID, err))
+	}
+
+	results := make([]map[string]interface{}, len(versions))
+	distinct := make([]string, 0, len(versions))
+	defaultVersion := ""
+	for i := range versions {
+		version := versions[i].Version
+		results[i] = map[string]interface{}{
+			"version":        version,
+			"provider_name":  versions[i].CloudProvider,
+			"instance_size":  versions[i].InstanceSize,
+			"default_status": versions[i].DefaultStatus,
+		}
+
+		if !containsString(distinct, version) {
+			distinct = append(distinct, version)
+		}
+		if defaultVersion == "" && versions[i].DefaultStatus == "DEFAULT" {
+			defaultVersion = version
+		}
+	}
+
+	if err := d.Set("results", results); err != nil {
+		return diag.FromErr(fmt.Errorf(errorMongoDBVersionsSetting, "results", projectID, err))
+	}
+
+	if err := d.Set("versions", distinct); err != nil {
+		return diag.FromErr(fmt.Errorf(errorMongoDBVersionsSetting, "versions", projectID, err))
+	}
+
+	if err := d.Set("default_version", defaultVersion); err != nil {
+		return diag.FromErr(fmt.Errorf(errorMongoDBVersionsSetting, "default_version", projectID, err))
+	}
+
+	d.SetId(encodeStateID(map[string]string{
+		"project_id":    projectID,
+		"provider_name": providerName,
+		"instance_size": instanceSize,
+	}))
+
+	return nil
+}
+
+// listMongoDBAvailableVersions lists the MongoDB versions available in the project, optionally for a cloud provider and
+// an instance size, sorted from the oldest version to the latest.
+func listMongoDBAvailableVersions(ctx context.Context, connV2 *admin.APIClient, projectID, providerName, instanceSize string) ([]mongoDBAvailableVersion, error) {
+	var versions []mongoDBAvailableVersion
+
+	for pageNum := 1; ; pageNum++ {
+		query := url.Values{}
+		query.Set("pageNum", strconv.Itoa(pageNum))
+		query.Set("itemsPerPage", strconv.Itoa(listItemsPerPage))
+		if providerName != "" {
+			query.Set("cloudProvider", providerName)
+		}
+		if instanceSize != "" {
+			query.Set("instanceSize", instanceSize)
+		}
+
+		page := new(mongoDBAvailableVersionsPage)
+		path := fmt.Sprintf("/api/atlas/v2/groups/%s/mongoDBVersions?%s", url.PathEscape(projectID), query.Encode())
+		if _, err := doAtlasAPIRequest(ctx, connV2, mongoDBVersionsMediaType, http.MethodGet, path, nil, page); err != nil {
+			return nil, err
+		}
+
+		versions = append(versions, page.Results...)
+
+		if len(page.Results) < listItemsPerPage {
+			break
+		}
+	}
+
+	sort.SliceStable(versions, func(i, j int) bool {
+		return compareMongoDBVersions(versions[i].Version, versions[j].Version) < 0
+	})
+
+	return versions, nil
+}
+
+// compareMongoDBVersions compares two versions such as 6.0 and 7.0.2 component by component, returning -1, 0 or 1.
+func compareMongoDBVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+	}
+
+	return 0
+}
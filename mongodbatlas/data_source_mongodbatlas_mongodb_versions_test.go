@@ -0,0 +1,106 @@
+package mongodbatlas
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestCompareMongoDBVersions(t *testing.T) {
+	testCases := []struct {
+		a, b     string
+		expected int
+	}{
+		{a: "6.0", b: "7.0", expected: -1},
+		{a: "10.0", b: "9.0", expected: 1},
+		{a: "7.0", b: "7.0", expected: 0},
+		{a: "7.0", b: "7.0.2", expected: -1},
+		{a: "8.0.1", b: "8.0", expected: 1},
+	}
+
+	for _, tc := range testCases {
+		if actual := compareMongoDBVersions(tc.a, tc.b); actual != tc.expected {
+			t.Errorf("Bad compareMongoDBVersions(%s, %s), expected %d, got %d", tc.a, tc.b, tc.expected, actual)
+		}
+	}
+}
+
+func TestMockConfigDSMongoDBVersions_basic(t *testing.T) {
+	var (
+		mock           = newAtlasMockServer(t)
+		projectID      = mock.newProject("test-mock-versions")
+		dataSourceName = "data.mongodbatlas_mongodb_versions.test"
+		versionsPath   = fmt.Sprintf("%s/groups/%s/mongoDBVersions", mockAtlasV2Path, projectID)
+	)
+
+	for _, version := range []map[string]interface{}{
+		{"version": "7.0", "defaultStatus": "DEFAULT"},
+		{"version": "8.0", "defaultStatus": "NOT_DEFAULT"},
+		{"version": "6.0", "defaultStatus": "NOT_DEFAULT"},
+	} {
+		version["cloudProvider"] = "AWS"
+		version["instanceSize"] = "M10"
+		mock.seed(versionsPath, version)
+	}
+
+	testMockResourceUnitTest(t, mock, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "mongodbatlas_mongodb_versions" "test" {
+						project_id    = %q
+						provider_name = "AWS"
+						instance_size = "M10"
+					}
+				`, projectID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "results.#", "3"),
+					resource.TestCheckResourceAttr(dataSourceName, "results.0.version", "6.0"),
+					resource.TestCheckResourceAttr(dataSourceName, "results.0.provider_name", "AWS"),
+					resource.TestCheckResourceAttr(dataSourceName, "results.0.instance_size", "M10"),
+					resource.TestCheckResourceAttr(dataSourceName, "results.0.default_status", "NOT_DEFAULT"),
+					resource.TestCheckResourceAttr(dataSourceName, "versions.#", "3"),
+					resource.TestCheckResourceAttr(dataSourceName, "versions.0", "6.0"),
+					resource.TestCheckResourceAttr(dataSourceName, "versions.1", "7.0"),
+					resource.TestCheckResourceAttr(dataSourceName, "versions.2", "8.0"),
+					resource.TestCheckResourceAttr(dataSourceName, "default_version", "7.0"),
+				),
+			},
+		},
+	})
+
+	if count := mock.requestCount("GET", versionsPath); count == 0 {
+		t.Errorf("expected the versions to be read from %s", versionsPath)
+	}
+}
+
+func TestAccConfigDSMongoDBVersions_basic(t *testing.T) {
+	SkipTestForCI(t)
+	var (
+		dataSourceName = "data.mongodbatlas_mongodb_versions.test"
+		projectID      = os.Getenv("MONGODB_ATLAS_PROJECT_ID")
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheckBasic(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "mongodbatlas_mongodb_versions" "test" {
+						project_id    = %q
+						provider_name = "AWS"
+						instance_size = "M10"
+					}
+				`, projectID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dataSourceName, "results.#"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "versions.0"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "default_version"),
+				),
+			},
+		},
+	})
+}
@@ -74,11 +74,11 @@ func dataSourceMongoDBAtlasNetworkContainer() *schema.Resource {
 
 func dataSourceMongoDBAtlasNetworkContainerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// Get client connection.
-	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 	projectID := d.Get("project_id").(string)
 	containerID := getEncodedID(d.Get("container_id").(string), "container_id")
 
-	container, resp, err := conn.Containers.Get(ctx, projectID, containerID)
+	container, resp, err := connV2.NetworkPeeringApi.GetPeeringContainer(ctx, projectID, containerID).Execute()
 	if err != nil {
 		if resp != nil && resp.StatusCode == http.StatusNotFound {
 			return nil
@@ -87,47 +87,47 @@ func dataSourceMongoDBAtlasNetworkContainerRead(ctx context.Context, d *schema.R
 		return diag.FromErr(fmt.Errorf(errorContainerRead, containerID, err))
 	}
 
-	if err := d.Set("atlas_cidr_block", container.AtlasCIDRBlock); err != nil {
+	if err := d.Set("atlas_cidr_block", container.GetAtlasCidrBlock()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `atlas_cidr_block` for Network Container (%s): %s", d.Id(), err))
 	}
 
-	if err = d.Set("provider_name", container.ProviderName); err != nil {
+	if err = d.Set("provider_name", container.GetProviderName()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `provider_name` for Network Container (%s): %s", d.Id(), err))
 	}
 
-	if err = d.Set("region_name", container.RegionName); err != nil {
+	if err = d.Set("region_name", container.GetRegionName()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `region_name` for Network Container (%s): %s", d.Id(), err))
 	}
 
-	if err = d.Set("region", container.Region); err != nil {
+	if err = d.Set("region", container.GetRegion()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `region` for Network Container (%s): %s", d.Id(), err))
 	}
 
-	if err = d.Set("azure_subscription_id", container.AzureSubscriptionID); err != nil {
+	if err = d.Set("azure_subscription_id", container.GetAzureSubscriptionId()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `azure_subscription_id` for Network Container (%s): %s", d.Id(), err))
 	}
 
-	if err = d.Set("provisioned", container.Provisioned); err != nil {
+	if err = d.Set("provisioned", container.GetProvisioned()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `provisioned` for Network Container (%s): %s", d.Id(), err))
 	}
 
-	if err = d.Set("gcp_project_id", container.GCPProjectID); err != nil {
+	if err = d.Set("gcp_project_id", container.GetGcpProjectId()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `gcp_project_id` for Network Container (%s): %s", d.Id(), err))
 	}
 
-	if err = d.Set("network_name", container.NetworkName); err != nil {
+	if err = d.Set("network_name", container.GetNetworkName()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `network_name` for Network Container (%s): %s", d.Id(), err))
 	}
 
-	if err = d.Set("gcp_project_id", container.GCPProjectID); err != nil {
+	if err = d.Set("gcp_project_id", container.GetGcpProjectId()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `gcp_project_id` for Network Container (%s): %s", d.Id(), err))
 	}
 
-	if err = d.Set("vpc_id", container.VPCID); err != nil {
+	if err = d.Set("vpc_id", container.GetVpcId()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `vpc_id` for Network Container (%s): %s", d.Id(), err))
 	}
 
-	if err = d.Set("vnet_name", container.VNetName); err != nil {
+	if err = d.Set("vnet_name", container.GetVnetName()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `vnet_name` for Network Container (%s): %s", d.Id(), err))
 	}
 
@@ -135,7 +135,7 @@ func dataSourceMongoDBAtlasNetworkContainerRead(ctx context.Context, d *schema.R
 		return diag.FromErr(fmt.Errorf("error setting `regions` for Network Container (%s): %s", d.Id(), err))
 	}
 
-	d.SetId(container.ID)
+	d.SetId(container.GetId())
 
 	return nil
 }
@@ -7,7 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	matlas "go.mongodb.org/atlas/mongodbatlas"
+	"go.mongodb.org/atlas-sdk/v20230201002/admin"
 )
 
 func dataSourceMongoDBAtlasNetworkContainers() *schema.Resource {
@@ -87,15 +87,24 @@ func dataSourceMongoDBAtlasNetworkContainers() *schema.Resource {
 
 func dataSourceMongoDBAtlasNetworkContainersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// Get client connection.
-	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 	projectID := d.Get("project_id").(string)
 
-	containers, _, err := conn.Containers.List(ctx, projectID, &matlas.ContainersListOptions{
-		ProviderName: d.Get("provider_name").(string),
-	})
+	var containers []admin.CloudProviderContainer
+	for pageNum := 1; ; pageNum++ {
+		page, _, err := connV2.NetworkPeeringApi.ListPeeringContainerByCloudProvider(ctx, projectID).
+			ProviderName(d.Get("provider_name").(string)).
+			PageNum(pageNum).
+			ItemsPerPage(listItemsPerPage).
+			Execute()
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error getting network peering containers information: %s", err))
+		}
 
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error getting network peering containers information: %s", err))
+		containers = append(containers, page.Results...)
+		if len(page.Results) < listItemsPerPage {
+			break
+		}
 	}
 
 	if err := d.Set("results", flattenNetworkContainers(containers)); err != nil {
@@ -107,7 +116,7 @@ func dataSourceMongoDBAtlasNetworkContainersRead(ctx context.Context, d *schema.
 	return nil
 }
 
-func flattenNetworkContainers(containers []matlas.Container) []map[string]interface{} {
+func flattenNetworkContainers(containers []admin.CloudProviderContainer) []map[string]interface{} {
 	var containersMap []map[string]interface{}
 
 	if len(containers) > 0 {
@@ -115,17 +124,17 @@ func flattenNetworkContainers(containers []matlas.Container) []map[string]interf
 
 		for i := range containers {
 			containersMap[i] = map[string]interface{}{
-				"id":                    containers[i].ID,
-				"atlas_cidr_block":      containers[i].AtlasCIDRBlock,
-				"provider_name":         containers[i].ProviderName,
-				"region_name":           containers[i].RegionName,
-				"region":                containers[i].Region,
-				"azure_subscription_id": containers[i].AzureSubscriptionID,
-				"provisioned":           containers[i].Provisioned,
-				"gcp_project_id":        containers[i].GCPProjectID,
-				"network_name":          containers[i].NetworkName,
-				"vpc_id":                containers[i].VPCID,
-				"vnet_name":             containers[i].VNetName,
+				"id":                    containers[i].GetId(),
+				"atlas_cidr_block":      containers[i].GetAtlasCidrBlock(),
+				"provider_name":         containers[i].GetProviderName(),
+				"region_name":           containers[i].GetRegionName(),
+				"region":                containers[i].GetRegion(),
+				"azure_subscription_id": containers[i].GetAzureSubscriptionId(),
+				"provisioned":           containers[i].GetProvisioned(),
+				"gcp_project_id":        containers[i].GetGcpProjectId(),
+				"network_name":          containers[i].GetNetworkName(),
+				"vpc_id":                containers[i].GetVpcId(),
+				"vnet_name":             containers[i].GetVnetName(),
 				"regions":               containers[i].Regions,
 			}
 		}
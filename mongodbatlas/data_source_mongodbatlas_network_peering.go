@@ -109,11 +109,11 @@ func dataSourceMongoDBAtlasNetworkPeering() *schema.Resource {
 
 func dataSourceMongoDBAtlasNetworkPeeringRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// Get client connection.
-	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 	projectID := d.Get("project_id").(string)
 	peerID := getEncodedID(d.Get("peering_id").(string), "peer_id")
 
-	peer, resp, err := conn.Peers.Get(ctx, projectID, peerID)
+	peer, resp, err := connV2.NetworkPeeringApi.GetPeeringConnection(ctx, projectID, peerID).Execute()
 	if err != nil {
 		if resp != nil && resp.StatusCode == http.StatusNotFound {
 			return nil
@@ -123,88 +123,94 @@ func dataSourceMongoDBAtlasNetworkPeeringRead(ctx context.Context, d *schema.Res
 	}
 
 	// Workaround until fix.
-	if peer.AccepterRegionName != "" {
-		if err := d.Set("accepter_region_name", peer.AccepterRegionName); err != nil {
+	if peer.GetAccepterRegionName() != "" {
+		if err := d.Set("accepter_region_name", peer.GetAccepterRegionName()); err != nil {
 			return diag.FromErr(fmt.Errorf("error setting `accepter_region_name` for Network Peering Connection (%s): %s", peerID, err))
 		}
 	}
 
-	if err := d.Set("aws_account_id", peer.AWSAccountID); err != nil {
+	if err := d.Set("aws_account_id", peer.GetAwsAccountId()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `aws_account_id` for Network Peering Connection (%s): %s", peerID, err))
 	}
 
-	if err := d.Set("container_id", peer.ContainerID); err != nil {
+	if err := d.Set("container_id", peer.GetContainerId()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `container_id` for Network Peering Connection (%s): %s", peerID, err))
 	}
 
-	if err := d.Set("route_table_cidr_block", peer.RouteTableCIDRBlock); err != nil {
+	if err := d.Set("route_table_cidr_block", peer.GetRouteTableCidrBlock()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `route_table_cidr_block` for Network Peering Connection (%s): %s", peerID, err))
 	}
 
-	if err := d.Set("vpc_id", peer.VpcID); err != nil {
+	if err := d.Set("vpc_id", peer.GetVpcId()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `vpc_id` for Network Peering Connection (%s): %s", peerID, err))
 	}
 
-	if err := d.Set("connection_id", peer.ConnectionID); err != nil {
+	if err := d.Set("connection_id", peer.GetConnectionId()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `connection_id` for Network Peering Connection (%s): %s", peerID, err))
 	}
 
-	if err := d.Set("error_state_name", peer.ErrorStateName); err != nil {
+	if err := d.Set("error_state_name", peer.GetErrorStateName()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `error_state_name` for Network Peering Connection (%s): %s", peerID, err))
 	}
 
-	if err := d.Set("atlas_id", peer.ID); err != nil {
+	if err := d.Set("atlas_id", peer.GetId()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `atlas_id` for Network Peering Connection (%s): %s", peerID, err))
 	}
 
-	if err := d.Set("status_name", peer.StatusName); err != nil {
+	if err := d.Set("status_name", peer.GetStatusName()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `status_name` for Network Peering Connection (%s): %s", peerID, err))
 	}
 
-	if err := d.Set("atlas_cidr_block", peer.AtlasCIDRBlock); err != nil {
+	// the Atlas SDK doesn't return the CIDR block of the peering connection, the one of its container is the same.
+	container, _, err := connV2.NetworkPeeringApi.GetPeeringContainer(ctx, projectID, peer.ContainerId).Execute()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf(errorContainerRead, peer.ContainerId, err))
+	}
+
+	if err := d.Set("atlas_cidr_block", container.GetAtlasCidrBlock()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `atlas_cidr_block` for Network Peering Connection (%s): %s", peerID, err))
 	}
 
-	if err := d.Set("azure_directory_id", peer.AzureDirectoryID); err != nil {
+	if err := d.Set("azure_directory_id", peer.GetAzureDirectoryId()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `azure_directory_id` for Network Peering Connection (%s): %s", peerID, err))
 	}
 
-	if err := d.Set("azure_subscription_id", peer.AzureSubscriptionID); err != nil {
+	if err := d.Set("azure_subscription_id", peer.GetAzureSubscriptionId()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `azure_subscription_id` for Network Peering Connection (%s): %s", peerID, err))
 	}
 
-	if err := d.Set("resource_group_name", peer.ResourceGroupName); err != nil {
+	if err := d.Set("resource_group_name", peer.GetResourceGroupName()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `resource_group_name` for Network Peering Connection (%s): %s", peerID, err))
 	}
 
-	if err := d.Set("vnet_name", peer.VNetName); err != nil {
+	if err := d.Set("vnet_name", peer.GetVnetName()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `vnet_name` for Network Peering Connection (%s): %s", peerID, err))
 	}
 
-	if err := d.Set("error_state", peer.ErrorState); err != nil {
+	if err := d.Set("error_state", peer.GetErrorState()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `error_state` for Network Peering Connection (%s): %s", peerID, err))
 	}
 
-	if err := d.Set("status", peer.Status); err != nil {
+	if err := d.Set("status", peer.GetStatus()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `status` for Network Peering Connection (%s): %s", peerID, err))
 	}
 
-	if err := d.Set("gcp_project_id", peer.GCPProjectID); err != nil {
+	if err := d.Set("gcp_project_id", peer.GetGcpProjectId()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `gcp_project_id` for Network Peering Connection (%s): %s", peerID, err))
 	}
 
-	if err := d.Set("network_name", peer.NetworkName); err != nil {
+	if err := d.Set("network_name", peer.GetNetworkName()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `network_name` for Network Peering Connection (%s): %s", peerID, err))
 	}
 
-	if err := d.Set("error_message", peer.ErrorMessage); err != nil {
+	if err := d.Set("error_message", peer.GetErrorMessage()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `error_message` for Network Peering Connection (%s): %s", peerID, err))
 	}
 
 	provider := "AWS"
-	if peer.VNetName != "" {
+	if peer.GetVnetName() != "" {
 		provider = "AZURE"
-	} else if peer.NetworkName != "" {
+	} else if peer.GetNetworkName() != "" {
 		provider = "GCP"
 	}
 
@@ -214,7 +220,7 @@ func dataSourceMongoDBAtlasNetworkPeeringRead(ctx context.Context, d *schema.Res
 
 	d.SetId(encodeStateID(map[string]string{
 		"project_id":    projectID,
-		"peer_id":       peer.ID,
+		"peer_id":       peer.GetId(),
 		"provider_name": provider,
 	}))
 
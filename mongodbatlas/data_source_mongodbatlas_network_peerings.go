@@ -8,7 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
-	matlas "go.mongodb.org/atlas/mongodbatlas"
+	"go.mongodb.org/atlas-sdk/v20230201002/admin"
 )
 
 func dataSourceMongoDBAtlasNetworkPeerings() *schema.Resource {
@@ -115,15 +115,37 @@ func dataSourceMongoDBAtlasNetworkPeerings() *schema.Resource {
 
 func dataSourceMongoDBAtlasNetworkPeeringsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// Get client connection.
-	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 	projectID := d.Get("project_id").(string)
 
-	peers, _, err := conn.Peers.List(ctx, projectID, nil)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error getting network peering connections information: %s", err))
+	var peers []admin.BaseNetworkPeeringConnectionSettings
+	for pageNum := 1; ; pageNum++ {
+		page, _, err := connV2.NetworkPeeringApi.ListPeeringConnections(ctx, projectID).PageNum(pageNum).ItemsPerPage(listItemsPerPage).Execute()
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error getting network peering connections information: %s", err))
+		}
+
+		peers = append(peers, page.Results...)
+		if len(page.Results) < listItemsPerPage {
+			break
+		}
+	}
+
+	// the Atlas SDK doesn't return the CIDR block of the peering connections, the one of their container is the same.
+	cidrBlocks := make(map[string]string)
+	for i := range peers {
+		if _, ok := cidrBlocks[peers[i].ContainerId]; ok {
+			continue
+		}
+
+		container, _, err := connV2.NetworkPeeringApi.GetPeeringContainer(ctx, projectID, peers[i].ContainerId).Execute()
+		if err != nil {
+			return diag.FromErr(fmt.Errorf(errorContainerRead, peers[i].ContainerId, err))
+		}
+		cidrBlocks[peers[i].ContainerId] = container.GetAtlasCidrBlock()
 	}
 
-	if err := d.Set("results", flattenNetworkPeerings(peers)); err != nil {
+	if err := d.Set("results", flattenNetworkPeerings(peers, cidrBlocks)); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `result` for network peering connections: %s", err))
 	}
 
@@ -132,33 +154,33 @@ func dataSourceMongoDBAtlasNetworkPeeringsRead(ctx context.Context, d *schema.Re
 	return nil
 }
 
-func flattenNetworkPeerings(peers []matlas.Peer) []map[string]interface{} {
+func flattenNetworkPeerings(peers []admin.BaseNetworkPeeringConnectionSettings, cidrBlocks map[string]string) []map[string]interface{} {
 	var peersMap []map[string]interface{}
 
 	if len(peers) > 0 {
 		peersMap = make([]map[string]interface{}, len(peers))
 		for i := range peers {
 			peersMap[i] = map[string]interface{}{
-				"peering_id":             peers[i].ID,
-				"container_id":           peers[i].ContainerID,
-				"accepter_region_name":   peers[i].AccepterRegionName,
-				"aws_account_id":         peers[i].AWSAccountID,
+				"peering_id":             peers[i].GetId(),
+				"container_id":           peers[i].ContainerId,
+				"accepter_region_name":   peers[i].GetAccepterRegionName(),
+				"aws_account_id":         peers[i].GetAwsAccountId(),
 				"provider_name":          getProviderNameByPeer(&peers[i]),
-				"route_table_cidr_block": peers[i].RouteTableCIDRBlock,
-				"vpc_id":                 peers[i].VpcID,
-				"connection_id":          peers[i].ConnectionID,
-				"error_state_name":       peers[i].ErrorStateName,
-				"status_name":            peers[i].StatusName,
-				"atlas_cidr_block":       peers[i].AtlasCIDRBlock,
-				"azure_directory_id":     peers[i].AzureDirectoryID,
-				"azure_subscription_id":  peers[i].AzureSubscriptionID,
-				"resource_group_name":    peers[i].ResourceGroupName,
-				"vnet_name":              peers[i].VNetName,
-				"error_state":            peers[i].ErrorState,
-				"status":                 peers[i].Status,
-				"gcp_project_id":         peers[i].GCPProjectID,
-				"network_name":           peers[i].NetworkName,
-				"error_message":          peers[i].ErrorMessage,
+				"route_table_cidr_block": peers[i].GetRouteTableCidrBlock(),
+				"vpc_id":                 peers[i].GetVpcId(),
+				"connection_id":          peers[i].GetConnectionId(),
+				"error_state_name":       peers[i].GetErrorStateName(),
+				"status_name":            peers[i].GetStatusName(),
+				"atlas_cidr_block":       cidrBlocks[peers[i].ContainerId],
+				"azure_directory_id":     peers[i].GetAzureDirectoryId(),
+				"azure_subscription_id":  peers[i].GetAzureSubscriptionId(),
+				"resource_group_name":    peers[i].GetResourceGroupName(),
+				"vnet_name":              peers[i].GetVnetName(),
+				"error_state":            peers[i].GetErrorState(),
+				"status":                 peers[i].GetStatus(),
+				"gcp_project_id":         peers[i].GetGcpProjectId(),
+				"network_name":           peers[i].GetNetworkName(),
+				"error_message":          peers[i].GetErrorMessage(),
 			}
 		}
 	}
@@ -166,11 +188,11 @@ func flattenNetworkPeerings(peers []matlas.Peer) []map[string]interface{} {
 	return peersMap
 }
 
-func getProviderNameByPeer(peer *matlas.Peer) string {
+func getProviderNameByPeer(peer *admin.BaseNetworkPeeringConnectionSettings) string {
 	provider := "AWS"
-	if peer.VNetName != "" {
+	if peer.GetVnetName() != "" {
 		provider = "AZURE"
-	} else if peer.NetworkName != "" {
+	} else if peer.GetNetworkName() != "" {
 		provider = "GCP"
 	}
 
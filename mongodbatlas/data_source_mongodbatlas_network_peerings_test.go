@@ -1,10 +1,12 @@
 package mongodbatlas
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	matlas "go.mongodb.org/atlas/mongodbatlas"
 )
@@ -69,3 +71,48 @@ data "mongodbatlas_network_peerings" "test" {
 }
 `, projectID, vpcID, awsAccountID, vpcCIDRBlock, awsRegion)
 }
+
+func TestMockNetworkDSNetworkPeerings_basic(t *testing.T) {
+	var (
+		mock      = newAtlasMockServer(t)
+		projectID = mock.newProject("test-mock")
+		r         = dataSourceMongoDBAtlasNetworkPeerings()
+	)
+
+	container := mock.seed(fmt.Sprintf("%s/groups/%s/containers", mockAtlasV2Path, projectID), map[string]interface{}{
+		"providerName":   "AWS",
+		"atlasCidrBlock": "192.168.208.0/21",
+		"regionName":     "US_EAST_1",
+	})
+
+	mock.seed(fmt.Sprintf("%s/groups/%s/peers", mockAtlasV2Path, projectID), map[string]interface{}{
+		"providerName":        "AWS",
+		"containerId":         container["id"],
+		"accepterRegionName":  "us-east-1",
+		"awsAccountId":        "123456789012",
+		"routeTableCidrBlock": "172.31.0.0/16",
+		"vpcId":               "vpc-0123456789abcdef0",
+		"statusName":          "AVAILABLE",
+	})
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"project_id": projectID})
+	if diags := r.ReadContext(context.Background(), d, mock.client()); diags.HasError() {
+		t.Fatalf("Bad read network peerings: %v", diags)
+	}
+
+	if got := d.Get("results.#").(int); got != 1 {
+		t.Fatalf("Bad results: got %d network peerings, want 1", got)
+	}
+
+	expected := map[string]string{
+		"provider_name":    "AWS",
+		"vpc_id":           "vpc-0123456789abcdef0",
+		"status_name":      "AVAILABLE",
+		"atlas_cidr_block": "192.168.208.0/21",
+	}
+	for attribute, want := range expected {
+		if got := d.Get("results.0." + attribute).(string); got != want {
+			t.Errorf("Bad results.0.%s: got %q, want %q", attribute, got, want)
+		}
+	}
+}
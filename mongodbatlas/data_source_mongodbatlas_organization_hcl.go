@@ -0,0 +1,584 @@
+package mongodbatlas
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/zclconf/go-cty/cty"
+	"go.mongodb.org/atlas-sdk/v20230201002/admin"
+)
+
+const (
+	errorOrganizationHCLRead    = "error generating the configuration of the organization (%s): %s"
+	errorOrganizationHCLSetting = "error setting `%s` for the configuration of the organization (%s): %s"
+)
+
+var (
+	organizationHCLResourceTypes = []string{
+		"mongodbatlas_project",
+		"mongodbatlas_advanced_cluster",
+		"mongodbatlas_database_user",
+		"mongodbatlas_project_ip_access_list",
+		"mongodbatlas_alert_configuration",
+	}
+	organizationHCLLabelRegexp = regexp.MustCompile(`[^a-z0-9_]+`)
+)
+
+func dataSourceMongoDBAtlasOrganizationHCL() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMongoDBAtlasOrganizationHCLRead,
+		Schema: map[string]*schema.Schema{
+			"org_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"project_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"resource_types": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(organizationHCLResourceTypes, false),
+				},
+			},
+			"projects": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"project_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"file_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_hcl": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"import_hcl": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"import_commands": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			"files": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceMongoDBAtlasOrganizationHCLRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Get client connection.
+	connV2 := meta.(*MongoDBClient).AtlasV2
+	orgID := d.Get("org_id").(string)
+
+	resourceTypes := organizationHCLResourceTypes
+	if v, ok := d.GetOk("resource_types"); ok {
+		resourceTypes = expandStringList(v.(*schema.Set).List())
+	}
+
+	var projectIDs []string
+	if v, ok := d.GetOk("project_ids"); ok {
+		projectIDs = expandStringList(v.(*schema.Set).List())
+	}
+
+	projects, err := listOrganizationProjects(ctx, connV2, orgID)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf(errorOrganizationHCLRead, orgID, err))
+	}
+
+	labels := make(map[string]map[string]bool)
+	results := make([]map[string]interface{}, 0, len(projects))
+	files := make(map[string]interface{}, len(projects))
+
+	for i := range projects {
+		if len(projectIDs) > 0 && !containsString(projectIDs, projects[i].GetId()) {
+			continue
+		}
+
+		g := &organizationHCLGenerator{
+			resourceTypes: resourceTypes,
+			labels:        labels,
+			resources:     hclwrite.NewEmptyFile(),
+			imports:       hclwrite.NewEmptyFile(),
+		}
+
+		fileName, err := g.appendProject(ctx, connV2, &projects[i])
+		if err != nil {
+			return diag.FromErr(fmt.Errorf(errorOrganizationHCLRead, orgID, err))
+		}
+
+		resourceHCL, importHCL := string(g.resources.Bytes()), string(g.imports.Bytes())
+		results = append(results, map[string]interface{}{
+			"project_id":      projects[i].GetId(),
+			"name":            projects[i].GetName(),
+			"file_name":       fileName,
+			"resource_hcl":    resourceHCL,
+			"import_hcl":      importHCL,
+			"import_commands": g.commands,
+		})
+		files[fileName] = resourceHCL + "\n" + importHCL
+	}
+
+	if err := d.Set("projects", results); err != nil {
+		return diag.FromErr(fmt.Errorf(errorOrganizationHCLSetting, "projects", orgID, err))
+	}
+
+	if err := d.Set("files", files); err != nil {
+		return diag.FromErr(fmt.Errorf(errorOrganizationHCLSetting, "files", orgID, err))
+	}
+
+	d.SetId(id.UniqueId())
+
+	return nil
+}
+
+// listOrganizationProjects returns the projects of the organization that the API key can access, sorted by name.
+func listOrganizationProjects(ctx context.Context, connV2 *admin.APIClient, orgID string) ([]admin.Group, error) {
+	var projects []admin.Group
+
+	for pageNum := 1; ; pageNum++ {
+		page, _, err := connV2.ProjectsApi.ListProjects(ctx).PageNum(pageNum).ItemsPerPage(listItemsPerPage).Execute()
+		if err != nil {
+			return nil, fmt.Errorf("error listing projects: %s", err)
+		}
+
+		for i := range page.Results {
+			if page.Results[i].GetOrgId() == orgID {
+				projects = append(projects, page.Results[i])
+			}
+		}
+
+		if len(page.Results) < listItemsPerPage {
+			break
+		}
+	}
+
+	sort.SliceStable(projects, func(i, j int) bool { return projects[i].GetName() < projects[j].GetName() })
+
+	return projects, nil
+}
+
+// organizationHCLGenerator writes the resources of a project to resources and the import blocks that
+// bring them under Terraform to imports. labels holds the labels used so far by resource type, which
+// are shared by all the projects since the generated files are meant to be in the same module.
+type organizationHCLGenerator struct {
+	resourceTypes []string
+	labels        map[string]map[string]bool
+	resources     *hclwrite.File
+	imports       *hclwrite.File
+	commands      []string
+	projectID     hcl.Traversal
+}
+
+// appendProject writes the project and the resources it contains, returning the name of the file to write them to.
+func (g *organizationHCLGenerator) appendProject(ctx context.Context, connV2 *admin.APIClient, project *admin.Group) (string, error) {
+	projectID := project.GetId()
+
+	var fileName string
+	if containsString(g.resourceTypes, "mongodbatlas_project") {
+		label, resource := g.appendResource("mongodbatlas_project", project.GetName(), projectID)
+		resource.SetAttributeValue("name", cty.StringVal(project.GetName()))
+		resource.SetAttributeValue("org_id", cty.StringVal(project.GetOrgId()))
+
+		g.projectID = hcl.Traversal{hcl.TraverseRoot{Name: "mongodbatlas_project"}, hcl.TraverseAttr{Name: label}, hcl.TraverseAttr{Name: "id"}}
+		fileName = label + ".tf"
+	} else {
+		// projects can share a name, the file name must be unique all the same.
+		fileName = g.uniqueLabel("mongodbatlas_project", organizationHCLLabel(project.GetName())) + ".tf"
+	}
+
+	if containsString(g.resourceTypes, "mongodbatlas_advanced_cluster") {
+		var clusters []admin.AdvancedClusterDescription
+		for pageNum := 1; ; pageNum++ {
+			page, _, err := connV2.MultiCloudClustersApi.ListClusters(ctx, projectID).PageNum(pageNum).ItemsPerPage(listItemsPerPage).Execute()
+			if err != nil {
+				return "", fmt.Errorf("error listing the clusters of project (%s): %s", projectID, err)
+			}
+
+			clusters = append(clusters, page.Results...)
+			if len(page.Results) < listItemsPerPage {
+				break
+			}
+		}
+
+		for i := range clusters {
+			g.appendAdvancedCluster(projectID, &clusters[i])
+		}
+	}
+
+	if containsString(g.resourceTypes, "mongodbatlas_database_user") {
+		var dbUsers []admin.CloudDatabaseUser
+		for pageNum := 1; ; pageNum++ {
+			page, _, err := connV2.DatabaseUsersApi.ListDatabaseUsers(ctx, projectID).PageNum(pageNum).ItemsPerPage(listItemsPerPage).Execute()
+			if err != nil {
+				return "", fmt.Errorf("error listing the database users of project (%s): %s", projectID, err)
+			}
+
+			dbUsers = append(dbUsers, page.Results...)
+			if len(page.Results) < listItemsPerPage {
+				break
+			}
+		}
+
+		for i := range dbUsers {
+			g.appendDatabaseUser(projectID, &dbUsers[i])
+		}
+	}
+
+	if containsString(g.resourceTypes, "mongodbatlas_project_ip_access_list") {
+		var entries []admin.NetworkPermissionEntry
+		for pageNum := 1; ; pageNum++ {
+			page, _, err := connV2.ProjectIPAccessListApi.ListProjectIpAccessLists(ctx, projectID).PageNum(pageNum).ItemsPerPage(listItemsPerPage).Execute()
+			if err != nil {
+				return "", fmt.Errorf("error listing the IP access list of project (%s): %s", projectID, err)
+			}
+
+			entries = append(entries, page.Results...)
+			if len(page.Results) < listItemsPerPage {
+				break
+			}
+		}
+
+		for i := range entries {
+			g.appendProjectIPAccessList(projectID, &entries[i])
+		}
+	}
+
+	if containsString(g.resourceTypes, "mongodbatlas_alert_configuration") {
+		var alerts []admin.GroupAlertsConfig
+		for pageNum := 1; ; pageNum++ {
+			page, _, err := connV2.AlertConfigurationsApi.ListAlertConfigurations(ctx, projectID).PageNum(pageNum).ItemsPerPage(listItemsPerPage).Execute()
+			if err != nil {
+				return "", fmt.Errorf(errorReadAlertConf, err)
+			}
+
+			alerts = append(alerts, page.Results...)
+			if len(page.Results) < listItemsPerPage {
+				break
+			}
+		}
+
+		for i := range alerts {
+			_, resource := g.appendResource("mongodbatlas_alert_configuration", fmt.Sprintf("%s_%s", project.GetName(), alerts[i].GetEventTypeName()),
+				fmt.Sprintf("%s-%s", projectID, alerts[i].GetId()))
+			appendAlertConfigurationResourceHcl(resource, &alerts[i])
+			g.setProjectID(resource, projectID)
+		}
+	}
+
+	return fileName, nil
+}
+
+func (g *organizationHCLGenerator) appendAdvancedCluster(projectID string, cluster *admin.AdvancedClusterDescription) {
+	_, resource := g.appendResource("mongodbatlas_advanced_cluster", cluster.GetName(), fmt.Sprintf("%s-%s", projectID, cluster.GetName()))
+
+	g.setProjectID(resource, projectID)
+	appendAdvancedClusterHcl(resource, cluster)
+}
+
+// appendAdvancedClusterHcl writes the arguments of the mongodbatlas_advanced_cluster resource of cluster to resource,
+// except project_id.
+func appendAdvancedClusterHcl(resource *hclwrite.Body, cluster *admin.AdvancedClusterDescription) {
+	resource.SetAttributeValue("name", cty.StringVal(cluster.GetName()))
+	resource.SetAttributeValue("cluster_type", cty.StringVal(cluster.GetClusterType()))
+	if v := cluster.GetMongoDBMajorVersion(); v != "" {
+		resource.SetAttributeValue("mongo_db_major_version", cty.StringVal(v))
+	}
+
+	// the disk grows on its own when auto-scaling is enabled, setting its size would revert it.
+	if cluster.DiskSizeGB != nil && !isAdvancedClusterDiskAutoScalingEnabled(cluster) {
+		resource.SetAttributeValue("disk_size_gb", cty.NumberFloatVal(cluster.GetDiskSizeGB()))
+	}
+
+	resource.SetAttributeValue("backup_enabled", cty.BoolVal(cluster.GetBackupEnabled()))
+	resource.SetAttributeValue("pit_enabled", cty.BoolVal(cluster.GetPitEnabled()))
+	resource.SetAttributeValue("termination_protection_enabled", cty.BoolVal(cluster.GetTerminationProtectionEnabled()))
+
+	if cluster.GetPaused() {
+		resource.SetAttributeValue("paused", cty.True)
+	}
+
+	if v := cluster.GetVersionReleaseSystem(); v != "" && v != "LTS" {
+		resource.SetAttributeValue("version_release_system", cty.StringVal(v))
+	}
+
+	if v := cluster.GetEncryptionAtRestProvider(); v != "" && v != "NONE" {
+		resource.SetAttributeValue("encryption_at_rest_provider", cty.StringVal(v))
+	}
+
+	if biConnector := cluster.GetBiConnector(); biConnector.GetEnabled() {
+		appendBlockWithCtyValues(resource, "bi_connector_config", []string{}, map[string]cty.Value{
+			"enabled":         cty.True,
+			"read_preference": cty.StringVal(biConnector.GetReadPreference()),
+		})
+	}
+
+	for _, label := range cluster.Labels {
+		appendBlockWithCtyValues(resource, "labels", []string{}, map[string]cty.Value{
+			"key":   cty.StringVal(label.GetKey()),
+			"value": cty.StringVal(label.GetValue()),
+		})
+	}
+
+	for i := range cluster.ReplicationSpecs {
+		spec := &cluster.ReplicationSpecs[i]
+
+		resource.AppendNewline()
+		block := resource.AppendNewBlock("replication_specs", nil).Body()
+		block.SetAttributeValue("num_shards", cty.NumberIntVal(int64(spec.GetNumShards())))
+		if v := spec.GetZoneName(); v != "" && v != "ZoneName managed by Terraform" {
+			block.SetAttributeValue("zone_name", cty.StringVal(v))
+		}
+
+		for j := range spec.RegionConfigs {
+			appendAdvancedClusterRegionConfigHcl(block, &spec.RegionConfigs[j])
+		}
+	}
+}
+
+func isAdvancedClusterDiskAutoScalingEnabled(cluster *admin.AdvancedClusterDescription) bool {
+	for i := range cluster.ReplicationSpecs {
+		for j := range cluster.ReplicationSpecs[i].RegionConfigs {
+			if autoScaling := cluster.ReplicationSpecs[i].RegionConfigs[j].AutoScaling; autoScaling != nil && autoScaling.DiskGB != nil && autoScaling.DiskGB.GetEnabled() {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func appendAdvancedClusterRegionConfigHcl(body *hclwrite.Body, regionConfig *admin.CloudRegionConfig) {
+	body.AppendNewline()
+	block := body.AppendNewBlock("region_configs", nil).Body()
+	block.SetAttributeValue("provider_name", cty.StringVal(regionConfig.GetProviderName()))
+	if v := regionConfig.GetBackingProviderName(); v != "" {
+		block.SetAttributeValue("backing_provider_name", cty.StringVal(v))
+	}
+	block.SetAttributeValue("region_name", cty.StringVal(regionConfig.GetRegionName()))
+	block.SetAttributeValue("priority", cty.NumberIntVal(int64(regionConfig.GetPriority())))
+
+	if regionConfig.ElectableSpecs != nil {
+		appendBlockWithCtyValues(block, "electable_specs", []string{}, convertHardwareSpecToCtyValues(regionConfig.ElectableSpecs))
+	}
+
+	if specs := regionConfig.AnalyticsSpecs; specs != nil && specs.GetNodeCount() > 0 {
+		appendBlockWithCtyValues(block, "analytics_specs", []string{}, convertHardwareSpecToCtyValues(hardwareSpecFromDedicated(specs)))
+	}
+
+	if specs := regionConfig.ReadOnlySpecs; specs != nil && specs.GetNodeCount() > 0 {
+		appendBlockWithCtyValues(block, "read_only_specs", []string{}, convertHardwareSpecToCtyValues(hardwareSpecFromDedicated(specs)))
+	}
+
+	if regionConfig.AutoScaling != nil {
+		appendBlockWithCtyValues(block, "auto_scaling", []string{}, convertAutoScalingToCtyValues(regionConfig.AutoScaling))
+	}
+
+	if regionConfig.AnalyticsAutoScaling != nil {
+		appendBlockWithCtyValues(block, "analytics_auto_scaling", []string{}, convertAutoScalingToCtyValues(regionConfig.AnalyticsAutoScaling))
+	}
+}
+
+func (g *organizationHCLGenerator) appendDatabaseUser(projectID string, dbUser *admin.CloudDatabaseUser) {
+	_, resource := g.appendResource("mongodbatlas_database_user", fmt.Sprintf("%s_%s", dbUser.GetUsername(), dbUser.GetDatabaseName()),
+		fmt.Sprintf("%s-%s-%s", projectID, dbUser.GetUsername(), dbUser.GetDatabaseName()))
+
+	g.setProjectID(resource, projectID)
+	resource.SetAttributeValue("username", cty.StringVal(dbUser.GetUsername()))
+	resource.SetAttributeValue("auth_database_name", cty.StringVal(dbUser.GetDatabaseName()))
+
+	for _, authType := range []struct{ attribute, value string }{
+		{"x509_type", dbUser.GetX509Type()},
+		{"ldap_auth_type", dbUser.GetLdapAuthType()},
+		{"aws_iam_type", dbUser.GetAwsIAMType()},
+		{"oidc_auth_type", dbUser.GetOidcAuthType()},
+	} {
+		if authType.value != "" && authType.value != "NONE" {
+			resource.SetAttributeValue(authType.attribute, cty.StringVal(authType.value))
+		}
+	}
+
+	if dbUser.DeleteAfterDate != nil {
+		resource.SetAttributeValue("delete_after_date", cty.StringVal(timeToString(dbUser.DeleteAfterDate)))
+	}
+
+	for _, role := range dbUser.Roles {
+		values := map[string]cty.Value{
+			"role_name":     cty.StringVal(role.GetRoleName()),
+			"database_name": cty.StringVal(role.GetDatabaseName()),
+		}
+		if v := role.GetCollectionName(); v != "" {
+			values["collection_name"] = cty.StringVal(v)
+		}
+
+		appendBlockWithCtyValues(resource, "roles", []string{}, values)
+	}
+
+	for _, scope := range dbUser.Scopes {
+		appendBlockWithCtyValues(resource, "scopes", []string{}, map[string]cty.Value{
+			"name": cty.StringVal(scope.GetName()),
+			"type": cty.StringVal(scope.GetType()),
+		})
+	}
+
+	for _, label := range dbUser.Labels {
+		appendBlockWithCtyValues(resource, "labels", []string{}, map[string]cty.Value{
+			"key":   cty.StringVal(label.GetKey()),
+			"value": cty.StringVal(label.GetValue()),
+		})
+	}
+}
+
+func (g *organizationHCLGenerator) appendProjectIPAccessList(projectID string, entry *admin.NetworkPermissionEntry) {
+	// temporary entries expire on their own, they aren't worth managing with Terraform.
+	if entry.DeleteAfterDate != nil {
+		return
+	}
+
+	attribute, value := "cidr_block", entry.GetCidrBlock()
+	switch {
+	case entry.GetAwsSecurityGroup() != "":
+		attribute, value = "aws_security_group", entry.GetAwsSecurityGroup()
+	case entry.GetIpAddress() != "":
+		attribute, value = "ip_address", entry.GetIpAddress()
+	}
+
+	_, resource := g.appendResource("mongodbatlas_project_ip_access_list", value, fmt.Sprintf("%s-%s", projectID, value))
+
+	g.setProjectID(resource, projectID)
+	resource.SetAttributeValue(attribute, cty.StringVal(value))
+	if v := entry.GetComment(); v != "" {
+		resource.SetAttributeValue("comment", cty.StringVal(v))
+	}
+}
+
+// appendResource writes a resource block of resourceType and the import block of importID to it, returning
+// the label of the resource, made of name, and the body of the resource block.
+func (g *organizationHCLGenerator) appendResource(resourceType, name, importID string) (string, *hclwrite.Body) {
+	label := g.uniqueLabel(resourceType, organizationHCLLabel(name))
+
+	root := g.resources.Body()
+	if len(root.Blocks()) > 0 {
+		root.AppendNewline()
+	}
+	resource := root.AppendNewBlock("resource", []string{resourceType, label}).Body()
+
+	imports := g.imports.Body()
+	if len(imports.Blocks()) > 0 {
+		imports.AppendNewline()
+	}
+	block := imports.AppendNewBlock("import", nil).Body()
+	block.SetAttributeTraversal("to", hcl.Traversal{hcl.TraverseRoot{Name: resourceType}, hcl.TraverseAttr{Name: label}})
+	block.SetAttributeValue("id", cty.StringVal(importID))
+
+	g.commands = append(g.commands, fmt.Sprintf("terraform import %s.%s '%s'", resourceType, label, importID))
+
+	return label, resource
+}
+
+// setProjectID sets project_id to a reference to the generated project, or to projectID if the project isn't generated.
+func (g *organizationHCLGenerator) setProjectID(resource *hclwrite.Body, projectID string) {
+	if g.projectID != nil {
+		resource.SetAttributeTraversal("project_id", g.projectID)
+		return
+	}
+
+	resource.SetAttributeValue("project_id", cty.StringVal(projectID))
+}
+
+func (g *organizationHCLGenerator) uniqueLabel(resourceType, label string) string {
+	if g.labels[resourceType] == nil {
+		g.labels[resourceType] = make(map[string]bool)
+	}
+
+	unique := label
+	for i := 2; g.labels[resourceType][unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", label, i)
+	}
+	g.labels[resourceType][unique] = true
+
+	return unique
+}
+
+// organizationHCLLabel returns a valid Terraform identifier made of the letters and digits of name.
+func organizationHCLLabel(name string) string {
+	label := strings.Trim(organizationHCLLabelRegexp.ReplaceAllString(strings.ToLower(name), "_"), "_")
+
+	if label == "" || (label[0] >= '0' && label[0] <= '9') {
+		label = "_" + label
+	}
+
+	return label
+}
+
+func convertHardwareSpecToCtyValues(specs *admin.HardwareSpec) map[string]cty.Value {
+	values := map[string]cty.Value{
+		"instance_size": cty.StringVal(specs.GetInstanceSize()),
+	}
+
+	if specs.NodeCount != nil {
+		values["node_count"] = cty.NumberIntVal(int64(specs.GetNodeCount()))
+	}
+
+	if v := specs.GetEbsVolumeType(); v != "" && v != "STANDARD" {
+		values["ebs_volume_type"] = cty.StringVal(v)
+		values["disk_iops"] = cty.NumberIntVal(int64(specs.GetDiskIOPS()))
+	}
+
+	return values
+}
+
+func convertAutoScalingToCtyValues(autoScaling *admin.AdvancedAutoScalingSettings) map[string]cty.Value {
+	compute := autoScaling.GetCompute()
+	values := map[string]cty.Value{
+		"disk_gb_enabled": cty.BoolVal(autoScaling.DiskGB != nil && autoScaling.DiskGB.GetEnabled()),
+		"compute_enabled": cty.BoolVal(compute.GetEnabled()),
+	}
+
+	if compute.GetEnabled() {
+		values["compute_scale_down_enabled"] = cty.BoolVal(compute.GetScaleDownEnabled())
+		values["compute_max_instance_size"] = cty.StringVal(compute.GetMaxInstanceSize())
+
+		if compute.GetScaleDownEnabled() {
+			values["compute_min_instance_size"] = cty.StringVal(compute.GetMinInstanceSize())
+		}
+	}
+
+	return values
+}
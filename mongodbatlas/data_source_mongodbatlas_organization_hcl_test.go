@@ -0,0 +1,199 @@
+package mongodbatlas
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestOrganizationHCLLabel(t *testing.T) {
+	testCases := map[string]string{
+		"Production":          "production",
+		"dev-cluster":         "dev_cluster",
+		"app.user@example":    "app_user_example",
+		"10.0.0.0/24":         "_10_0_0_0_24",
+		"  --Shared  Tier-- ": "shared_tier",
+		"!!!":                 "_",
+	}
+
+	for name, expected := range testCases {
+		if got := organizationHCLLabel(name); got != expected {
+			t.Errorf("Bad organizationHCLLabel(%q): got %s, want %s", name, got, expected)
+		}
+	}
+
+	g := &organizationHCLGenerator{labels: make(map[string]map[string]bool)}
+	for _, expected := range []string{"dev", "dev_2", "dev_3"} {
+		if got := g.uniqueLabel("mongodbatlas_advanced_cluster", "dev"); got != expected {
+			t.Errorf("Bad uniqueLabel: got %s, want %s", got, expected)
+		}
+	}
+	if got := g.uniqueLabel("mongodbatlas_project", "dev"); got != "dev" {
+		t.Errorf("Bad uniqueLabel of another resource type: got %s, want dev", got)
+	}
+}
+
+func TestMockConfigDSOrganizationHCL_basic(t *testing.T) {
+	var (
+		mock           = newAtlasMockServer(t)
+		dataSourceName = "data.mongodbatlas_organization_hcl.test"
+		orgID          = newMockObjectID()
+	)
+
+	projectID := mock.seed(mockAtlasV1Path+"/groups", map[string]interface{}{
+		"name":  "Test Project",
+		"orgId": orgID,
+	})["id"].(string)
+	mock.seed(mockAtlasV1Path+"/groups", map[string]interface{}{
+		"name":  "Other Organization",
+		"orgId": newMockObjectID(),
+	})
+
+	mock.seed(fmt.Sprintf("%s/groups/%s/clusters", mockAtlasV2Path, projectID), map[string]interface{}{
+		"name":        "dev-cluster",
+		"groupId":     projectID,
+		"clusterType": "REPLICASET",
+		"diskSizeGB":  40,
+		"labels": []interface{}{
+			map[string]interface{}{"key": "env", "value": "dev"},
+		},
+		"replicationSpecs": []interface{}{
+			map[string]interface{}{
+				"numShards": 1,
+				"zoneName":  "Zone 1",
+				"regionConfigs": []interface{}{
+					map[string]interface{}{
+						"providerName":   "AWS",
+						"regionName":     "US_EAST_1",
+						"priority":       7,
+						"electableSpecs": map[string]interface{}{"instanceSize": "M10", "nodeCount": 3},
+						"readOnlySpecs":  map[string]interface{}{"instanceSize": "M10", "nodeCount": 0},
+						"autoScaling": map[string]interface{}{
+							"compute": map[string]interface{}{"enabled": true, "scaleDownEnabled": false, "maxInstanceSize": "M30"},
+							"diskGB":  map[string]interface{}{"enabled": true},
+						},
+					},
+				},
+			},
+		},
+	})
+	mock.seed(fmt.Sprintf("%s/groups/%s/databaseUsers", mockAtlasV2Path, projectID), map[string]interface{}{
+		"username":     "app",
+		"databaseName": "admin",
+		"groupId":      projectID,
+		"roles": []interface{}{
+			map[string]interface{}{"roleName": "readWrite", "databaseName": "app"},
+		},
+	})
+	mock.seed(fmt.Sprintf("%s/groups/%s/accessList", mockAtlasV2Path, projectID), map[string]interface{}{
+		"cidrBlock": "10.0.0.0/24",
+		"comment":   "office",
+		"groupId":   projectID,
+	})
+	mock.seed(fmt.Sprintf("%s/groups/%s/accessList", mockAtlasV2Path, projectID), map[string]interface{}{
+		"ipAddress":       "192.0.2.10",
+		"groupId":         projectID,
+		"deleteAfterDate": "2030-01-01T00:00:00Z",
+	})
+	alertID := mock.seed(fmt.Sprintf("%s/groups/%s/alertConfigs", mockAtlasV2Path, projectID), map[string]interface{}{
+		"groupId":       projectID,
+		"eventTypeName": "NO_PRIMARY",
+		"enabled":       true,
+		"notifications": []interface{}{
+			map[string]interface{}{"typeName": "GROUP", "intervalMin": 5, "emailEnabled": true},
+		},
+	})["id"].(string)
+
+	var generated string
+	testMockResourceUnitTest(t, mock, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "mongodbatlas_organization_hcl" "test" {
+						org_id = %q
+					}
+				`, orgID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "projects.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "projects.0.project_id", projectID),
+					resource.TestCheckResourceAttr(dataSourceName, "projects.0.file_name", "test_project.tf"),
+					resource.TestCheckResourceAttr(dataSourceName, "projects.0.import_commands.#", "5"),
+					resource.TestCheckResourceAttr(dataSourceName, "projects.0.import_commands.0",
+						fmt.Sprintf("terraform import mongodbatlas_project.test_project '%s'", projectID)),
+					resource.TestCheckResourceAttrWith(dataSourceName, "files.test_project.tf", func(value string) error {
+						generated = value
+						return nil
+					}),
+				),
+			},
+		},
+	})
+
+	if _, diags := hclwrite.ParseConfig([]byte(generated), "test_project.tf", hcl.InitialPos); diags.HasErrors() {
+		t.Fatalf("Bad generated configuration: %s\n%s", diags, generated)
+	}
+
+	for _, want := range []string{
+		`resource "mongodbatlas_project" "test_project" {`,
+		`resource "mongodbatlas_advanced_cluster" "dev_cluster" {`,
+		`project_id                     = mongodbatlas_project.test_project.id`,
+		`zone_name  = "Zone 1"`,
+		`instance_size = "M10"`,
+		`compute_max_instance_size  = "M30"`,
+		`resource "mongodbatlas_database_user" "app_admin" {`,
+		`role_name     = "readWrite"`,
+		`resource "mongodbatlas_project_ip_access_list" "_10_0_0_0_24" {`,
+		`comment    = "office"`,
+		`resource "mongodbatlas_alert_configuration" "test_project_no_primary" {`,
+		fmt.Sprintf("to = mongodbatlas_advanced_cluster.dev_cluster\n  id = \"%s-dev-cluster\"", projectID),
+		fmt.Sprintf("id = \"%s-app-admin\"", projectID),
+		fmt.Sprintf("id = \"%s-%s\"", projectID, alertID),
+	} {
+		if !strings.Contains(generated, want) {
+			t.Errorf("Bad generated configuration, expected it to contain %s:\n%s", want, generated)
+		}
+	}
+
+	// the disk of the cluster is auto-scaled and it uses the default release system.
+	for _, unwanted := range []string{"192.0.2.10", "read_only_specs", "Other Organization", "disk_size_gb", "version_release_system"} {
+		if strings.Contains(generated, unwanted) {
+			t.Errorf("Bad generated configuration, expected it not to contain %s:\n%s", unwanted, generated)
+		}
+	}
+}
+
+func TestAccConfigDSOrganizationHCL_basic(t *testing.T) {
+	SkipTestForCI(t)
+	var (
+		dataSourceName = "data.mongodbatlas_organization_hcl.test"
+		orgID          = os.Getenv("MONGODB_ATLAS_ORG_ID")
+		projectID      = os.Getenv("MONGODB_ATLAS_PROJECT_ID")
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheckBasic(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "mongodbatlas_organization_hcl" "test" {
+						org_id         = %q
+						project_ids    = [%q]
+						resource_types = ["mongodbatlas_project", "mongodbatlas_advanced_cluster"]
+					}
+				`, orgID, projectID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "projects.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "projects.0.project_id", projectID),
+					resource.TestCheckResourceAttrSet(dataSourceName, "projects.0.resource_hcl"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "projects.0.import_hcl"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,194 @@
+package mongodbatlas
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	matlas "go.mongodb.org/atlas/mongodbatlas"
+)
+
+func defaultLabelsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Labels added to every resource supporting labels",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"labels": {
+					Type:     schema.TypeMap,
+					Optional: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
+
+// labelsAllSchema holds the labels of the resource merged with the default labels of the provider,
+// the ones actually sent to Atlas.
+func labelsAllSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"key": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"value": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+func expandDefaultLabels(tfList []interface{}) ([]matlas.Label, error) {
+	if len(tfList) == 0 || tfList[0] == nil {
+		return nil, nil
+	}
+
+	tfMap := tfList[0].(map[string]interface{})
+	labelsMap, _ := tfMap["labels"].(map[string]interface{})
+
+	labels := make([]matlas.Label, 0, len(labelsMap))
+	for key, value := range labelsMap {
+		if key == defaultLabel.Key {
+			return nil, fmt.Errorf("you should not set `%s` label, it is used for internal purposes", defaultLabel.Key)
+		}
+		labels = append(labels, matlas.Label{Key: key, Value: value.(string)})
+	}
+
+	sortLabels(labels)
+
+	return labels, nil
+}
+
+func defaultLabelsFromMeta(meta interface{}) []matlas.Label {
+	client, ok := meta.(*MongoDBClient)
+	if !ok || client == nil || client.Config == nil {
+		return nil
+	}
+
+	return client.Config.DefaultLabels
+}
+
+// mergeDefaultLabels returns the default labels followed by the labels of the resource, a label
+// of the resource replacing the default label with the same key.
+func mergeDefaultLabels(defaults, labels []matlas.Label) []matlas.Label {
+	merged := make([]matlas.Label, 0, len(defaults)+len(labels))
+	for _, label := range defaults {
+		if !containsLabelKey(labels, label.Key) {
+			merged = append(merged, label)
+		}
+	}
+
+	return append(merged, labels...)
+}
+
+// expandLabelsWithDefaults returns the labels of the resource merged with the default labels of the provider.
+func expandLabelsWithDefaults(d *schema.ResourceData, meta interface{}) []matlas.Label {
+	return mergeDefaultLabels(defaultLabelsFromMeta(meta), expandLabelSliceFromSetSchema(d))
+}
+
+// removeDefaultLabels returns the labels read from Atlas that don't come from the default labels of the provider,
+// i.e. the ones that are set on the resource or that don't match a default label. This keeps the default labels
+// out of the labels of the resource, and so out of its diffs.
+func removeDefaultLabels(labels, resourceLabels, defaults []matlas.Label) []matlas.Label {
+	result := make([]matlas.Label, 0, len(labels))
+	for _, label := range labels {
+		if !containsLabelKey(resourceLabels, label.Key) && containsLabel(defaults, label) {
+			continue
+		}
+		result = append(result, label)
+	}
+
+	return result
+}
+
+// setLabelsWithDefaults sets labels and labels_all from the labels read from Atlas.
+func setLabelsWithDefaults(d *schema.ResourceData, meta interface{}, labels []matlas.Label) error {
+	resourceLabels := removeDefaultLabels(labels, expandLabelSliceFromSetSchema(d), defaultLabelsFromMeta(meta))
+
+	if err := d.Set("labels", flattenLabels(resourceLabels)); err != nil {
+		return err
+	}
+
+	return d.Set("labels_all", flattenLabels(labels))
+}
+
+// customizeDiffDefaultLabels plans labels_all, so that a change of the default labels of the provider
+// updates the resource while its labels stay unchanged.
+func customizeDiffDefaultLabels(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("labels") {
+		return d.SetNewComputed("labels_all")
+	}
+
+	var labels []matlas.Label
+	if v, ok := d.Get("labels").(*schema.Set); ok {
+		labels = expandLabelsFromSet(v)
+	}
+
+	merged := mergeDefaultLabels(defaultLabelsFromMeta(meta), labels)
+
+	var current []matlas.Label
+	if v, ok := d.Get("labels_all").(*schema.Set); ok {
+		current = expandLabelsFromSet(v)
+	}
+
+	sortLabels(merged)
+	sortLabels(current)
+	if d.Id() != "" && reflect.DeepEqual(merged, current) {
+		return nil
+	}
+
+	return d.SetNew("labels_all", flattenLabels(merged))
+}
+
+func expandLabelsFromSet(set *schema.Set) []matlas.Label {
+	labels := make([]matlas.Label, 0, set.Len())
+	for _, val := range set.List() {
+		v := val.(map[string]interface{})
+		labels = append(labels, matlas.Label{
+			Key:   v["key"].(string),
+			Value: v["value"].(string),
+		})
+	}
+
+	return labels
+}
+
+func containsLabelKey(list []matlas.Label, key string) bool {
+	for _, v := range list {
+		if v.Key == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsLabel(list []matlas.Label, item matlas.Label) bool {
+	for _, v := range list {
+		if v == item {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sortLabels(labels []matlas.Label) {
+	sort.Slice(labels, func(i, j int) bool {
+		if labels[i].Key != labels[j].Key {
+			return labels[i].Key < labels[j].Key
+		}
+		return labels[i].Value < labels[j].Value
+	})
+}
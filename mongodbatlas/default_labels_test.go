@@ -0,0 +1,216 @@
+package mongodbatlas
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	matlas "go.mongodb.org/atlas/mongodbatlas"
+)
+
+func TestExpandDefaultLabels(t *testing.T) {
+	got, err := expandDefaultLabels([]interface{}{
+		map[string]interface{}{
+			"labels": map[string]interface{}{"environment": "prod", "cost-center": "eng"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []matlas.Label{{Key: "cost-center", Value: "eng"}, {Key: "environment", Value: "prod"}}
+	if diff := deep.Equal(expected, got); diff != nil {
+		t.Fatalf("Bad expandDefaultLabels return \n got = %#v\nwant = %#v \ndiff = %#v", got, expected, diff)
+	}
+
+	if _, err := expandDefaultLabels([]interface{}{
+		map[string]interface{}{"labels": map[string]interface{}{defaultLabel.Key: "other"}},
+	}); err == nil {
+		t.Fatalf("expected an error when the %q label is a default label", defaultLabel.Key)
+	}
+}
+
+func TestMergeDefaultLabels(t *testing.T) {
+	defaults := []matlas.Label{{Key: "cost-center", Value: "eng"}, {Key: "environment", Value: "prod"}}
+
+	testCases := map[string]struct {
+		labels   []matlas.Label
+		expected []matlas.Label
+	}{
+		"no resource labels": {
+			labels:   nil,
+			expected: defaults,
+		},
+		"resource labels are added": {
+			labels:   []matlas.Label{{Key: "team", Value: "payments"}},
+			expected: []matlas.Label{{Key: "cost-center", Value: "eng"}, {Key: "environment", Value: "prod"}, {Key: "team", Value: "payments"}},
+		},
+		"resource labels take precedence": {
+			labels:   []matlas.Label{{Key: "environment", Value: "staging"}},
+			expected: []matlas.Label{{Key: "cost-center", Value: "eng"}, {Key: "environment", Value: "staging"}},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := mergeDefaultLabels(defaults, tc.labels)
+			if diff := deep.Equal(tc.expected, got); diff != nil {
+				t.Fatalf("Bad mergeDefaultLabels return \n got = %#v\nwant = %#v \ndiff = %#v", got, tc.expected, diff)
+			}
+		})
+	}
+}
+
+func TestRemoveDefaultLabels(t *testing.T) {
+	defaults := []matlas.Label{{Key: "cost-center", Value: "eng"}, {Key: "environment", Value: "prod"}}
+
+	testCases := map[string]struct {
+		labels         []matlas.Label
+		resourceLabels []matlas.Label
+		expected       []matlas.Label
+	}{
+		"default labels are removed": {
+			labels:   []matlas.Label{{Key: "cost-center", Value: "eng"}, {Key: "team", Value: "payments"}},
+			expected: []matlas.Label{{Key: "team", Value: "payments"}},
+		},
+		"labels set on the resource are kept": {
+			labels:         []matlas.Label{{Key: "cost-center", Value: "eng"}, {Key: "environment", Value: "prod"}},
+			resourceLabels: []matlas.Label{{Key: "environment", Value: "prod"}},
+			expected:       []matlas.Label{{Key: "environment", Value: "prod"}},
+		},
+		"labels changed outside of terraform are kept": {
+			labels:   []matlas.Label{{Key: "cost-center", Value: "finance"}},
+			expected: []matlas.Label{{Key: "cost-center", Value: "finance"}},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := removeDefaultLabels(tc.labels, tc.resourceLabels, defaults)
+			if diff := deep.Equal(tc.expected, got); diff != nil {
+				t.Fatalf("Bad removeDefaultLabels return \n got = %#v\nwant = %#v \ndiff = %#v", got, tc.expected, diff)
+			}
+		})
+	}
+}
+
+func TestMockProviderDefaultLabels(t *testing.T) {
+	var (
+		mock         = newAtlasMockServer(t)
+		projectID    = mock.newProject("test-default-labels")
+		resourceName = "mongodbatlas_database_user.test"
+		username     = "test-default-labels-user"
+	)
+
+	testMockResourceUnitTest(t, mock, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: testMockProviderDefaultLabelsConfig(projectID, username, "eng", `
+					labels {
+						key   = "team"
+						value = "payments"
+					}
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "labels.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "labels.*", map[string]string{"key": "team", "value": "payments"}),
+					resource.TestCheckResourceAttr(resourceName, "labels_all.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "labels_all.*", map[string]string{"key": "cost-center", "value": "eng"}),
+					testMockCheckDatabaseUserLabels(mock, projectID, username, map[string]string{"cost-center": "eng", "team": "payments"}),
+				),
+			},
+			{
+				// a change of the default labels updates the database user without changing its labels.
+				Config: testMockProviderDefaultLabelsConfig(projectID, username, "ops", `
+					labels {
+						key   = "team"
+						value = "payments"
+					}
+				`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "labels.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "labels_all.*", map[string]string{"key": "cost-center", "value": "ops"}),
+					testMockCheckDatabaseUserLabels(mock, projectID, username, map[string]string{"cost-center": "ops", "team": "payments"}),
+				),
+			},
+			{
+				// a label of the resource takes precedence over the default label with the same key.
+				Config: testMockProviderDefaultLabelsConfig(projectID, username, "ops", `
+					labels {
+						key   = "cost-center"
+						value = "finance"
+					}
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "labels.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "labels_all.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "labels_all.*", map[string]string{"key": "cost-center", "value": "finance"}),
+					testMockCheckDatabaseUserLabels(mock, projectID, username, map[string]string{"cost-center": "finance"}),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportStateIdFunc:       testAccCheckMongoDBAtlasDatabaseUserImportStateIDFunc(resourceName),
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+		},
+	})
+}
+
+func testMockCheckDatabaseUserLabels(mock *atlasMockServer, projectID, username string, expected map[string]string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		user := mock.get(fmt.Sprintf("%s/groups/%s/databaseUsers/admin/%s", mockAtlasV1Path, projectID, username))
+		if user == nil {
+			return fmt.Errorf("database user %s not found", username)
+		}
+
+		got := make(map[string]string)
+		labels, _ := user["labels"].([]interface{})
+		for _, v := range labels {
+			label := v.(map[string]interface{})
+			got[fmt.Sprint(label["key"])] = fmt.Sprint(label["value"])
+		}
+
+		if diff := deep.Equal(expected, got); diff != nil {
+			return fmt.Errorf("bad labels sent to Atlas \n got = %#v\nwant = %#v \ndiff = %#v", got, expected, diff)
+		}
+
+		return nil
+	}
+}
+
+func testMockProviderDefaultLabelsConfig(projectID, username, costCenter, labels string) string {
+	return fmt.Sprintf(`
+		provider "mongodbatlas" {
+			default_labels {
+				labels = {
+					cost-center = %[3]q
+				}
+			}
+		}
+
+		resource "mongodbatlas_database_user" "test" {
+			project_id         = %[1]q
+			username           = %[2]q
+			password           = "test-mock-password"
+			auth_database_name = "admin"
+
+			roles {
+				role_name     = "read"
+				database_name = "admin"
+			}
+
+			%[4]s
+		}
+	`, projectID, username, costCenter, labels)
+}
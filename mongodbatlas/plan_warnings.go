@@ -0,0 +1,64 @@
+package mongodbatlas
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type planWarningsKey struct{}
+
+// planWarnings collects the warnings added while a resource change is planned, CustomizeDiff can only
+// return errors.
+type planWarnings struct {
+	mu          sync.Mutex
+	diagnostics []*tfprotov5.Diagnostic
+}
+
+// addPlanWarning adds a warning to the plan of the resource change being planned, if any.
+func addPlanWarning(ctx context.Context, summary, detail string) {
+	warnings, ok := ctx.Value(planWarningsKey{}).(*planWarnings)
+	if !ok {
+		return
+	}
+
+	warnings.mu.Lock()
+	defer warnings.mu.Unlock()
+
+	for _, v := range warnings.diagnostics {
+		if v.Summary == summary && v.Detail == detail {
+			return
+		}
+	}
+
+	warnings.diagnostics = append(warnings.diagnostics, &tfprotov5.Diagnostic{
+		Severity: tfprotov5.DiagnosticSeverityWarning,
+		Summary:  summary,
+		Detail:   detail,
+	})
+}
+
+// ProviderServer returns the provider server of the provider, which adds the warnings of addPlanWarning
+// to the plans of the resources.
+func ProviderServer() tfprotov5.ProviderServer {
+	return &planWarningsProviderServer{ProviderServer: schema.NewGRPCProviderServer(Provider())}
+}
+
+type planWarningsProviderServer struct {
+	tfprotov5.ProviderServer
+}
+
+func (s *planWarningsProviderServer) PlanResourceChange(ctx context.Context, req *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
+	warnings := &planWarnings{}
+
+	resp, err := s.ProviderServer.PlanResourceChange(context.WithValue(ctx, planWarningsKey{}, warnings), req)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+
+	resp.Diagnostics = append(resp.Diagnostics, warnings.diagnostics...)
+
+	return resp, nil
+}
@@ -0,0 +1,39 @@
+package mongodbatlas
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+)
+
+type testPlanProviderServer struct {
+	tfprotov5.ProviderServer
+}
+
+func (s *testPlanProviderServer) PlanResourceChange(ctx context.Context, req *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
+	addPlanWarning(ctx, "summary", "detail")
+	addPlanWarning(ctx, "summary", "detail")
+
+	return &tfprotov5.PlanResourceChangeResponse{}, nil
+}
+
+func TestPlanWarningsProviderServer(t *testing.T) {
+	server := &planWarningsProviderServer{ProviderServer: &testPlanProviderServer{}}
+
+	resp, err := server.PlanResourceChange(context.Background(), &tfprotov5.PlanResourceChangeRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []*tfprotov5.Diagnostic{
+		{Severity: tfprotov5.DiagnosticSeverityWarning, Summary: "summary", Detail: "detail"},
+	}
+	if diff := deep.Equal(expected, resp.Diagnostics); diff != nil {
+		t.Fatalf("Bad plan diagnostics \n got = %#v\nwant = %#v \ndiff = %#v", resp.Diagnostics, expected, diff)
+	}
+
+	// outside of a plan the warnings are dropped.
+	addPlanWarning(context.Background(), "summary", "detail")
+}
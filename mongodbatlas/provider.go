@@ -29,6 +29,7 @@ import (
 	"github.com/mwielbut/pointy"
 	"github.com/spf13/cast"
 	"github.com/zclconf/go-cty/cty"
+	"go.mongodb.org/atlas-sdk/v20230201002/admin"
 	matlas "go.mongodb.org/atlas/mongodbatlas"
 )
 
@@ -44,11 +45,15 @@ const (
 	AWS                                   = "AWS"
 	AZURE                                 = "AZURE"
 	GCP                                   = "GCP"
+	// maximum number of items per page of the Atlas SDK list operations
+	listItemsPerPage = 500
 )
 
 type SecretData struct {
-	PublicKey  string `json:"public_key"`
-	PrivateKey string `json:"private_key"`
+	PublicKey    string `json:"public_key"`
+	PrivateKey   string `json:"private_key"`
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
 }
 
 // Provider returns the provider to be use by the code.
@@ -57,7 +62,7 @@ func Provider() *schema.Provider {
 		Schema: map[string]*schema.Schema{
 			"public_key": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
 					"MONGODB_ATLAS_PUBLIC_KEY",
 					"MCLI_PUBLIC_API_KEY",
@@ -66,7 +71,7 @@ func Provider() *schema.Provider {
 			},
 			"private_key": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
 					"MONGODB_ATLAS_PRIVATE_KEY",
 					"MCLI_PRIVATE_API_KEY",
@@ -74,6 +79,19 @@ func Provider() *schema.Provider {
 				Description: "MongoDB Atlas Programmatic Private Key",
 				Sensitive:   true,
 			},
+			"client_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("MONGODB_ATLAS_CLIENT_ID", ""),
+				Description: "MongoDB Atlas Service Account Client ID",
+			},
+			"client_secret": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("MONGODB_ATLAS_CLIENT_SECRET", ""),
+				Description: "MongoDB Atlas Service Account Client Secret",
+				Sensitive:   true,
+			},
 			"base_url": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -89,12 +107,76 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("MONGODB_REALM_BASE_URL", ""),
 				Description: "MongoDB Realm Base URL",
 			},
+			"default_project_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("MONGODB_ATLAS_DEFAULT_PROJECT_ID", ""),
+				Description: "Project ID used by the resources that omit project_id",
+			},
+			"default_org_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("MONGODB_ATLAS_DEFAULT_ORG_ID", ""),
+				Description: "Organization ID used by the resources that omit org_id",
+			},
+			"default_labels": defaultLabelsSchema(),
+			"profile": {
+				Type:     schema.TypeString,
+				Optional: true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					"MONGODB_ATLAS_PROFILE",
+					"MCLI_PROFILE",
+				}, ""),
+				Description: "Name of the Atlas CLI profile to read the credentials, base URL and default organization and project from",
+			},
+			"profile_config_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("MONGODB_ATLAS_PROFILE_CONFIG_FILE", ""),
+				Description: "Path of the Atlas CLI configuration file, by default the one of the Atlas CLI or the MongoDB CLI",
+			},
 			"is_mongodbgov_cloud": {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Description: "MongoDB Atlas Base URL default to gov",
 			},
-			"assume_role": assumeRoleSchema(),
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("MONGODB_ATLAS_MAX_RETRIES", defaultMaxRetries),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of times a request rate limited or failed with a transient error is retried, 0 disables retries",
+			},
+			"retry_wait_min": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("MONGODB_ATLAS_RETRY_WAIT_MIN", int(defaultRetryWaitMin.Seconds())),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Minimum time in seconds to wait before retrying a request",
+			},
+			"retry_wait_max": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("MONGODB_ATLAS_RETRY_WAIT_MAX", int(defaultRetryWaitMax.Seconds())),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Maximum time in seconds to wait before retrying a request",
+			},
+			"requests_per_minute": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("MONGODB_ATLAS_REQUESTS_PER_MINUTE", 0),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of requests per minute sent to MongoDB Atlas, 0 means unlimited",
+			},
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("MONGODB_ATLAS_MAX_CONCURRENT_REQUESTS", 0),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of requests sent to MongoDB Atlas at the same time, 0 means unlimited",
+			},
+			"assume_role":          assumeRoleSchema(),
+			"credentials_provider": credentialsProviderSchema(),
 			"secret_name": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -145,6 +227,7 @@ func Provider() *schema.Provider {
 		ConfigureContextFunc: providerConfigure,
 	}
 	addBetaFeatures(provider)
+	addProviderDefaults(provider)
 	return provider
 }
 
@@ -152,6 +235,10 @@ func getDataSourcesMap() map[string]*schema.Resource {
 	dataSourcesMap := map[string]*schema.Resource{
 		"mongodbatlas_advanced_cluster":                  dataSourceMongoDBAtlasAdvancedCluster(),
 		"mongodbatlas_advanced_clusters":                 dataSourceMongoDBAtlasAdvancedClusters(),
+		"mongodbatlas_advanced_cluster_migration":        dataSourceMongoDBAtlasAdvancedClusterMigration(),
+		"mongodbatlas_cluster_processes":                 dataSourceMongoDBAtlasClusterProcesses(),
+		"mongodbatlas_cloud_provider_regions":            dataSourceMongoDBAtlasCloudProviderRegions(),
+		"mongodbatlas_mongodb_versions":                  dataSourceMongoDBAtlasMongoDBVersions(),
 		"mongodbatlas_custom_db_role":                    dataSourceMongoDBAtlasCustomDBRole(),
 		"mongodbatlas_custom_db_roles":                   dataSourceMongoDBAtlasCustomDBRoles(),
 		"mongodbatlas_database_user":                     dataSourceMongoDBAtlasDatabaseUser(),
@@ -213,6 +300,7 @@ func getDataSourcesMap() map[string]*schema.Resource {
 		"mongodbatlas_org_invitation":                                               dataSourceMongoDBAtlasOrgInvitation(),
 		"mongodbatlas_organization":                                                 dataSourceMongoDBAtlasOrganization(),
 		"mongodbatlas_organizations":                                                dataSourceMongoDBAtlasOrganizations(),
+		"mongodbatlas_organization_hcl":                                             dataSourceMongoDBAtlasOrganizationHCL(),
 		"mongodbatlas_cloud_backup_snapshot":                                        dataSourceMongoDBAtlasCloudBackupSnapshot(),
 		"mongodbatlas_cloud_backup_snapshots":                                       dataSourceMongoDBAtlasCloudBackupSnapshots(),
 		"mongodbatlas_backup_compliance_policy":                                     dataSourceMongoDBAtlasBackupCompliancePolicy(),
@@ -272,7 +360,9 @@ func getResourcesMap() map[string]*schema.Resource {
 		"mongodbatlas_privatelink_endpoint_service_adl":                            resourceMongoDBAtlasPrivateLinkEndpointServiceADL(),
 		"mongodbatlas_privatelink_endpoint_service_serverless":                     resourceMongoDBAtlasPrivateLinkEndpointServiceServerless(),
 		"mongodbatlas_third_party_integration":                                     resourceMongoDBAtlasThirdPartyIntegration(),
+		"mongodbatlas_project_database_users":                                      resourceMongoDBAtlasProjectDatabaseUsers(),
 		"mongodbatlas_project_ip_access_list":                                      resourceMongoDBAtlasProjectIPAccessList(),
+		"mongodbatlas_project_ip_access_list_set":                                  resourceMongoDBAtlasProjectIPAccessListSet(),
 		"mongodbatlas_cloud_provider_access":                                       resourceMongoDBAtlasCloudProviderAccess(),
 		"mongodbatlas_online_archive":                                              resourceMongoDBAtlasOnlineArchive(),
 		"mongodbatlas_custom_dns_configuration_cluster_aws":                        resourceMongoDBAtlasCustomDNSConfiguration(),
@@ -284,6 +374,7 @@ func getResourcesMap() map[string]*schema.Resource {
 		"mongodbatlas_data_lake":                                                   resourceMongoDBAtlasDataLake(),
 		"mongodbatlas_data_lake_pipeline":                                          resourceMongoDBAtlasDataLakePipeline(),
 		"mongodbatlas_event_trigger":                                               resourceMongoDBAtlasEventTriggers(),
+		"mongodbatlas_cluster_pause_schedule":                                      resourceMongoDBAtlasClusterPauseSchedule(),
 		"mongodbatlas_cloud_backup_schedule":                                       resourceMongoDBAtlasCloudBackupSchedule(),
 		"mongodbatlas_project_invitation":                                          resourceMongoDBAtlasProjectInvitation(),
 		"mongodbatlas_org_invitation":                                              resourceMongoDBAtlasOrgInvitation(),
@@ -313,34 +404,101 @@ func addBetaFeatures(provider *schema.Provider) {
 func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	mongodbgovCloud := pointy.Bool(d.Get("is_mongodbgov_cloud").(bool))
 	if *mongodbgovCloud {
-		baseURL = "https://cloud.mongodbgov.com"
+		baseURL = mongoDBGovBaseURL
 	} else {
 		baseURL = d.Get("base_url").(string)
 	}
 
 	config := Config{
-		PublicKey:    d.Get("public_key").(string),
-		PrivateKey:   d.Get("private_key").(string),
-		BaseURL:      baseURL,
-		RealmBaseURL: d.Get("realm_base_url").(string),
+		PublicKey:             d.Get("public_key").(string),
+		PrivateKey:            d.Get("private_key").(string),
+		ClientID:              d.Get("client_id").(string),
+		ClientSecret:          d.Get("client_secret").(string),
+		BaseURL:               baseURL,
+		RealmBaseURL:          d.Get("realm_base_url").(string),
+		MaxRetries:            d.Get("max_retries").(int),
+		RetryWaitMin:          time.Duration(d.Get("retry_wait_min").(int)) * time.Second,
+		RetryWaitMax:          time.Duration(d.Get("retry_wait_max").(int)) * time.Second,
+		RequestsPerMinute:     d.Get("requests_per_minute").(int),
+		MaxConcurrentRequests: d.Get("max_concurrent_requests").(int),
+		DefaultProjectID:      d.Get("default_project_id").(string),
+		DefaultOrgID:          d.Get("default_org_id").(string),
+	}
+
+	defaultLabels, err := expandDefaultLabels(d.Get("default_labels").([]interface{}))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	config.DefaultLabels = defaultLabels
+
+	if name := d.Get("profile").(string); name != "" {
+		profile, err := readAtlasCLIProfile(d.Get("profile_config_file").(string), name)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		profile.apply(&config)
+		baseURL = config.BaseURL
+	}
+
+	if config.RetryWaitMax < config.RetryWaitMin {
+		return nil, diag.Errorf("retry_wait_max (%s) must be greater than or equal to retry_wait_min (%s)", config.RetryWaitMax, config.RetryWaitMin)
 	}
 
+	var credentialSource credentialProvider
 	if v, ok := d.GetOk("assume_role"); ok && len(v.([]interface{})) > 0 && v.([]interface{})[0] != nil {
 		config.AssumeRole = expandAssumeRole(v.([]interface{})[0].(map[string]interface{}))
-		secret := d.Get("secret_name").(string)
-		region := d.Get("region").(string)
-		awsAccessKeyID := d.Get("aws_access_key_id").(string)
-		awsSecretAccessKey := d.Get("aws_secret_access_key").(string)
-		awsSessionToken := d.Get("aws_session_token").(string)
-		endpoint := d.Get("sts_endpoint").(string)
-		var err error
-		config, err = configureCredentialsSTS(&config, secret, region, awsAccessKeyID, awsSecretAccessKey, awsSessionToken, endpoint)
-		if err != nil {
+		credentialSource = &awsSecretsManagerCredentialProvider{
+			config:             &config,
+			secret:             d.Get("secret_name").(string),
+			region:             d.Get("region").(string),
+			awsAccessKeyID:     d.Get("aws_access_key_id").(string),
+			awsSecretAccessKey: d.Get("aws_secret_access_key").(string),
+			awsSessionToken:    d.Get("aws_session_token").(string),
+			endpoint:           d.Get("sts_endpoint").(string),
+		}
+	} else {
+		credentialSource = expandCredentialProvider(d.Get("credentials_provider").([]interface{}))
+	}
+
+	if credentialSource != nil {
+		if err := configureCredentials(ctx, &config, credentialSource); err != nil {
 			return nil, diag.FromErr(err)
 		}
 	}
 
-	return config.NewClient(ctx)
+	diags := validateCredentials(&config)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	client, clientDiags := config.NewClient(ctx)
+
+	return client, append(diags, clientDiags...)
+}
+
+// validateCredentials checks that the provider has either an API key or a service account to authenticate with.
+func validateCredentials(config *Config) diag.Diagnostics {
+	if config.usesServiceAccount() {
+		if config.ClientID == "" || config.ClientSecret == "" {
+			return diag.Errorf("both `client_id` and `client_secret` must be set to authenticate with a service account")
+		}
+
+		if config.PublicKey != "" || config.PrivateKey != "" {
+			return diag.Diagnostics{{
+				Severity: diag.Warning,
+				Summary:  "Both an API key and a service account are configured",
+				Detail:   "`client_id` and `client_secret` take precedence, `public_key` and `private_key` are ignored.",
+			}}
+		}
+
+		return nil
+	}
+
+	if config.PublicKey == "" || config.PrivateKey == "" {
+		return diag.Errorf("either `public_key` and `private_key` or `client_id` and `client_secret` must be set")
+	}
+
+	return nil
 }
 
 func configureCredentialsSTS(config *Config, secret, region, awsAccessKeyID, awsSecretAccessKey, awsSessionToken, endpoint string) (Config, error) {
@@ -395,21 +553,40 @@ func configureCredentialsSTS(config *Config, secret, region, awsAccessKeyID, aws
 		return *config, err
 	}
 
+	return configureCredentialsFromSecret(config, secretString)
+}
+
+// configureCredentialsFromSecret sets the API key or the service account held by the secret,
+// which must have either public_key and private_key or client_id and client_secret.
+func configureCredentialsFromSecret(config *Config, secretString string) (Config, error) {
 	var secretData SecretData
-	err = json.Unmarshal([]byte(secretString), &secretData)
+	err := json.Unmarshal([]byte(secretString), &secretData)
 	if err != nil {
 		return *config, err
 	}
-	if secretData.PrivateKey == "" {
-		return *config, fmt.Errorf("secret missing value for credential PrivateKey")
-	}
 
-	if secretData.PublicKey == "" {
-		return *config, fmt.Errorf("secret missing value for credential PublicKey")
+	if secretData.ClientID != "" || secretData.ClientSecret != "" {
+		if secretData.ClientID == "" {
+			return *config, fmt.Errorf("secret missing value for credential ClientID")
+		}
+
+		if secretData.ClientSecret == "" {
+			return *config, fmt.Errorf("secret missing value for credential ClientSecret")
+		}
+	} else {
+		if secretData.PrivateKey == "" {
+			return *config, fmt.Errorf("secret missing value for credential PrivateKey")
+		}
+
+		if secretData.PublicKey == "" {
+			return *config, fmt.Errorf("secret missing value for credential PublicKey")
+		}
 	}
 
 	config.PublicKey = secretData.PublicKey
 	config.PrivateKey = secretData.PrivateKey
+	config.ClientID = secretData.ClientID
+	config.ClientSecret = secretData.ClientSecret
 	return *config, nil
 }
 
@@ -522,6 +699,32 @@ func flattenLabels(l []matlas.Label) []map[string]interface{} {
 	return labels
 }
 
+// toComponentLabels converts labels to the model of the Atlas SDK.
+func toComponentLabels(l []matlas.Label) []admin.ComponentLabel {
+	labels := make([]admin.ComponentLabel, len(l))
+	for i, v := range l {
+		labels[i] = admin.ComponentLabel{
+			Key:   admin.PtrString(v.Key),
+			Value: admin.PtrString(v.Value),
+		}
+	}
+
+	return labels
+}
+
+// fromComponentLabels converts labels from the model of the Atlas SDK.
+func fromComponentLabels(l []admin.ComponentLabel) []matlas.Label {
+	labels := make([]matlas.Label, len(l))
+	for i, v := range l {
+		labels[i] = matlas.Label{
+			Key:   v.GetKey(),
+			Value: v.GetValue(),
+		}
+	}
+
+	return labels
+}
+
 func expandLabelSliceFromSetSchema(d *schema.ResourceData) []matlas.Label {
 	list := d.Get("labels").(*schema.Set)
 	res := make([]matlas.Label, list.Len())
@@ -566,6 +769,24 @@ func removeLabel(list []matlas.Label, item matlas.Label) []matlas.Label {
 	return list
 }
 
+// stringPtrOrNil returns nil for an empty string, so that the Atlas SDK leaves the field out of the request.
+func stringPtrOrNil(v string) *string {
+	if v == "" {
+		return nil
+	}
+
+	return &v
+}
+
+// timeToString formats a date of the Atlas SDK the way Atlas returns it, empty when it isn't set.
+func timeToString(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
+
 func expandStringList(list []interface{}) (res []string) {
 	for _, v := range list {
 		res = append(res, v.(string))
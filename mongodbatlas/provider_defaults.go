@@ -0,0 +1,125 @@
+package mongodbatlas
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// providerDefaultAttributes maps the resource attributes that can fall back to a provider level default
+// to the provider argument holding it.
+var providerDefaultAttributes = map[string]string{
+	"project_id": "default_project_id",
+	"org_id":     "default_org_id",
+}
+
+// addProviderDefaults makes the project_id and org_id attributes of the resources optional when they are
+// required, the value of default_project_id or default_org_id being used when they are omitted.
+func addProviderDefaults(provider *schema.Provider) {
+	for _, r := range provider.ResourcesMap {
+		var attributes []string
+		for attribute := range providerDefaultAttributes {
+			s, ok := r.Schema[attribute]
+			if !ok || !s.Required || s.Type != schema.TypeString {
+				continue
+			}
+
+			s.Required = false
+			s.Optional = true
+			// computed so that the default can be planned and the attribute keeps the value of the state
+			// when it is removed from a configuration while matching the default.
+			s.Computed = true
+			attributes = append(attributes, attribute)
+		}
+
+		if len(attributes) == 0 {
+			continue
+		}
+
+		if r.CustomizeDiff != nil {
+			r.CustomizeDiff = customdiff.Sequence(providerDefaultsCustomizeDiff(attributes), r.CustomizeDiff)
+		} else {
+			r.CustomizeDiff = providerDefaultsCustomizeDiff(attributes)
+		}
+
+		if r.Importer != nil && r.Importer.StateContext != nil {
+			r.Importer.StateContext = providerDefaultsImporter(attributes, r.Importer.StateContext)
+		}
+	}
+}
+
+// providerDefaultValue returns the provider level default of attribute, empty when it isn't set.
+func providerDefaultValue(meta interface{}, attribute string) string {
+	client, ok := meta.(*MongoDBClient)
+	if !ok || client == nil || client.Config == nil {
+		return ""
+	}
+
+	switch attribute {
+	case "project_id":
+		return client.Config.DefaultProjectID
+	case "org_id":
+		return client.Config.DefaultOrgID
+	}
+
+	return ""
+}
+
+// providerDefaultsCustomizeDiff plans the provider level default of the attributes omitted from the configuration.
+// A change of the default is planned like a change of the attribute, e.g. it replaces the resources for which the
+// attribute forces a new resource.
+func providerDefaultsCustomizeDiff(attributes []string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		rawConfig := d.GetRawConfig()
+		if rawConfig.IsNull() || !rawConfig.IsKnown() {
+			return nil
+		}
+
+		for _, attribute := range attributes {
+			if value := rawConfig.GetAttr(attribute); !value.IsNull() {
+				continue
+			}
+
+			defaultValue := providerDefaultValue(meta, attribute)
+			if defaultValue == "" {
+				return fmt.Errorf("%q: required field is not set, set it or the %q provider argument", attribute, providerDefaultAttributes[attribute])
+			}
+
+			if d.Get(attribute).(string) == defaultValue {
+				continue
+			}
+
+			if err := d.SetNew(attribute, defaultValue); err != nil {
+				return fmt.Errorf("error setting %q to the provider default: %w", attribute, err)
+			}
+		}
+
+		return nil
+	}
+}
+
+// providerDefaultsImporter sets the attributes the importer left empty to their provider level default.
+func providerDefaultsImporter(attributes []string, importer schema.StateContextFunc) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		results, err := importer(ctx, d, meta)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, result := range results {
+			for _, attribute := range attributes {
+				if result.Get(attribute).(string) != "" {
+					continue
+				}
+
+				if err := result.Set(attribute, providerDefaultValue(meta, attribute)); err != nil {
+					return nil, fmt.Errorf("error setting %q to the provider default: %w", attribute, err)
+				}
+			}
+		}
+
+		return results, nil
+	}
+}
@@ -0,0 +1,192 @@
+package mongodbatlas
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestAddProviderDefaults(t *testing.T) {
+	provider := Provider()
+
+	testCases := []struct {
+		resource  string
+		attribute string
+	}{
+		{"mongodbatlas_cluster", "project_id"},
+		{"mongodbatlas_advanced_cluster", "project_id"},
+		{"mongodbatlas_database_user", "project_id"},
+		{"mongodbatlas_project", "org_id"},
+		{"mongodbatlas_api_key", "org_id"},
+	}
+
+	for _, tc := range testCases {
+		s := provider.ResourcesMap[tc.resource].Schema[tc.attribute]
+		if s.Required || !s.Optional || !s.Computed {
+			t.Errorf("expected %s.%s to be optional and computed, got required=%t optional=%t computed=%t",
+				tc.resource, tc.attribute, s.Required, s.Optional, s.Computed)
+		}
+
+		if provider.ResourcesMap[tc.resource].CustomizeDiff == nil {
+			t.Errorf("expected %s to plan the provider default of %s", tc.resource, tc.attribute)
+		}
+	}
+
+	// computed attributes keep their schema.
+	if s := provider.ResourcesMap["mongodbatlas_organization"].Schema["org_id"]; s.Optional {
+		t.Error("expected mongodbatlas_organization.org_id to stay computed only")
+	}
+}
+
+func TestProviderDefaultsEnvironment(t *testing.T) {
+	// the variables of the acceptance tests aren't used as defaults.
+	t.Setenv("MONGODB_ATLAS_PROJECT_ID", "acceptance-project")
+	t.Setenv("MONGODB_ATLAS_ORG_ID", "acceptance-org")
+	t.Setenv("MONGODB_ATLAS_DEFAULT_PROJECT_ID", "default-project")
+	t.Setenv("MONGODB_ATLAS_DEFAULT_ORG_ID", "default-org")
+
+	provider := Provider()
+	for argument, expected := range map[string]string{"default_project_id": "default-project", "default_org_id": "default-org"} {
+		got, err := provider.Schema[argument].DefaultValue()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if got != expected {
+			t.Errorf("expected %s to default to %q, got %v", argument, expected, got)
+		}
+	}
+}
+
+func TestProviderDefaultsImporter(t *testing.T) {
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"project_id": {Type: schema.TypeString, Optional: true, Computed: true},
+			"name":       {Type: schema.TypeString, Optional: true},
+		},
+	}
+
+	importer := providerDefaultsImporter([]string{"project_id"}, func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		if err := d.Set("name", d.Id()); err != nil {
+			return nil, err
+		}
+		return []*schema.ResourceData{d}, nil
+	})
+
+	meta := &MongoDBClient{Config: &Config{DefaultProjectID: "default-project"}}
+
+	d := r.TestResourceData()
+	d.SetId("test")
+	results, err := importer(context.Background(), d, meta)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got := results[0].Get("project_id").(string); got != "default-project" {
+		t.Fatalf("expected the default project to be set, got %q", got)
+	}
+
+	d = r.TestResourceData()
+	d.SetId("test")
+	if err := d.Set("project_id", "imported-project"); err != nil {
+		t.Fatal(err)
+	}
+	if results, err = importer(context.Background(), d, meta); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got := results[0].Get("project_id").(string); got != "imported-project" {
+		t.Fatalf("expected the imported project to be kept, got %q", got)
+	}
+}
+
+func TestMockProviderDefaultProjectID(t *testing.T) {
+	var (
+		mock         = newAtlasMockServer(t)
+		projectID    = mock.newProject("test-default-project")
+		otherID      = mock.newProject("test-other-project")
+		resourceName = "mongodbatlas_database_user.test"
+		username     = "test-default-project-user"
+	)
+
+	testMockResourceUnitTest(t, mock, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:      testMockProviderDefaultsConfig("", username),
+				ExpectError: regexp.MustCompile(`"project_id": required field is not set`),
+			},
+			{
+				Config: testMockProviderDefaultsConfig(projectID, username),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "project_id", projectID),
+					resource.TestCheckResourceAttr(resourceName, "username", username),
+				),
+			},
+			{
+				// setting the attribute to the default doesn't change anything.
+				Config: testMockProviderDefaultsConfig(projectID, username) + fmt.Sprintf(`
+					resource "mongodbatlas_database_user" "explicit" {
+						project_id         = %[1]q
+						username           = "test-explicit-project-user"
+						password           = "test-mock-password"
+						auth_database_name = "admin"
+
+						roles {
+							role_name     = "read"
+							database_name = "admin"
+						}
+					}
+				`, projectID),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionNoop),
+						plancheck.ExpectResourceAction("mongodbatlas_database_user.explicit", plancheck.ResourceActionCreate),
+					},
+				},
+			},
+			{
+				ResourceName:            resourceName,
+				ImportStateIdFunc:       testAccCheckMongoDBAtlasDatabaseUserImportStateIDFunc(resourceName),
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+			{
+				// project_id forces a new database user, so does a change of the default.
+				Config: testMockProviderDefaultsConfig(otherID, username),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "project_id", otherID),
+				),
+			},
+		},
+	})
+}
+
+func testMockProviderDefaultsConfig(projectID, username string) string {
+	return fmt.Sprintf(`
+		provider "mongodbatlas" {
+			default_project_id = %[1]q
+		}
+
+		resource "mongodbatlas_database_user" "test" {
+			username           = %[2]q
+			password           = "test-mock-password"
+			auth_database_name = "admin"
+
+			roles {
+				role_name     = "read"
+				database_name = "admin"
+			}
+		}
+	`, projectID, username)
+}
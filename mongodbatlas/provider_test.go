@@ -4,6 +4,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-test/deep"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -189,6 +190,99 @@ func TestRemoveLabel(t *testing.T) {
 	}
 }
 
+func TestResourceTimeouts(t *testing.T) {
+	expected := map[string]map[string]time.Duration{
+		"mongodbatlas_serverless_instance":   {"create": 3 * time.Hour, "update": 3 * time.Hour, "delete": 3 * time.Hour},
+		"mongodbatlas_online_archive":        {"create": 3 * time.Hour, "delete": 20 * time.Minute},
+		"mongodbatlas_cloud_backup_snapshot": {"create": 1 * time.Hour, "delete": 20 * time.Minute},
+		"mongodbatlas_network_peering":       {"create": 1 * time.Hour, "update": 1 * time.Hour, "delete": 1 * time.Hour},
+		"mongodbatlas_encryption_at_rest":    {"create": 5 * time.Minute, "update": 5 * time.Minute},
+	}
+
+	resources := Provider().ResourcesMap
+	for name, want := range expected {
+		timeouts := resources[name].Timeouts
+		if timeouts == nil {
+			t.Errorf("%s: expected configurable timeouts", name)
+			continue
+		}
+
+		got := map[string]time.Duration{}
+		for key, timeout := range map[string]*time.Duration{"create": timeouts.Create, "update": timeouts.Update, "delete": timeouts.Delete} {
+			if timeout != nil {
+				got[key] = *timeout
+			}
+		}
+
+		if diff := deep.Equal(want, got); diff != nil {
+			t.Errorf("Bad %s timeouts \n got = %#v\nwant = %#v \ndiff = %#v", name, got, want, diff)
+		}
+	}
+}
+
+func TestValidateCredentials(t *testing.T) {
+	testCases := map[string]struct {
+		config         Config
+		expectError    bool
+		expectWarnings int
+	}{
+		"api key":               {config: Config{PublicKey: "public", PrivateKey: "private"}},
+		"service account":       {config: Config{ClientID: "id", ClientSecret: "secret"}},
+		"both":                  {config: Config{PublicKey: "public", PrivateKey: "private", ClientID: "id", ClientSecret: "secret"}, expectWarnings: 1},
+		"missing client secret": {config: Config{PublicKey: "public", PrivateKey: "private", ClientID: "id"}, expectError: true},
+		"missing private key":   {config: Config{PublicKey: "public"}, expectError: true},
+		"no credentials":        {config: Config{}, expectError: true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			diags := validateCredentials(&tc.config)
+			if diags.HasError() != tc.expectError {
+				t.Fatalf("expected error: %t, got %v", tc.expectError, diags)
+			}
+
+			if !tc.expectError && len(diags) != tc.expectWarnings {
+				t.Fatalf("expected %d warnings, got %v", tc.expectWarnings, diags)
+			}
+		})
+	}
+}
+
+func TestConfigureCredentialsFromSecret(t *testing.T) {
+	testCases := map[string]struct {
+		secret      string
+		expected    Config
+		expectError bool
+	}{
+		"api key":               {secret: `{"public_key": "public", "private_key": "private"}`, expected: Config{PublicKey: "public", PrivateKey: "private"}},
+		"service account":       {secret: `{"client_id": "id", "client_secret": "secret"}`, expected: Config{ClientID: "id", ClientSecret: "secret"}},
+		"missing private key":   {secret: `{"public_key": "public"}`, expectError: true},
+		"missing client secret": {secret: `{"client_id": "id", "private_key": "private"}`, expectError: true},
+		"empty":                 {secret: `{}`, expectError: true},
+		"invalid":               {secret: `public:private`, expectError: true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := configureCredentialsFromSecret(&Config{}, tc.secret)
+			if tc.expectError {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if diff := deep.Equal(tc.expected, got); diff != nil {
+				t.Fatalf("Bad credentials \n got = %#v\nwant = %#v \ndiff = %#v", got, tc.expected, diff)
+			}
+		})
+	}
+}
+
 func SkipTestExtCred(tb testing.TB) {
 	if strings.EqualFold(os.Getenv("SKIP_TEST_EXTERNAL_CREDENTIALS"), "true") {
 		tb.Skip()
@@ -13,11 +13,12 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	"github.com/mwielbut/pointy"
 	"github.com/spf13/cast"
+	"go.mongodb.org/atlas-sdk/v20230201002/admin"
 	matlas "go.mongodb.org/atlas/mongodbatlas"
 	"golang.org/x/exp/slices"
 )
@@ -33,6 +34,7 @@ const (
 	errorAdvancedClusterAdvancedConfUpdate = "error updating Advanced Configuration Option form MongoDB Cluster (%s): %s"
 	errorAdvancedClusterAdvancedConfRead   = "error reading Advanced Configuration Option form MongoDB Cluster (%s): %s"
 	errorAdvancedClusterListStatus         = "error awaiting MongoDB ClusterAdvanced List IDLE: %s"
+	errorClusterAdvancedFinalSnapshot      = "error taking the final snapshot of MongoDB ClusterAdvanced (%s): %s"
 )
 
 var upgradeRequestCtxKey acCtxKey = "upgradeRequest"
@@ -46,6 +48,13 @@ func resourceMongoDBAtlasAdvancedCluster() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceMongoDBAtlasAdvancedClusterImportState,
 		},
+		CustomizeDiff: customdiff.Sequence(
+			resourceAdvancedClusterCustomizeDiff,
+			resourceAdvancedClusterFinalSnapshotCustomizeDiff,
+			resourceAdvancedClusterServerlessUpgradeCustomizeDiff,
+			resourceAdvancedClusterChangeWarnings,
+			customizeDiffDefaultLabels,
+		),
 		SchemaVersion: 1,
 		StateUpgraders: []schema.StateUpgrader{
 			{
@@ -74,6 +83,31 @@ func resourceMongoDBAtlasAdvancedCluster() *schema.Resource {
 				Optional:    true,
 				Description: "Flag that indicates whether to retain backup snapshots for the deleted dedicated cluster",
 			},
+			"final_snapshot": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Takes an on-demand snapshot of the cluster before deleting it, the snapshot is kept after the cluster is deleted",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"description": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"retention_in_days": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      7,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+					},
+				},
+			},
+			"upgrade_from_serverless": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Creates the cluster by upgrading the serverless instance with the same name to a dedicated cluster, keeping its data",
+			},
 			"bi_connector": {
 				Type:          schema.TypeList,
 				Optional:      true,
@@ -162,6 +196,7 @@ func resourceMongoDBAtlasAdvancedCluster() *schema.Resource {
 					},
 				},
 			},
+			"labels_all": labelsAllSchema(),
 			"mongo_db_major_version": {
 				Type:      schema.TypeString,
 				Optional:  true,
@@ -356,6 +391,11 @@ func advancedClusterRegionConfigsSpecsSchema() *schema.Schema {
 					Type:     schema.TypeInt,
 					Optional: true,
 				},
+				"disk_size_gb": {
+					Type:     schema.TypeFloat,
+					Optional: true,
+					Computed: true,
+				},
 				"ebs_volume_type": {
 					Type:     schema.TypeString,
 					Optional: true,
@@ -376,87 +416,130 @@ func advancedClusterRegionConfigsSpecsSchema() *schema.Schema {
 func resourceMongoDBAtlasAdvancedClusterCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// Get client connection.
 	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 
 	projectID := d.Get("project_id").(string)
 
-	request := &matlas.AdvancedCluster{
-		Name:             d.Get("name").(string),
-		ClusterType:      cast.ToString(d.Get("cluster_type")),
+	request := &admin.AdvancedClusterDescription{
+		Name:             admin.PtrString(d.Get("name").(string)),
+		ClusterType:      admin.PtrString(cast.ToString(d.Get("cluster_type"))),
 		ReplicationSpecs: expandAdvancedReplicationSpecs(d.Get("replication_specs").([]interface{})),
 	}
 
 	if v, ok := d.GetOk("backup_enabled"); ok {
-		request.BackupEnabled = pointy.Bool(v.(bool))
+		request.BackupEnabled = admin.PtrBool(v.(bool))
 	}
 	if _, ok := d.GetOk("bi_connector"); ok {
-		biConnector, err := expandBiConnectorConfig(d)
+		biConnector, err := expandAdvancedBiConnectorConfig(d)
 		if err != nil {
 			return diag.FromErr(fmt.Errorf(errorClusterAdvancedCreate, err))
 		}
 		request.BiConnector = biConnector
 	}
 	if _, ok := d.GetOk("bi_connector_config"); ok {
-		biConnector, err := expandBiConnectorConfig(d)
+		biConnector, err := expandAdvancedBiConnectorConfig(d)
 		if err != nil {
 			return diag.FromErr(fmt.Errorf(errorClusterAdvancedCreate, err))
 		}
 		request.BiConnector = biConnector
 	}
 	if v, ok := d.GetOk("disk_size_gb"); ok {
-		request.DiskSizeGB = pointy.Float64(v.(float64))
+		request.DiskSizeGB = admin.PtrFloat64(v.(float64))
 	}
 	if v, ok := d.GetOk("encryption_at_rest_provider"); ok {
-		request.EncryptionAtRestProvider = v.(string)
+		request.EncryptionAtRestProvider = admin.PtrString(v.(string))
 	}
 
 	if _, ok := d.GetOk("labels"); ok && containsLabelOrKey(expandLabelSliceFromSetSchema(d), defaultLabel) {
 		return diag.FromErr(fmt.Errorf("you should not set `Infrastructure Tool` label, it is used for internal purposes"))
 	}
-	request.Labels = append(expandLabelSliceFromSetSchema(d), defaultLabel)
+	request.Labels = toComponentLabels(append(expandLabelsWithDefaults(d, meta), defaultLabel))
 
 	if v, ok := d.GetOk("mongo_db_major_version"); ok {
-		request.MongoDBMajorVersion = formatMongoDBMajorVersion(v.(string))
+		request.MongoDBMajorVersion = admin.PtrString(formatMongoDBMajorVersion(v.(string)))
 	}
 	if v, ok := d.GetOk("pit_enabled"); ok {
-		request.PitEnabled = pointy.Bool(v.(bool))
+		request.PitEnabled = admin.PtrBool(v.(bool))
 	}
 	if v, ok := d.GetOk("root_cert_type"); ok {
-		request.RootCertType = v.(string)
+		request.RootCertType = admin.PtrString(v.(string))
 	}
 	if v, ok := d.GetOk("termination_protection_enabled"); ok {
-		request.TerminationProtectionEnabled = pointy.Bool(v.(bool))
+		request.TerminationProtectionEnabled = admin.PtrBool(v.(bool))
 	}
 	if v, ok := d.GetOk("version_release_system"); ok {
-		request.VersionReleaseSystem = v.(string)
+		request.VersionReleaseSystem = admin.PtrString(v.(string))
 	}
 
-	// We need to validate the oplog_size_mb attr of the advanced configuration option to show the error
-	// before that the cluster is created
-	if oplogSizeMB, ok := d.GetOkExists("advanced_configuration.0.oplog_size_mb"); ok {
-		if cast.ToInt64(oplogSizeMB) <= 0 {
-			return diag.FromErr(fmt.Errorf("`advanced_configuration.oplog_size_mb` cannot be <= 0"))
+	var (
+		cluster *admin.AdvancedClusterDescription
+		err     error
+	)
+
+	timeout := d.Timeout(schema.TimeoutCreate)
+	upgradeFromServerless := d.Get("upgrade_from_serverless").(bool)
+	shardedIndependently := isAdvancedClusterShardedIndependently(request.ReplicationSpecs)
+	switch {
+	case upgradeFromServerless:
+		// the upgrade and the update of the cluster to its configuration both wait for the cluster to be IDLE,
+		// they share the timeout of the creation.
+		deadline := time.Now().Add(timeout)
+
+		var upgraded *matlas.Cluster
+		upgraded, err = upgradeServerlessInstance(ctx, conn, connV2, projectID, request, timeout)
+		if err != nil {
+			break
 		}
-	}
 
-	cluster, _, err := conn.AdvancedClusters.Create(ctx, projectID, request)
+		d.SetId(encodeStateID(map[string]string{
+			"cluster_id":   upgraded.ID,
+			"project_id":   projectID,
+			"cluster_name": request.GetName(),
+		}))
+
+		cluster, err = updateUpgradedServerlessInstance(ctx, connV2, projectID, request, time.Until(deadline))
+		if err != nil {
+			// the upgraded cluster holds the data of the serverless instance: it's read in the state without an error,
+			// which would taint it, so that the next plan updates it to its configuration instead of replacing it.
+			diags := resourceMongoDBAtlasAdvancedClusterRead(ctx, d, meta)
+			return append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "The serverless instance was upgraded but the cluster wasn't updated to its configuration",
+				Detail:   fmt.Sprintf("%s. The next apply updates the cluster to its configuration.", err),
+			})
+		}
+		timeout = time.Until(deadline)
+	case shardedIndependently:
+		shardsRequest := &advancedClusterShardsDescription{
+			AdvancedClusterDescription: *request,
+			ReplicationSpecs:           expandAdvancedClusterShardSpecs(d.Get("replication_specs").([]interface{}), request.GetDiskSizeGB()),
+		}
+		// the disk size is set for each shard in this version of the API.
+		shardsRequest.DiskSizeGB = nil
+
+		cluster, err = createAdvancedClusterShards(ctx, connV2, projectID, shardsRequest)
+	default:
+		cluster, _, err = connV2.MultiCloudClustersApi.CreateCluster(ctx, projectID, request).Execute()
+	}
 	if err != nil {
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedCreate, err))
 	}
 
-	timeout := d.Timeout(schema.TimeoutCreate)
-	stateConf := &retry.StateChangeConf{
-		Pending:    []string{"CREATING", "UPDATING", "REPAIRING", "REPEATING", "PENDING"},
-		Target:     []string{"IDLE"},
-		Refresh:    resourceClusterAdvancedRefreshFunc(ctx, d.Get("name").(string), projectID, conn),
-		Timeout:    timeout,
-		MinTimeout: 1 * time.Minute,
-		Delay:      3 * time.Minute,
-	}
+	if !upgradeFromServerless {
+		stateConf := &retry.StateChangeConf{
+			Pending:    []string{"CREATING", "UPDATING", "REPAIRING", "REPEATING", "PENDING"},
+			Target:     []string{"IDLE"},
+			Refresh:    resourceClusterAdvancedRefreshFunc(ctx, d.Get("name").(string), projectID, connV2, shardedIndependently),
+			Timeout:    timeout,
+			MinTimeout: 1 * time.Minute,
+			Delay:      3 * time.Minute,
+		}
 
-	// Wait, catching any errors
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf(errorClusterAdvancedCreate, err))
+		// Wait, catching any errors
+		_, err = stateConf.WaitForStateContext(ctx)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf(errorClusterAdvancedCreate, err))
+		}
 	}
 
 	/*
@@ -468,29 +551,33 @@ func resourceMongoDBAtlasAdvancedClusterCreate(ctx context.Context, d *schema.Re
 		advancedConfReq := expandProcessArgs(d, aclist[0].(map[string]interface{}))
 
 		if ok {
-			_, _, err := conn.Clusters.UpdateProcessArgs(ctx, projectID, cluster.Name, advancedConfReq)
+			_, _, err := conn.Clusters.UpdateProcessArgs(ctx, projectID, cluster.GetName(), advancedConfReq)
 			if err != nil {
-				return diag.FromErr(fmt.Errorf(errorAdvancedClusterAdvancedConfUpdate, cluster.Name, err))
+				return diag.FromErr(fmt.Errorf(errorAdvancedClusterAdvancedConfUpdate, cluster.GetName(), err))
 			}
 		}
 	}
 
 	// To pause a cluster
 	if v := d.Get("paused").(bool); v {
-		request = &matlas.AdvancedCluster{
-			Paused: pointy.Bool(v),
+		request = &admin.AdvancedClusterDescription{
+			Paused: admin.PtrBool(v),
 		}
 
-		_, _, err = updateAdvancedCluster(ctx, conn, request, projectID, d.Get("name").(string), timeout)
+		if shardedIndependently {
+			err = updateAdvancedClusterShards(ctx, connV2, &advancedClusterShardsDescription{AdvancedClusterDescription: *request}, projectID, d.Get("name").(string), timeout)
+		} else {
+			_, _, err = updateAdvancedCluster(ctx, connV2, request, projectID, d.Get("name").(string), timeout)
+		}
 		if err != nil {
 			return diag.FromErr(fmt.Errorf(errorClusterAdvancedUpdate, d.Get("name").(string), err))
 		}
 	}
 
 	d.SetId(encodeStateID(map[string]string{
-		"cluster_id":   cluster.ID,
+		"cluster_id":   cluster.GetId(),
 		"project_id":   projectID,
-		"cluster_name": cluster.Name,
+		"cluster_name": cluster.GetName(),
 	}))
 
 	return resourceMongoDBAtlasAdvancedClusterRead(ctx, d, meta)
@@ -499,11 +586,13 @@ func resourceMongoDBAtlasAdvancedClusterCreate(ctx context.Context, d *schema.Re
 func resourceMongoDBAtlasAdvancedClusterRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// Get client connection.
 	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 	ids := decodeStateID(d.Id())
 	projectID := ids["project_id"]
 	clusterName := ids["cluster_name"]
+	shardedIndependently := isAdvancedClusterShardedIndependently(expandAdvancedReplicationSpecs(d.Get("replication_specs").([]interface{})))
 
-	cluster, resp, err := conn.AdvancedClusters.Get(ctx, projectID, clusterName)
+	cluster, shards, resp, err := getAdvancedCluster(ctx, connV2, projectID, clusterName, shardedIndependently)
 	if err != nil {
 		if resp != nil && resp.StatusCode == http.StatusNotFound {
 			d.SetId("")
@@ -515,88 +604,89 @@ func resourceMongoDBAtlasAdvancedClusterRead(ctx context.Context, d *schema.Reso
 
 	log.Printf("[DEBUG] GET ClusterAdvanced %+v", cluster)
 
-	if err := d.Set("cluster_id", cluster.ID); err != nil {
+	if err := d.Set("cluster_id", cluster.GetId()); err != nil {
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedSetting, "cluster_id", clusterName, err))
 	}
 
-	if err := d.Set("backup_enabled", cluster.BackupEnabled); err != nil {
+	if err := d.Set("backup_enabled", cluster.GetBackupEnabled()); err != nil {
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedSetting, "backup_enabled", clusterName, err))
 	}
 
-	if err := d.Set("bi_connector", flattenBiConnectorConfig(cluster.BiConnector)); err != nil {
+	if err := d.Set("bi_connector", flattenAdvancedBiConnectorConfig(cluster.BiConnector)); err != nil {
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedSetting, "bi_connector", clusterName, err))
 	}
 
-	if err := d.Set("bi_connector_config", flattenBiConnectorConfig(cluster.BiConnector)); err != nil {
+	if err := d.Set("bi_connector_config", flattenAdvancedBiConnectorConfig(cluster.BiConnector)); err != nil {
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedSetting, "bi_connector_config", clusterName, err))
 	}
 
-	if err := d.Set("cluster_type", cluster.ClusterType); err != nil {
+	if err := d.Set("cluster_type", cluster.GetClusterType()); err != nil {
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedSetting, "cluster_type", clusterName, err))
 	}
 
-	if err := d.Set("connection_strings", flattenConnectionStrings(cluster.ConnectionStrings)); err != nil {
+	if err := d.Set("connection_strings", flattenAdvancedConnectionStrings(cluster.ConnectionStrings)); err != nil {
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedSetting, "connection_strings", clusterName, err))
 	}
 
-	if err := d.Set("create_date", cluster.CreateDate); err != nil {
+	if err := d.Set("create_date", timeToString(cluster.CreateDate)); err != nil {
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedSetting, "create_date", clusterName, err))
 	}
 
-	if err := d.Set("disk_size_gb", cluster.DiskSizeGB); err != nil {
+	if err := d.Set("disk_size_gb", cluster.GetDiskSizeGB()); err != nil {
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedSetting, "disk_size_gb", clusterName, err))
 	}
 
-	if err := d.Set("encryption_at_rest_provider", cluster.EncryptionAtRestProvider); err != nil {
+	if err := d.Set("encryption_at_rest_provider", cluster.GetEncryptionAtRestProvider()); err != nil {
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedSetting, "encryption_at_rest_provider", clusterName, err))
 	}
 
-	if err := d.Set("labels", flattenLabels(removeLabel(cluster.Labels, defaultLabel))); err != nil {
+	if err := setLabelsWithDefaults(d, meta, removeLabel(fromComponentLabels(cluster.Labels), defaultLabel)); err != nil {
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedSetting, "labels", clusterName, err))
 	}
 
-	if err := d.Set("mongo_db_major_version", cluster.MongoDBMajorVersion); err != nil {
+	if err := d.Set("mongo_db_major_version", cluster.GetMongoDBMajorVersion()); err != nil {
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedSetting, "mongo_db_major_version", clusterName, err))
 	}
 
-	if err := d.Set("mongo_db_version", cluster.MongoDBVersion); err != nil {
+	if err := d.Set("mongo_db_version", cluster.GetMongoDBVersion()); err != nil {
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedSetting, "mongo_db_version", clusterName, err))
 	}
 
-	if err := d.Set("name", cluster.Name); err != nil {
+	if err := d.Set("name", cluster.GetName()); err != nil {
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedSetting, "name", clusterName, err))
 	}
 
-	if err := d.Set("paused", cluster.Paused); err != nil {
+	if err := d.Set("paused", cluster.GetPaused()); err != nil {
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedSetting, "paused", clusterName, err))
 	}
 
-	if err := d.Set("pit_enabled", cluster.PitEnabled); err != nil {
+	if err := d.Set("pit_enabled", cluster.GetPitEnabled()); err != nil {
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedSetting, "pit_enabled", clusterName, err))
 	}
 
-	replicationSpecs, err := flattenAdvancedReplicationSpecs(ctx, cluster.ReplicationSpecs, d.Get("replication_specs").([]interface{}), d, conn)
+	replicationSpecs, err := flattenAdvancedReplicationSpecs(ctx, cluster.ReplicationSpecs, d.Get("replication_specs").([]interface{}), d, connV2)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedSetting, "replication_specs", clusterName, err))
 	}
+	flattenAdvancedClusterShardDiskSizes(replicationSpecs, shards)
 
 	if err := d.Set("replication_specs", replicationSpecs); err != nil {
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedSetting, "replication_specs", clusterName, err))
 	}
 
-	if err := d.Set("root_cert_type", cluster.RootCertType); err != nil {
+	if err := d.Set("root_cert_type", cluster.GetRootCertType()); err != nil {
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedSetting, "state_name", clusterName, err))
 	}
 
-	if err := d.Set("state_name", cluster.StateName); err != nil {
+	if err := d.Set("state_name", cluster.GetStateName()); err != nil {
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedSetting, "state_name", clusterName, err))
 	}
 
-	if err := d.Set("termination_protection_enabled", cluster.TerminationProtectionEnabled); err != nil {
+	if err := d.Set("termination_protection_enabled", cluster.GetTerminationProtectionEnabled()); err != nil {
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedSetting, "termination_protection_enabled", clusterName, err))
 	}
 
-	if err := d.Set("version_release_system", cluster.VersionReleaseSystem); err != nil {
+	if err := d.Set("version_release_system", cluster.GetVersionReleaseSystem()); err != nil {
 		return diag.FromErr(fmt.Errorf(errorClusterAdvancedSetting, "version_release_system", clusterName, err))
 	}
 
@@ -625,6 +715,7 @@ func resourceMongoDBAtlasAdvancedClusterUpdateOrUpgrade(ctx context.Context, d *
 }
 
 func resourceMongoDBAtlasAdvancedClusterUpgrade(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// the upgrade of a shared-tier cluster is only available in the legacy API.
 	conn := meta.(*MongoDBClient).Atlas
 	ids := decodeStateID(d.Id())
 	projectID := ids["project_id"]
@@ -654,51 +745,65 @@ func resourceMongoDBAtlasAdvancedClusterUpgrade(ctx context.Context, d *schema.R
 func resourceMongoDBAtlasAdvancedClusterUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// Get client connection.
 	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 	ids := decodeStateID(d.Id())
 	projectID := ids["project_id"]
 	clusterName := ids["cluster_name"]
 
-	cluster := new(matlas.AdvancedCluster)
-	clusterChangeDetect := new(matlas.AdvancedCluster)
+	cluster := new(admin.AdvancedClusterDescription)
+	clusterChangeDetect := new(admin.AdvancedClusterDescription)
+	shardedIndependently := isAdvancedClusterShardedIndependently(expandAdvancedReplicationSpecs(d.Get("replication_specs").([]interface{})))
+	timeout := d.Timeout(schema.TimeoutUpdate)
+
+	// a cluster sharded independently is updated through the version of the API describing each shard.
+	update := func(request *admin.AdvancedClusterDescription, shards []advancedClusterShardSpec) error {
+		if shardedIndependently {
+			return updateAdvancedClusterShards(ctx, connV2, &advancedClusterShardsDescription{AdvancedClusterDescription: *request, ReplicationSpecs: shards},
+				projectID, clusterName, timeout)
+		}
+
+		_, _, err := updateAdvancedCluster(ctx, connV2, request, projectID, clusterName, timeout)
+		return err
+	}
 
 	if d.HasChange("backup_enabled") {
-		cluster.BackupEnabled = pointy.Bool(d.Get("backup_enabled").(bool))
+		cluster.BackupEnabled = admin.PtrBool(d.Get("backup_enabled").(bool))
 	}
 
 	if d.HasChange("bi_connector_config") {
-		cluster.BiConnector, _ = expandBiConnectorConfig(d)
+		cluster.BiConnector, _ = expandAdvancedBiConnectorConfig(d)
 	}
 
 	if d.HasChange("bi_connector") {
-		cluster.BiConnector, _ = expandBiConnectorConfig(d)
+		cluster.BiConnector, _ = expandAdvancedBiConnectorConfig(d)
 	}
 
 	if d.HasChange("cluster_type") {
-		cluster.ClusterType = d.Get("cluster_type").(string)
+		cluster.ClusterType = admin.PtrString(d.Get("cluster_type").(string))
 	}
 
 	if d.HasChange("disk_size_gb") {
-		cluster.DiskSizeGB = pointy.Float64(d.Get("disk_size_gb").(float64))
+		cluster.DiskSizeGB = admin.PtrFloat64(d.Get("disk_size_gb").(float64))
 	}
 
 	if d.HasChange("encryption_at_rest_provider") {
-		cluster.EncryptionAtRestProvider = d.Get("encryption_at_rest_provider").(string)
+		cluster.EncryptionAtRestProvider = admin.PtrString(d.Get("encryption_at_rest_provider").(string))
 	}
 
-	if d.HasChange("labels") {
+	if d.HasChange("labels") || d.HasChange("labels_all") {
 		if containsLabelOrKey(expandLabelSliceFromSetSchema(d), defaultLabel) {
 			return diag.FromErr(fmt.Errorf("you should not set `Infrastructure Tool` label, it is used for internal purposes"))
 		}
 
-		cluster.Labels = append(expandLabelSliceFromSetSchema(d), defaultLabel)
+		cluster.Labels = toComponentLabels(append(expandLabelsWithDefaults(d, meta), defaultLabel))
 	}
 
 	if d.HasChange("mongo_db_major_version") {
-		cluster.MongoDBMajorVersion = formatMongoDBMajorVersion(d.Get("mongo_db_major_version"))
+		cluster.MongoDBMajorVersion = admin.PtrString(formatMongoDBMajorVersion(d.Get("mongo_db_major_version")))
 	}
 
 	if d.HasChange("pit_enabled") {
-		cluster.PitEnabled = pointy.Bool(d.Get("pit_enabled").(bool))
+		cluster.PitEnabled = admin.PtrBool(d.Get("pit_enabled").(bool))
 	}
 
 	if d.HasChange("replication_specs") {
@@ -706,39 +811,49 @@ func resourceMongoDBAtlasAdvancedClusterUpdate(ctx context.Context, d *schema.Re
 	}
 
 	if d.HasChange("root_cert_type") {
-		cluster.RootCertType = d.Get("root_cert_type").(string)
+		cluster.RootCertType = admin.PtrString(d.Get("root_cert_type").(string))
 	}
 
 	if d.HasChange("termination_protection_enabled") {
-		cluster.TerminationProtectionEnabled = pointy.Bool(d.Get("termination_protection_enabled").(bool))
+		cluster.TerminationProtectionEnabled = admin.PtrBool(d.Get("termination_protection_enabled").(bool))
 	}
 
 	if d.HasChange("version_release_system") {
-		cluster.VersionReleaseSystem = d.Get("version_release_system").(string)
+		cluster.VersionReleaseSystem = admin.PtrString(d.Get("version_release_system").(string))
 	}
 
 	if d.HasChange("paused") && !d.Get("paused").(bool) {
-		cluster.Paused = pointy.Bool(d.Get("paused").(bool))
+		cluster.Paused = admin.PtrBool(d.Get("paused").(bool))
 	}
 
-	timeout := d.Timeout(schema.TimeoutUpdate)
+	var shards []advancedClusterShardSpec
+	if shardedIndependently && (d.HasChange("replication_specs") || d.HasChange("disk_size_gb")) {
+		var diskSizeGB float64
+		if !d.GetRawConfig().GetAttr("disk_size_gb").IsNull() {
+			diskSizeGB = d.Get("disk_size_gb").(float64)
+		}
+
+		// the disk size is set for each shard in this version of the API.
+		shards = expandAdvancedClusterShardSpecs(d.Get("replication_specs").([]interface{}), diskSizeGB)
+		cluster.ReplicationSpecs = nil
+		cluster.DiskSizeGB = nil
+	}
 
 	// Has changes
-	if !reflect.DeepEqual(cluster, clusterChangeDetect) {
+	if !reflect.DeepEqual(cluster, clusterChangeDetect) || len(shards) > 0 {
 		err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
-			_, _, err := updateAdvancedCluster(ctx, conn, cluster, projectID, clusterName, timeout)
+			err := update(cluster, shards)
 			if err != nil {
-				var target *matlas.ErrorResponse
-				if errors.As(err, &target) && target.ErrorCode == "CANNOT_UPDATE_PAUSED_CLUSTER" {
-					clusterRequest := &matlas.AdvancedCluster{
-						Paused: pointy.Bool(false),
+				if isAdvancedClusterErrorCode(err, "CANNOT_UPDATE_PAUSED_CLUSTER") {
+					clusterRequest := &admin.AdvancedClusterDescription{
+						Paused: admin.PtrBool(false),
 					}
-					_, _, err := updateAdvancedCluster(ctx, conn, clusterRequest, projectID, clusterName, timeout)
+					err := update(clusterRequest, nil)
 					if err != nil {
 						return retry.NonRetryableError(fmt.Errorf(errorClusterAdvancedUpdate, clusterName, err))
 					}
 				}
-				if errors.As(err, &target) && target.HTTPCode == 400 {
+				if advancedClusterErrorStatus(err) == http.StatusBadRequest {
 					return retry.NonRetryableError(fmt.Errorf(errorClusterAdvancedUpdate, clusterName, err))
 				}
 			}
@@ -766,11 +881,11 @@ func resourceMongoDBAtlasAdvancedClusterUpdate(ctx context.Context, d *schema.Re
 	}
 
 	if d.Get("paused").(bool) {
-		clusterRequest := &matlas.AdvancedCluster{
-			Paused: pointy.Bool(true),
+		clusterRequest := &admin.AdvancedClusterDescription{
+			Paused: admin.PtrBool(true),
 		}
 
-		_, _, err := updateAdvancedCluster(ctx, conn, clusterRequest, projectID, clusterName, timeout)
+		err := update(clusterRequest, nil)
 		if err != nil {
 			return diag.FromErr(fmt.Errorf(errorClusterAdvancedUpdate, clusterName, err))
 		}
@@ -781,21 +896,40 @@ func resourceMongoDBAtlasAdvancedClusterUpdate(ctx context.Context, d *schema.Re
 
 func resourceMongoDBAtlasAdvancedClusterDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// Get client connection.
-	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 	ids := decodeStateID(d.Id())
 	projectID := ids["project_id"]
 	clusterName := ids["cluster_name"]
 
-	var options *matlas.DeleteAdvanceClusterOptions
-	if v, ok := d.GetOkExists("retain_backups_enabled"); ok {
-		options = &matlas.DeleteAdvanceClusterOptions{
-			RetainBackups: pointy.Bool(v.(bool)),
+	var diags diag.Diagnostics
+
+	retainBackups, retainBackupsSet := d.GetOkExists("retain_backups_enabled")
+	if finalSnapshot := d.Get("final_snapshot").([]interface{}); len(finalSnapshot) > 0 && finalSnapshot[0] != nil {
+		if !retainBackupsSet || !retainBackups.(bool) {
+			return diag.FromErr(fmt.Errorf(errorClusterAdvancedFinalSnapshot, clusterName, "`retain_backups_enabled` must be true, the snapshot would be deleted with the cluster"))
+		}
+
+		snapshotID, err := takeAdvancedClusterFinalSnapshot(ctx, connV2, projectID, clusterName, finalSnapshot[0].(map[string]interface{}), d.Timeout(schema.TimeoutDelete))
+		if err != nil {
+			return diag.FromErr(fmt.Errorf(errorClusterAdvancedFinalSnapshot, clusterName, err))
 		}
+
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Final snapshot of cluster %q kept after its deletion", clusterName),
+			Detail: fmt.Sprintf("The snapshot %s of cluster %s in project %s is kept after the cluster is deleted, "+
+				"it can be restored with a mongodbatlas_cloud_backup_snapshot_restore_job.", snapshotID, clusterName, projectID),
+		})
+	}
+
+	request := connV2.MultiCloudClustersApi.DeleteCluster(ctx, projectID, clusterName)
+	if retainBackupsSet {
+		request = request.RetainBackups(retainBackups.(bool))
 	}
 
-	_, err := conn.AdvancedClusters.Delete(ctx, projectID, clusterName, options)
+	_, err := request.Execute()
 	if err != nil {
-		return diag.FromErr(fmt.Errorf(errorClusterAdvancedDelete, clusterName, err))
+		return append(diags, diag.FromErr(fmt.Errorf(errorClusterAdvancedDelete, clusterName, err))...)
 	}
 
 	log.Println("[INFO] Waiting for MongoDB ClusterAdvanced to be destroyed")
@@ -803,7 +937,7 @@ func resourceMongoDBAtlasAdvancedClusterDelete(ctx context.Context, d *schema.Re
 	stateConf := &retry.StateChangeConf{
 		Pending:    []string{"IDLE", "CREATING", "UPDATING", "REPAIRING", "DELETING"},
 		Target:     []string{"DELETED"},
-		Refresh:    resourceClusterAdvancedRefreshFunc(ctx, clusterName, projectID, conn),
+		Refresh:    resourceClusterAdvancedRefreshFunc(ctx, clusterName, projectID, connV2, false),
 		Timeout:    d.Timeout(schema.TimeoutDelete),
 		MinTimeout: 30 * time.Second,
 		Delay:      1 * time.Minute, // Wait 30 secs before starting
@@ -812,37 +946,95 @@ func resourceMongoDBAtlasAdvancedClusterDelete(ctx context.Context, d *schema.Re
 	// Wait, catching any errors
 	_, err = stateConf.WaitForStateContext(ctx)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf(errorClusterAdvancedDelete, clusterName, err))
+		return append(diags, diag.FromErr(fmt.Errorf(errorClusterAdvancedDelete, clusterName, err))...)
+	}
+
+	return diags
+}
+
+// takeAdvancedClusterFinalSnapshot takes an on-demand snapshot of the cluster and waits for it to complete.
+func takeAdvancedClusterFinalSnapshot(ctx context.Context, connV2 *admin.APIClient, projectID, clusterName string,
+	tfMap map[string]interface{}, timeout time.Duration) (string, error) {
+	description := tfMap["description"].(string)
+	if description == "" {
+		description = fmt.Sprintf("Final snapshot of cluster %s", clusterName)
+	}
+
+	snapshot, _, err := connV2.CloudBackupsApi.TakeSnapshot(ctx, projectID, clusterName, &admin.DiskBackupOnDemandSnapshotRequest{
+		Description:     admin.PtrString(description),
+		RetentionInDays: admin.PtrInt(tfMap["retention_in_days"].(int)),
+	}).Execute()
+	if err != nil {
+		return "", err
+	}
+
+	log.Printf("[INFO] Waiting for the final snapshot %s of MongoDB ClusterAdvanced %s", snapshot.GetId(), clusterName)
+
+	stateConf := &retry.StateChangeConf{
+		Pending:    []string{"queued", "inProgress"},
+		Target:     []string{"completed"},
+		Refresh:    resourceCloudBackupSnapshotRefreshFunc(ctx, connV2, projectID, clusterName, snapshot.GetId()),
+		Timeout:    timeout,
+		MinTimeout: 30 * time.Second,
+	}
+
+	// Wait, catching any errors
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return "", err
+	}
+
+	return snapshot.GetId(), nil
+}
+
+// resourceAdvancedClusterFinalSnapshotCustomizeDiff rejects a final_snapshot that couldn't be taken or
+// wouldn't be kept after the deletion of the cluster.
+func resourceAdvancedClusterFinalSnapshotCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return nil
+	}
+
+	if finalSnapshot := rawConfig.GetAttr("final_snapshot"); finalSnapshot.IsNull() || !finalSnapshot.IsKnown() || finalSnapshot.LengthInt() == 0 {
+		return nil
+	}
+
+	// Atlas can only keep all the snapshots of a deleted cluster, retain_backups_enabled must be set explicitly.
+	if v := rawConfig.GetAttr("retain_backups_enabled"); v.IsKnown() && (v.IsNull() || v.False()) {
+		return fmt.Errorf("`final_snapshot` requires `retain_backups_enabled` to be true, the snapshot would be deleted with the cluster")
+	}
+
+	if v := rawConfig.GetAttr("backup_enabled"); v.IsKnown() && !v.IsNull() && v.False() {
+		return fmt.Errorf("`final_snapshot` requires `backup_enabled` to be true")
 	}
 
 	return nil
 }
 
 func resourceMongoDBAtlasAdvancedClusterImportState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 
 	projectID, name, err := splitSClusterAdvancedImportID(d.Id())
 	if err != nil {
 		return nil, err
 	}
 
-	u, _, err := conn.AdvancedClusters.Get(ctx, *projectID, *name)
+	u, _, _, err := getAdvancedCluster(ctx, connV2, *projectID, *name, false)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't import cluster %s in project %s, error: %s", *name, *projectID, err)
 	}
 
-	if err := d.Set("project_id", u.GroupID); err != nil {
-		log.Printf(errorClusterAdvancedSetting, "project_id", u.ID, err)
+	if err := d.Set("project_id", u.GetGroupId()); err != nil {
+		log.Printf(errorClusterAdvancedSetting, "project_id", u.GetId(), err)
 	}
 
-	if err := d.Set("name", u.Name); err != nil {
-		log.Printf(errorClusterAdvancedSetting, "name", u.ID, err)
+	if err := d.Set("name", u.GetName()); err != nil {
+		log.Printf(errorClusterAdvancedSetting, "name", u.GetId(), err)
 	}
 
 	d.SetId(encodeStateID(map[string]string{
-		"cluster_id":   u.ID,
+		"cluster_id":   u.GetId(),
 		"project_id":   *projectID,
-		"cluster_name": u.Name,
+		"cluster_name": u.GetName(),
 	}))
 
 	return []*schema.ResourceData{d}, nil
@@ -863,26 +1055,26 @@ func splitSClusterAdvancedImportID(id string) (projectID, clusterName *string, e
 	return
 }
 
-func expandAdvancedReplicationSpec(tfMap map[string]interface{}) *matlas.AdvancedReplicationSpec {
+func expandAdvancedReplicationSpec(tfMap map[string]interface{}) *admin.ReplicationSpec {
 	if tfMap == nil {
 		return nil
 	}
 
-	apiObject := &matlas.AdvancedReplicationSpec{
-		NumShards:     tfMap["num_shards"].(int),
-		ZoneName:      tfMap["zone_name"].(string),
+	apiObject := &admin.ReplicationSpec{
+		NumShards:     admin.PtrInt(tfMap["num_shards"].(int)),
+		ZoneName:      stringPtrOrNil(tfMap["zone_name"].(string)),
 		RegionConfigs: expandRegionConfigs(tfMap["region_configs"].([]interface{})),
 	}
 
 	return apiObject
 }
 
-func expandAdvancedReplicationSpecs(tfList []interface{}) []*matlas.AdvancedReplicationSpec {
+func expandAdvancedReplicationSpecs(tfList []interface{}) []admin.ReplicationSpec {
 	if len(tfList) == 0 {
 		return nil
 	}
 
-	var apiObjects []*matlas.AdvancedReplicationSpec
+	var apiObjects []admin.ReplicationSpec
 
 	for _, tfMapRaw := range tfList {
 		tfMap, ok := tfMapRaw.(map[string]interface{})
@@ -893,29 +1085,29 @@ func expandAdvancedReplicationSpecs(tfList []interface{}) []*matlas.AdvancedRepl
 
 		apiObject := expandAdvancedReplicationSpec(tfMap)
 
-		apiObjects = append(apiObjects, apiObject)
+		apiObjects = append(apiObjects, *apiObject)
 	}
 
 	return apiObjects
 }
 
-func expandRegionConfig(tfMap map[string]interface{}) *matlas.AdvancedRegionConfig {
+func expandRegionConfig(tfMap map[string]interface{}) *admin.CloudRegionConfig {
 	if tfMap == nil {
 		return nil
 	}
 
 	providerName := tfMap["provider_name"].(string)
-	apiObject := &matlas.AdvancedRegionConfig{
-		Priority:     pointy.Int(cast.ToInt(tfMap["priority"])),
-		ProviderName: providerName,
-		RegionName:   tfMap["region_name"].(string),
+	apiObject := &admin.CloudRegionConfig{
+		Priority:     admin.PtrInt(cast.ToInt(tfMap["priority"])),
+		ProviderName: admin.PtrString(providerName),
+		RegionName:   admin.PtrString(tfMap["region_name"].(string)),
 	}
 
 	if v, ok := tfMap["analytics_specs"]; ok && len(v.([]interface{})) > 0 {
 		apiObject.AnalyticsSpecs = expandRegionConfigSpec(v.([]interface{}), providerName)
 	}
 	if v, ok := tfMap["electable_specs"]; ok && len(v.([]interface{})) > 0 {
-		apiObject.ElectableSpecs = expandRegionConfigSpec(v.([]interface{}), providerName)
+		apiObject.ElectableSpecs = hardwareSpecFromDedicated(expandRegionConfigSpec(v.([]interface{}), providerName))
 	}
 	if v, ok := tfMap["read_only_specs"]; ok && len(v.([]interface{})) > 0 {
 		apiObject.ReadOnlySpecs = expandRegionConfigSpec(v.([]interface{}), providerName)
@@ -927,18 +1119,18 @@ func expandRegionConfig(tfMap map[string]interface{}) *matlas.AdvancedRegionConf
 		apiObject.AnalyticsAutoScaling = expandRegionConfigAutoScaling(v.([]interface{}))
 	}
 	if v, ok := tfMap["backing_provider_name"]; ok {
-		apiObject.BackingProviderName = v.(string)
+		apiObject.BackingProviderName = stringPtrOrNil(v.(string))
 	}
 
 	return apiObject
 }
 
-func expandRegionConfigs(tfList []interface{}) []*matlas.AdvancedRegionConfig {
+func expandRegionConfigs(tfList []interface{}) []admin.CloudRegionConfig {
 	if len(tfList) == 0 {
 		return nil
 	}
 
-	var apiObjects []*matlas.AdvancedRegionConfig
+	var apiObjects []admin.CloudRegionConfig
 
 	for _, tfMapRaw := range tfList {
 		tfMap, ok := tfMapRaw.(map[string]interface{})
@@ -949,69 +1141,95 @@ func expandRegionConfigs(tfList []interface{}) []*matlas.AdvancedRegionConfig {
 
 		apiObject := expandRegionConfig(tfMap)
 
-		apiObjects = append(apiObjects, apiObject)
+		apiObjects = append(apiObjects, *apiObject)
 	}
 
 	return apiObjects
 }
 
-func expandRegionConfigSpec(tfList []interface{}, providerName string) *matlas.Specs {
+func expandRegionConfigSpec(tfList []interface{}, providerName string) *admin.DedicatedHardwareSpec {
 	if tfList == nil && len(tfList) > 0 {
 		return nil
 	}
 
 	tfMap, _ := tfList[0].(map[string]interface{})
 
-	apiObject := &matlas.Specs{}
+	apiObject := &admin.DedicatedHardwareSpec{}
 
 	if providerName == "AWS" {
 		if v, ok := tfMap["disk_iops"]; ok && v.(int) > 0 {
-			apiObject.DiskIOPS = pointy.Int64(cast.ToInt64(v.(int)))
+			apiObject.DiskIOPS = admin.PtrInt(v.(int))
 		}
 		if v, ok := tfMap["ebs_volume_type"]; ok {
-			apiObject.EbsVolumeType = v.(string)
+			apiObject.EbsVolumeType = stringPtrOrNil(v.(string))
 		}
 	}
 	if v, ok := tfMap["instance_size"]; ok {
-		apiObject.InstanceSize = v.(string)
+		apiObject.InstanceSize = stringPtrOrNil(v.(string))
 	}
 	if v, ok := tfMap["node_count"]; ok {
-		apiObject.NodeCount = pointy.Int(v.(int))
+		apiObject.NodeCount = admin.PtrInt(v.(int))
 	}
 
 	return apiObject
 }
 
-func expandRegionConfigAutoScaling(tfList []interface{}) *matlas.AdvancedAutoScaling {
+// hardwareSpecFromDedicated and dedicatedFromHardwareSpec convert between the models used by the Atlas SDK
+// for the electable nodes and for the analytics and read-only nodes, which only differ by their name.
+func hardwareSpecFromDedicated(apiObject *admin.DedicatedHardwareSpec) *admin.HardwareSpec {
+	if apiObject == nil {
+		return nil
+	}
+
+	return &admin.HardwareSpec{
+		DiskIOPS:      apiObject.DiskIOPS,
+		EbsVolumeType: apiObject.EbsVolumeType,
+		InstanceSize:  apiObject.InstanceSize,
+		NodeCount:     apiObject.NodeCount,
+	}
+}
+
+func dedicatedFromHardwareSpec(apiObject *admin.HardwareSpec) *admin.DedicatedHardwareSpec {
+	if apiObject == nil {
+		return nil
+	}
+
+	return &admin.DedicatedHardwareSpec{
+		DiskIOPS:      apiObject.DiskIOPS,
+		EbsVolumeType: apiObject.EbsVolumeType,
+		InstanceSize:  apiObject.InstanceSize,
+		NodeCount:     apiObject.NodeCount,
+	}
+}
+
+func expandRegionConfigAutoScaling(tfList []interface{}) *admin.AdvancedAutoScalingSettings {
 	if tfList == nil && len(tfList) > 0 {
 		return nil
 	}
 
 	tfMap, _ := tfList[0].(map[string]interface{})
 
-	advancedAutoScaling := &matlas.AdvancedAutoScaling{}
-	diskGB := &matlas.DiskGB{}
-	compute := &matlas.Compute{}
+	advancedAutoScaling := &admin.AdvancedAutoScalingSettings{}
+	diskGB := &admin.DiskGBAutoScaling{}
+	compute := &admin.AdvancedComputeAutoScaling{}
 
 	if v, ok := tfMap["disk_gb_enabled"]; ok {
-		diskGB.Enabled = pointy.Bool(v.(bool))
+		diskGB.Enabled = admin.PtrBool(v.(bool))
 	}
 	if v, ok := tfMap["compute_enabled"]; ok {
-		compute.Enabled = pointy.Bool(v.(bool))
+		compute.Enabled = admin.PtrBool(v.(bool))
 	}
 	if v, ok := tfMap["compute_scale_down_enabled"]; ok {
-		compute.ScaleDownEnabled = pointy.Bool(v.(bool))
+		compute.ScaleDownEnabled = admin.PtrBool(v.(bool))
 	}
 	if v, ok := tfMap["compute_min_instance_size"]; ok {
-		value := compute.ScaleDownEnabled
-		if *value {
-			compute.MinInstanceSize = v.(string)
+		if compute.GetScaleDownEnabled() {
+			compute.MinInstanceSize = stringPtrOrNil(v.(string))
 		}
 	}
 	if v, ok := tfMap["compute_max_instance_size"]; ok {
-		value := compute.Enabled
-		if *value {
-			compute.MaxInstanceSize = v.(string)
+		if compute.GetEnabled() {
+			compute.MaxInstanceSize = stringPtrOrNil(v.(string))
 		}
 	}
 
@@ -1021,49 +1239,98 @@ func expandRegionConfigAutoScaling(tfList []interface{}) *matlas.AdvancedAutoSca
 	return advancedAutoScaling
 }
 
-func flattenAdvancedReplicationSpec(ctx context.Context, apiObject *matlas.AdvancedReplicationSpec, tfMapObject map[string]interface{},
-	d *schema.ResourceData, conn *matlas.Client) (map[string]interface{}, error) {
+func expandAdvancedBiConnectorConfig(d *schema.ResourceData) (*admin.BiConnector, error) {
+	biConnector, err := expandBiConnectorConfig(d)
+	if err != nil {
+		return nil, err
+	}
+
+	return &admin.BiConnector{
+		Enabled:        biConnector.Enabled,
+		ReadPreference: stringPtrOrNil(biConnector.ReadPreference),
+	}, nil
+}
+
+func flattenAdvancedBiConnectorConfig(biConnector *admin.BiConnector) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"enabled":         biConnector.GetEnabled(),
+			"read_preference": biConnector.GetReadPreference(),
+		},
+	}
+}
+
+func flattenAdvancedConnectionStrings(connectionStrings *admin.ClusterConnectionStrings) []map[string]interface{} {
+	connections := make([]map[string]interface{}, 0)
+
+	privateEndpoints := make([]map[string]interface{}, 0)
+	for _, endpoint := range connectionStrings.GetPrivateEndpoint() {
+		endpoints := make([]map[string]interface{}, 0)
+		for _, v := range endpoint.Endpoints {
+			endpoints = append(endpoints, map[string]interface{}{
+				"region":        v.GetRegion(),
+				"provider_name": v.GetProviderName(),
+				"endpoint_id":   v.GetEndpointId(),
+			})
+		}
+
+		privateEndpoints = append(privateEndpoints, map[string]interface{}{
+			"connection_string":                     endpoint.GetConnectionString(),
+			"srv_connection_string":                 endpoint.GetSrvConnectionString(),
+			"srv_shard_optimized_connection_string": endpoint.GetSrvShardOptimizedConnectionString(),
+			"endpoints":                             endpoints,
+			"type":                                  endpoint.GetType(),
+		})
+	}
+
+	connections = append(connections, map[string]interface{}{
+		"standard":             connectionStrings.GetStandard(),
+		"standard_srv":         connectionStrings.GetStandardSrv(),
+		"aws_private_link":     connectionStrings.GetAwsPrivateLink(),
+		"aws_private_link_srv": connectionStrings.GetAwsPrivateLinkSrv(),
+		"private":              connectionStrings.GetPrivate(),
+		"private_srv":          connectionStrings.GetPrivateSrv(),
+		"private_endpoint":     privateEndpoints,
+	})
+
+	return connections
+}
+
+func flattenAdvancedReplicationSpec(ctx context.Context, apiObject *admin.ReplicationSpec, tfMapObject map[string]interface{},
+	d *schema.ResourceData, connV2 *admin.APIClient) (map[string]interface{}, error) {
 	if apiObject == nil {
 		return nil, nil
 	}
 
 	tfMap := map[string]interface{}{}
-	tfMap["num_shards"] = apiObject.NumShards
-	tfMap["id"] = apiObject.ID
+	tfMap["num_shards"] = apiObject.GetNumShards()
+	tfMap["id"] = apiObject.GetId()
 	if tfMapObject != nil {
-		object, containerIds, err := flattenAdvancedReplicationSpecRegionConfigs(ctx, apiObject.RegionConfigs, tfMapObject["region_configs"].([]interface{}), d, conn)
+		object, containerIds, err := flattenAdvancedReplicationSpecRegionConfigs(ctx, apiObject.RegionConfigs, tfMapObject["region_configs"].([]interface{}), d, connV2)
 		if err != nil {
 			return nil, err
 		}
 		tfMap["region_configs"] = object
 		tfMap["container_id"] = containerIds
 	} else {
-		object, containerIds, err := flattenAdvancedReplicationSpecRegionConfigs(ctx, apiObject.RegionConfigs, nil, d, conn)
+		object, containerIds, err := flattenAdvancedReplicationSpecRegionConfigs(ctx, apiObject.RegionConfigs, nil, d, connV2)
 		if err != nil {
 			return nil, err
 		}
 		tfMap["region_configs"] = object
 		tfMap["container_id"] = containerIds
 	}
-	tfMap["zone_name"] = apiObject.ZoneName
+	tfMap["zone_name"] = apiObject.GetZoneName()
 
 	return tfMap, nil
 }
 
-func doesAdvancedReplicationSpecMatchAPI(tfObject map[string]interface{}, apiObject *matlas.AdvancedReplicationSpec) bool {
-	return tfObject["id"] == apiObject.ID || (tfObject["id"] == nil && tfObject["zone_name"] == apiObject.ZoneName)
+func doesAdvancedReplicationSpecMatchAPI(tfObject map[string]interface{}, apiObject *admin.ReplicationSpec) bool {
+	return tfObject["id"] == apiObject.GetId() || (tfObject["id"] == nil && tfObject["zone_name"] == apiObject.GetZoneName())
 }
 
-func flattenAdvancedReplicationSpecs(ctx context.Context, rawAPIObjects []*matlas.AdvancedReplicationSpec, tfMapObjects []interface{},
-	d *schema.ResourceData, conn *matlas.Client) ([]map[string]interface{}, error) {
-	var apiObjects []*matlas.AdvancedReplicationSpec
-
-	for _, advancedReplicationSpec := range rawAPIObjects {
-		if advancedReplicationSpec != nil {
-			apiObjects = append(apiObjects, advancedReplicationSpec)
-		}
-	}
-
+func flattenAdvancedReplicationSpecs(ctx context.Context, apiObjects []admin.ReplicationSpec, tfMapObjects []interface{},
+	d *schema.ResourceData, connV2 *admin.APIClient) ([]map[string]interface{}, error) {
 	if len(apiObjects) == 0 {
 		return nil, nil
 	}
@@ -1083,11 +1350,11 @@ func flattenAdvancedReplicationSpecs(ctx context.Context, rawAPIObjects []*matla
 				continue
 			}
 
-			if !doesAdvancedReplicationSpecMatchAPI(tfMapObject, apiObjects[j]) {
+			if !doesAdvancedReplicationSpecMatchAPI(tfMapObject, &apiObjects[j]) {
 				continue
 			}
 
-			advancedReplicationSpec, err := flattenAdvancedReplicationSpec(ctx, apiObjects[j], tfMapObject, d, conn)
+			advancedReplicationSpec, err := flattenAdvancedReplicationSpec(ctx, &apiObjects[j], tfMapObject, d, connV2)
 
 			if err != nil {
 				return nil, err
@@ -1111,7 +1378,7 @@ func flattenAdvancedReplicationSpecs(ctx context.Context, rawAPIObjects []*matla
 		}
 
 		j := slices.IndexFunc(wasAPIObjectUsed, func(isUsed bool) bool { return !isUsed })
-		advancedReplicationSpec, err := flattenAdvancedReplicationSpec(ctx, apiObjects[j], tfMapObject, d, conn)
+		advancedReplicationSpec, err := flattenAdvancedReplicationSpec(ctx, &apiObjects[j], tfMapObject, d, connV2)
 
 		if err != nil {
 			return nil, err
@@ -1124,21 +1391,24 @@ func flattenAdvancedReplicationSpecs(ctx context.Context, rawAPIObjects []*matla
 	return tfList, nil
 }
 
-func flattenAdvancedReplicationSpecRegionConfig(apiObject *matlas.AdvancedRegionConfig, tfMapObject map[string]interface{}) map[string]interface{} {
+func flattenAdvancedReplicationSpecRegionConfig(apiObject *admin.CloudRegionConfig, tfMapObject map[string]interface{}) map[string]interface{} {
 	if apiObject == nil {
 		return nil
 	}
 
+	providerName := apiObject.GetProviderName()
+	electableSpecs := dedicatedFromHardwareSpec(apiObject.ElectableSpecs)
+
 	tfMap := map[string]interface{}{}
 	if tfMapObject != nil {
 		if v, ok := tfMapObject["analytics_specs"]; ok && len(v.([]interface{})) > 0 {
-			tfMap["analytics_specs"] = flattenAdvancedReplicationSpecRegionConfigSpec(apiObject.AnalyticsSpecs, apiObject.ProviderName, tfMapObject["analytics_specs"].([]interface{}))
+			tfMap["analytics_specs"] = flattenAdvancedReplicationSpecRegionConfigSpec(apiObject.AnalyticsSpecs, providerName, tfMapObject["analytics_specs"].([]interface{}))
 		}
 		if v, ok := tfMapObject["electable_specs"]; ok && len(v.([]interface{})) > 0 {
-			tfMap["electable_specs"] = flattenAdvancedReplicationSpecRegionConfigSpec(apiObject.ElectableSpecs, apiObject.ProviderName, tfMapObject["electable_specs"].([]interface{}))
+			tfMap["electable_specs"] = flattenAdvancedReplicationSpecRegionConfigSpec(electableSpecs, providerName, tfMapObject["electable_specs"].([]interface{}))
 		}
 		if v, ok := tfMapObject["read_only_specs"]; ok && len(v.([]interface{})) > 0 {
-			tfMap["read_only_specs"] = flattenAdvancedReplicationSpecRegionConfigSpec(apiObject.ReadOnlySpecs, apiObject.ProviderName, tfMapObject["read_only_specs"].([]interface{}))
+			tfMap["read_only_specs"] = flattenAdvancedReplicationSpecRegionConfigSpec(apiObject.ReadOnlySpecs, providerName, tfMapObject["read_only_specs"].([]interface{}))
 		}
 		if v, ok := tfMapObject["auto_scaling"]; ok && len(v.([]interface{})) > 0 {
 			tfMap["auto_scaling"] = flattenAdvancedReplicationSpecAutoScaling(apiObject.AutoScaling)
@@ -1147,23 +1417,23 @@ func flattenAdvancedReplicationSpecRegionConfig(apiObject *matlas.AdvancedRegion
 			tfMap["analytics_auto_scaling"] = flattenAdvancedReplicationSpecAutoScaling(apiObject.AnalyticsAutoScaling)
 		}
 	} else {
-		tfMap["analytics_specs"] = flattenAdvancedReplicationSpecRegionConfigSpec(apiObject.AnalyticsSpecs, apiObject.ProviderName, nil)
-		tfMap["electable_specs"] = flattenAdvancedReplicationSpecRegionConfigSpec(apiObject.ElectableSpecs, apiObject.ProviderName, nil)
-		tfMap["read_only_specs"] = flattenAdvancedReplicationSpecRegionConfigSpec(apiObject.ReadOnlySpecs, apiObject.ProviderName, nil)
+		tfMap["analytics_specs"] = flattenAdvancedReplicationSpecRegionConfigSpec(apiObject.AnalyticsSpecs, providerName, nil)
+		tfMap["electable_specs"] = flattenAdvancedReplicationSpecRegionConfigSpec(electableSpecs, providerName, nil)
+		tfMap["read_only_specs"] = flattenAdvancedReplicationSpecRegionConfigSpec(apiObject.ReadOnlySpecs, providerName, nil)
 		tfMap["auto_scaling"] = flattenAdvancedReplicationSpecAutoScaling(apiObject.AutoScaling)
 		tfMap["analytics_auto_scaling"] = flattenAdvancedReplicationSpecAutoScaling(apiObject.AnalyticsAutoScaling)
 	}
 
-	tfMap["region_name"] = apiObject.RegionName
-	tfMap["provider_name"] = apiObject.ProviderName
-	tfMap["backing_provider_name"] = apiObject.BackingProviderName
-	tfMap["priority"] = apiObject.Priority
+	tfMap["region_name"] = apiObject.GetRegionName()
+	tfMap["provider_name"] = providerName
+	tfMap["backing_provider_name"] = apiObject.GetBackingProviderName()
+	tfMap["priority"] = apiObject.GetPriority()
 
 	return tfMap
 }
 
-func flattenAdvancedReplicationSpecRegionConfigs(ctx context.Context, apiObjects []*matlas.AdvancedRegionConfig, tfMapObjects []interface{},
-	d *schema.ResourceData, conn *matlas.Client) (tfResult []map[string]interface{}, containersIDs map[string]string, err error) {
+func flattenAdvancedReplicationSpecRegionConfigs(ctx context.Context, apiObjects []admin.CloudRegionConfig, tfMapObjects []interface{},
+	d *schema.ResourceData, connV2 *admin.APIClient) (tfResult []map[string]interface{}, containersIDs map[string]string, err error) {
 	if len(apiObjects) == 0 {
 		return nil, nil, nil
 	}
@@ -1171,10 +1441,8 @@ func flattenAdvancedReplicationSpecRegionConfigs(ctx context.Context, apiObjects
 	var tfList []map[string]interface{}
 	containerIds := make(map[string]string)
 
-	for i, apiObject := range apiObjects {
-		if apiObject == nil {
-			continue
-		}
+	for i := range apiObjects {
+		apiObject := &apiObjects[i]
 
 		if len(tfMapObjects) > i {
 			tfMapObject := tfMapObjects[i].(map[string]interface{})
@@ -1183,15 +1451,17 @@ func flattenAdvancedReplicationSpecRegionConfigs(ctx context.Context, apiObjects
 			tfList = append(tfList, flattenAdvancedReplicationSpecRegionConfig(apiObject, nil))
 		}
 
-		if apiObject.ProviderName != "TENANT" {
-			containers, _, err := conn.Containers.List(ctx, d.Get("project_id").(string),
-				&matlas.ContainersListOptions{ProviderName: apiObject.ProviderName})
+		if apiObject.GetProviderName() != "TENANT" {
+			containers, _, err := connV2.NetworkPeeringApi.ListPeeringContainerByCloudProvider(ctx, d.Get("project_id").(string)).
+				ProviderName(apiObject.GetProviderName()).
+				ItemsPerPage(listItemsPerPage).
+				Execute()
 			if err != nil {
 				return nil, nil, err
 			}
-			if result := getAdvancedClusterContainerID(containers, apiObject); result != "" {
+			if result := getAdvancedClusterContainerID(containers.Results, apiObject); result != "" {
 				// Will print as "providerName:regionName" = "containerId" in terraform show
-				containerIds[fmt.Sprintf("%s:%s", apiObject.ProviderName, apiObject.RegionName)] = result
+				containerIds[fmt.Sprintf("%s:%s", apiObject.GetProviderName(), apiObject.GetRegionName())] = result
 			}
 		}
 	}
@@ -1199,7 +1469,7 @@ func flattenAdvancedReplicationSpecRegionConfigs(ctx context.Context, apiObjects
 	return tfList, containerIds, nil
 }
 
-func flattenAdvancedReplicationSpecRegionConfigSpec(apiObject *matlas.Specs, providerName string, tfMapObjects []interface{}) []map[string]interface{} {
+func flattenAdvancedReplicationSpecRegionConfigSpec(apiObject *admin.DedicatedHardwareSpec, providerName string, tfMapObjects []interface{}) []map[string]interface{} {
 	if apiObject == nil {
 		return nil
 	}
@@ -1211,34 +1481,34 @@ func flattenAdvancedReplicationSpecRegionConfigSpec(apiObject *matlas.Specs, pro
 		tfMapObject := tfMapObjects[0].(map[string]interface{})
 
 		if providerName == "AWS" {
-			if cast.ToInt64(apiObject.DiskIOPS) > 0 {
+			if apiObject.GetDiskIOPS() > 0 {
 				if v, ok := tfMapObject["disk_iops"]; ok && v.(int) > 0 {
-					tfMap["disk_iops"] = apiObject.DiskIOPS
+					tfMap["disk_iops"] = apiObject.GetDiskIOPS()
 				}
 			}
 			if v, ok := tfMapObject["ebs_volume_type"]; ok && v.(string) != "" {
-				tfMap["ebs_volume_type"] = apiObject.EbsVolumeType
+				tfMap["ebs_volume_type"] = apiObject.GetEbsVolumeType()
 			}
 		}
 		if _, ok := tfMapObject["node_count"]; ok {
-			tfMap["node_count"] = apiObject.NodeCount
+			tfMap["node_count"] = apiObject.GetNodeCount()
 		}
 		if v, ok := tfMapObject["instance_size"]; ok && v.(string) != "" {
-			tfMap["instance_size"] = apiObject.InstanceSize
+			tfMap["instance_size"] = apiObject.GetInstanceSize()
 			tfList = append(tfList, tfMap)
 		}
 	} else {
-		tfMap["disk_iops"] = apiObject.DiskIOPS
-		tfMap["ebs_volume_type"] = apiObject.EbsVolumeType
-		tfMap["node_count"] = apiObject.NodeCount
-		tfMap["instance_size"] = apiObject.InstanceSize
+		tfMap["disk_iops"] = apiObject.GetDiskIOPS()
+		tfMap["ebs_volume_type"] = apiObject.GetEbsVolumeType()
+		tfMap["node_count"] = apiObject.GetNodeCount()
+		tfMap["instance_size"] = apiObject.GetInstanceSize()
 		tfList = append(tfList, tfMap)
 	}
 
 	return tfList
 }
 
-func flattenAdvancedReplicationSpecAutoScaling(apiObject *matlas.AdvancedAutoScaling) []map[string]interface{} {
+func flattenAdvancedReplicationSpecAutoScaling(apiObject *admin.AdvancedAutoScalingSettings) []map[string]interface{} {
 	if apiObject == nil {
 		return nil
 	}
@@ -1247,13 +1517,13 @@ func flattenAdvancedReplicationSpecAutoScaling(apiObject *matlas.AdvancedAutoSca
 
 	tfMap := map[string]interface{}{}
 	if apiObject.DiskGB != nil {
-		tfMap["disk_gb_enabled"] = apiObject.DiskGB.Enabled
+		tfMap["disk_gb_enabled"] = apiObject.DiskGB.GetEnabled()
 	}
 	if apiObject.Compute != nil {
-		tfMap["compute_enabled"] = apiObject.Compute.Enabled
-		tfMap["compute_scale_down_enabled"] = apiObject.Compute.ScaleDownEnabled
-		tfMap["compute_min_instance_size"] = apiObject.Compute.MinInstanceSize
-		tfMap["compute_max_instance_size"] = apiObject.Compute.MaxInstanceSize
+		tfMap["compute_enabled"] = apiObject.Compute.GetEnabled()
+		tfMap["compute_scale_down_enabled"] = apiObject.Compute.GetScaleDownEnabled()
+		tfMap["compute_min_instance_size"] = apiObject.Compute.GetMinInstanceSize()
+		tfMap["compute_max_instance_size"] = apiObject.Compute.GetMaxInstanceSize()
 	}
 
 	tfList = append(tfList, tfMap)
@@ -1261,9 +1531,9 @@ func flattenAdvancedReplicationSpecAutoScaling(apiObject *matlas.AdvancedAutoSca
 	return tfList
 }
 
-func resourceClusterAdvancedRefreshFunc(ctx context.Context, name, projectID string, client *matlas.Client) retry.StateRefreshFunc {
+func resourceClusterAdvancedRefreshFunc(ctx context.Context, name, projectID string, connV2 *admin.APIClient, shardedIndependently bool) retry.StateRefreshFunc {
 	return func() (interface{}, string, error) {
-		c, resp, err := client.AdvancedClusters.Get(ctx, projectID, name)
+		c, _, resp, err := getAdvancedCluster(ctx, connV2, projectID, name, shardedIndependently)
 
 		if err != nil && strings.Contains(err.Error(), "reset by peer") {
 			return nil, "REPEATING", nil
@@ -1283,11 +1553,11 @@ func resourceClusterAdvancedRefreshFunc(ctx context.Context, name, projectID str
 			return nil, "", err
 		}
 
-		if c.StateName != "" {
-			log.Printf("[DEBUG] status for MongoDB cluster: %s: %s", name, c.StateName)
+		if c.GetStateName() != "" {
+			log.Printf("[DEBUG] status for MongoDB cluster: %s: %s", name, c.GetStateName())
 		}
 
-		return c, c.StateName, nil
+		return c, c.GetStateName(), nil
 	}
 }
 
@@ -1332,7 +1602,7 @@ func replicationSpecsHashSet(v interface{}) int {
 	return schema.HashString(buf.String())
 }
 
-func getUpgradeRequest(d *schema.ResourceData) *matlas.Cluster {
+func getUpgradeRequest(d resourceChanges) *matlas.Cluster {
 	if !d.HasChange("replication_specs") {
 		return nil
 	}
@@ -1347,29 +1617,29 @@ func getUpgradeRequest(d *schema.ResourceData) *matlas.Cluster {
 
 	currentRegion := currentSpecs[0].RegionConfigs[0]
 	updatedRegion := updatedSpecs[0].RegionConfigs[0]
-	currentSize := currentRegion.ElectableSpecs.InstanceSize
+	currentSize := currentRegion.ElectableSpecs.GetInstanceSize()
 
-	if currentRegion.ElectableSpecs.InstanceSize == updatedRegion.ElectableSpecs.InstanceSize || !isSharedTier(currentSize) {
+	if currentSize == updatedRegion.ElectableSpecs.GetInstanceSize() || !isSharedTier(currentSize) {
 		return nil
 	}
 
 	return &matlas.Cluster{
 		ProviderSettings: &matlas.ProviderSettings{
-			ProviderName:     updatedRegion.ProviderName,
-			InstanceSizeName: updatedRegion.ElectableSpecs.InstanceSize,
-			RegionName:       updatedRegion.RegionName,
+			ProviderName:     updatedRegion.GetProviderName(),
+			InstanceSizeName: updatedRegion.ElectableSpecs.GetInstanceSize(),
+			RegionName:       updatedRegion.GetRegionName(),
 		},
 	}
 }
 
 func updateAdvancedCluster(
 	ctx context.Context,
-	conn *matlas.Client,
-	request *matlas.AdvancedCluster,
+	connV2 *admin.APIClient,
+	request *admin.AdvancedClusterDescription,
 	projectID, name string,
 	timeout time.Duration,
-) (*matlas.AdvancedCluster, *matlas.Response, error) {
-	cluster, resp, err := conn.AdvancedClusters.Update(ctx, projectID, name, request)
+) (*admin.AdvancedClusterDescription, *http.Response, error) {
+	cluster, resp, err := connV2.MultiCloudClustersApi.UpdateCluster(ctx, projectID, name, request).Execute()
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1377,10 +1647,10 @@ func updateAdvancedCluster(
 	stateConf := &retry.StateChangeConf{
 		Pending:    []string{"CREATING", "UPDATING", "REPAIRING"},
 		Target:     []string{"IDLE"},
-		Refresh:    resourceClusterAdvancedRefreshFunc(ctx, name, projectID, conn),
+		Refresh:    resourceClusterAdvancedRefreshFunc(ctx, name, projectID, connV2, false),
 		Timeout:    timeout,
 		MinTimeout: 30 * time.Second,
-		Delay:      1 * time.Minute,
+		Delay:      clusterUpdateDelay,
 	}
 
 	// Wait, catching any errors
@@ -1392,17 +1662,17 @@ func updateAdvancedCluster(
 	return cluster, resp, nil
 }
 
-func getAdvancedClusterContainerID(containers []matlas.Container, cluster *matlas.AdvancedRegionConfig) string {
+func getAdvancedClusterContainerID(containers []admin.CloudProviderContainer, cluster *admin.CloudRegionConfig) string {
 	if len(containers) != 0 {
 		for i := range containers {
-			if cluster.ProviderName == "GCP" {
-				return containers[i].ID
+			if cluster.GetProviderName() == "GCP" {
+				return containers[i].GetId()
 			}
 
-			if containers[i].ProviderName == cluster.ProviderName &&
-				containers[i].Region == cluster.RegionName || // For Azure
-				containers[i].RegionName == cluster.RegionName { // For AWS
-				return containers[i].ID
+			if containers[i].GetProviderName() == cluster.GetProviderName() &&
+				containers[i].GetRegion() == cluster.GetRegionName() || // For Azure
+				containers[i].GetRegionName() == cluster.GetRegionName() { // For AWS
+				return containers[i].GetId()
 			}
 		}
 	}
@@ -0,0 +1,197 @@
+package mongodbatlas
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceChanges is implemented by both schema.ResourceData and schema.ResourceDiff, so that a change
+// can be inspected the same way when it is planned and when it is applied.
+type resourceChanges interface {
+	HasChange(key string) bool
+	GetChange(key string) (interface{}, interface{})
+}
+
+type advancedClusterChangeImpact int
+
+const (
+	advancedClusterChangeInPlace advancedClusterChangeImpact = iota
+	advancedClusterChangeRollingRestart
+	advancedClusterChangeTierUpgrade
+	advancedClusterChangeProviderMigration
+)
+
+// advancedClusterChange is the change of an attribute of the cluster and what applying it does to the cluster.
+type advancedClusterChange struct {
+	attribute string
+	old       interface{}
+	new       interface{}
+	impact    advancedClusterChangeImpact
+}
+
+var advancedClusterChangeSummaries = map[advancedClusterChangeImpact]string{
+	advancedClusterChangeInPlace:           "Cluster %q is updated in place",
+	advancedClusterChangeRollingRestart:    "Cluster %q goes through a rolling restart",
+	advancedClusterChangeTierUpgrade:       "Cluster %q is upgraded from the shared tier",
+	advancedClusterChangeProviderMigration: "Cluster %q is migrated to another cloud provider",
+}
+
+var advancedClusterChangeDetails = map[advancedClusterChangeImpact]string{
+	advancedClusterChangeInPlace: "These changes are applied to the running cluster without restarting its nodes.",
+	advancedClusterChangeRollingRestart: "These changes restart the nodes of the cluster one at a time, " +
+		"which triggers elections and briefly interrupts the connections to the restarted nodes.",
+	advancedClusterChangeTierUpgrade: "The cluster is upgraded through the legacy upgrade API, " +
+		"the cluster is unavailable for several minutes while its data is moved to the new instances.",
+	advancedClusterChangeProviderMigration: "Atlas deploys new nodes on the new cloud provider and copies the data to them before " +
+		"removing the old nodes, which can take hours for large clusters and triggers elections when the primary moves.",
+}
+
+// resourceAdvancedClusterChangeWarnings adds a warning to the plan for each kind of impact the changes to
+// an existing cluster have, so that reviewers of the plan know what applying it does to the cluster.
+func resourceAdvancedClusterChangeWarnings(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	changes := advancedClusterChanges(d)
+	impacts := make(map[advancedClusterChangeImpact][]string)
+	for _, change := range changes {
+		impacts[change.impact] = append(impacts[change.impact],
+			fmt.Sprintf("%s: %s => %s", change.attribute, formatPlanValue(change.old, "(not set)"), formatPlanValue(change.new, "(known after apply)")))
+	}
+
+	for _, impact := range []advancedClusterChangeImpact{
+		advancedClusterChangeInPlace,
+		advancedClusterChangeRollingRestart,
+		advancedClusterChangeTierUpgrade,
+		advancedClusterChangeProviderMigration,
+	} {
+		if len(impacts[impact]) == 0 {
+			continue
+		}
+
+		addPlanWarning(ctx,
+			fmt.Sprintf(advancedClusterChangeSummaries[impact], d.Get("name").(string)),
+			fmt.Sprintf("%s\n\n%s", advancedClusterChangeDetails[impact], strings.Join(impacts[impact], "\n")))
+	}
+
+	return nil
+}
+
+// advancedClusterChanges classifies the changes to the topology, storage, version and configuration of
+// the cluster, following the way resourceMongoDBAtlasAdvancedClusterUpdateOrUpgrade applies them.
+func advancedClusterChanges(d resourceChanges) []advancedClusterChange {
+	var changes []advancedClusterChange
+
+	if d.HasChange("disk_size_gb") {
+		o, n := d.GetChange("disk_size_gb")
+		changes = append(changes, advancedClusterChange{"disk_size_gb", o, n, advancedClusterChangeInPlace})
+	}
+
+	if d.HasChange("mongo_db_major_version") {
+		o, n := d.GetChange("mongo_db_major_version")
+		changes = append(changes, advancedClusterChange{"mongo_db_major_version", o, n, advancedClusterChangeRollingRestart})
+	}
+
+	if d.HasChange("advanced_configuration") {
+		o, n := d.GetChange("advanced_configuration")
+		changes = append(changes, advancedClusterMapChanges("advanced_configuration.0", firstMap(o), firstMap(n), advancedClusterChangeInPlace)...)
+	}
+
+	if d.HasChange("replication_specs") {
+		o, n := d.GetChange("replication_specs")
+
+		if getUpgradeRequest(d) != nil {
+			changes = append(changes, advancedClusterRegionChanges(o.([]interface{}), n.([]interface{}), advancedClusterChangeTierUpgrade)...)
+		} else {
+			changes = append(changes, advancedClusterRegionChanges(o.([]interface{}), n.([]interface{}), advancedClusterChangeRollingRestart)...)
+		}
+	}
+
+	return changes
+}
+
+// advancedClusterRegionChanges returns the changes of provider and instance sizes of the regions present before
+// and after the change, resizeImpact being the impact of a change of instance size.
+func advancedClusterRegionChanges(oldSpecs, newSpecs []interface{}, resizeImpact advancedClusterChangeImpact) []advancedClusterChange {
+	var changes []advancedClusterChange
+
+	for i := 0; i < len(oldSpecs) && i < len(newSpecs); i++ {
+		oldRegions, _ := mapAt(oldSpecs, i)["region_configs"].([]interface{})
+		newRegions, _ := mapAt(newSpecs, i)["region_configs"].([]interface{})
+
+		for j := 0; j < len(oldRegions) && j < len(newRegions); j++ {
+			oldRegion := mapAt(oldRegions, j)
+			newRegion := mapAt(newRegions, j)
+			prefix := fmt.Sprintf("replication_specs.%d.region_configs.%d", i, j)
+
+			oldProvider, newProvider := oldRegion["provider_name"], newRegion["provider_name"]
+			if oldProvider != newProvider && resizeImpact != advancedClusterChangeTierUpgrade {
+				changes = append(changes, advancedClusterChange{prefix + ".provider_name", oldProvider, newProvider, advancedClusterChangeProviderMigration})
+				continue
+			}
+
+			for _, specs := range []string{"electable_specs", "analytics_specs", "read_only_specs"} {
+				oldSize, newSize := firstMap(oldRegion[specs])["instance_size"], firstMap(newRegion[specs])["instance_size"]
+				if oldSize != nil && newSize != nil && oldSize != newSize {
+					changes = append(changes, advancedClusterChange{fmt.Sprintf("%s.%s.0.instance_size", prefix, specs), oldSize, newSize, resizeImpact})
+				}
+
+				// the disk of each shard of a cluster sharded independently is resized in place, like the one of the cluster.
+				oldDisk, _ := firstMap(oldRegion[specs])["disk_size_gb"].(float64)
+				newDisk, _ := firstMap(newRegion[specs])["disk_size_gb"].(float64)
+				if oldDisk > 0 && newDisk > 0 && oldDisk != newDisk {
+					changes = append(changes, advancedClusterChange{fmt.Sprintf("%s.%s.0.disk_size_gb", prefix, specs), oldDisk, newDisk, advancedClusterChangeInPlace})
+				}
+			}
+		}
+	}
+
+	return changes
+}
+
+func advancedClusterMapChanges(prefix string, oldMap, newMap map[string]interface{}, impact advancedClusterChangeImpact) []advancedClusterChange {
+	keys := make([]string, 0, len(newMap))
+	for k := range newMap {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var changes []advancedClusterChange
+	for _, k := range keys {
+		if !reflect.DeepEqual(oldMap[k], newMap[k]) {
+			changes = append(changes, advancedClusterChange{fmt.Sprintf("%s.%s", prefix, k), oldMap[k], newMap[k], impact})
+		}
+	}
+
+	return changes
+}
+
+func firstMap(v interface{}) map[string]interface{} {
+	list, _ := v.([]interface{})
+
+	return mapAt(list, 0)
+}
+
+func mapAt(list []interface{}, i int) map[string]interface{} {
+	if i >= len(list) {
+		return nil
+	}
+
+	tfMap, _ := list[i].(map[string]interface{})
+
+	return tfMap
+}
+
+func formatPlanValue(v interface{}, empty string) string {
+	if v == nil || v == "" {
+		return empty
+	}
+
+	return fmt.Sprintf("%v", v)
+}
@@ -0,0 +1,170 @@
+package mongodbatlas
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+type testResourceChanges map[string][2]interface{}
+
+func (c testResourceChanges) HasChange(key string) bool {
+	v, ok := c[key]
+
+	return ok && !reflect.DeepEqual(v[0], v[1])
+}
+
+func (c testResourceChanges) GetChange(key string) (o, n interface{}) {
+	return c[key][0], c[key][1]
+}
+
+func TestAdvancedClusterChanges(t *testing.T) {
+	replicationSpecs := func(providerName, instanceSize string) []interface{} {
+		return []interface{}{
+			map[string]interface{}{
+				"num_shards": 1,
+				"zone_name":  "Zone 1",
+				"region_configs": []interface{}{
+					map[string]interface{}{
+						"provider_name": providerName,
+						"region_name":   "US_EAST_1",
+						"priority":      7,
+						"electable_specs": []interface{}{
+							map[string]interface{}{"instance_size": instanceSize, "node_count": 3},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	shardDisk := func(instanceSize string, diskSizeGB float64) []interface{} {
+		specs := replicationSpecs("AWS", instanceSize)
+		firstMap(mapAt(specs, 0)["region_configs"])["electable_specs"] = []interface{}{
+			map[string]interface{}{"instance_size": instanceSize, "node_count": 3, "disk_size_gb": diskSizeGB},
+		}
+		return specs
+	}
+
+	testCases := map[string]struct {
+		changes  testResourceChanges
+		expected []advancedClusterChange
+	}{
+		"no changes": {
+			changes: testResourceChanges{
+				"replication_specs": {replicationSpecs("AWS", "M10"), replicationSpecs("AWS", "M10")},
+			},
+		},
+		"disk size and advanced configuration": {
+			changes: testResourceChanges{
+				"disk_size_gb": {10.0, 20.0},
+				"advanced_configuration": {
+					[]interface{}{map[string]interface{}{"javascript_enabled": true, "oplog_size_mb": 1000}},
+					[]interface{}{map[string]interface{}{"javascript_enabled": true, "oplog_size_mb": 2000}},
+				},
+			},
+			expected: []advancedClusterChange{
+				{"disk_size_gb", 10.0, 20.0, advancedClusterChangeInPlace},
+				{"advanced_configuration.0.oplog_size_mb", 1000, 2000, advancedClusterChangeInPlace},
+			},
+		},
+		"instance size and major version": {
+			changes: testResourceChanges{
+				"mongo_db_major_version": {"5.0", "6.0"},
+				"replication_specs":      {replicationSpecs("AWS", "M10"), replicationSpecs("AWS", "M20")},
+			},
+			expected: []advancedClusterChange{
+				{"mongo_db_major_version", "5.0", "6.0", advancedClusterChangeRollingRestart},
+				{"replication_specs.0.region_configs.0.electable_specs.0.instance_size", "M10", "M20", advancedClusterChangeRollingRestart},
+			},
+		},
+		"shared tier upgrade": {
+			changes: testResourceChanges{
+				"replication_specs": {replicationSpecs("TENANT", "M5"), replicationSpecs("AWS", "M10")},
+			},
+			expected: []advancedClusterChange{
+				{"replication_specs.0.region_configs.0.electable_specs.0.instance_size", "M5", "M10", advancedClusterChangeTierUpgrade},
+			},
+		},
+		"disk size of a shard": {
+			changes: testResourceChanges{
+				"replication_specs": {
+					append(replicationSpecs("AWS", "M30"), shardDisk("M30", 40)...),
+					append(replicationSpecs("AWS", "M30"), shardDisk("M50", 160)...),
+				},
+			},
+			expected: []advancedClusterChange{
+				{"replication_specs.1.region_configs.0.electable_specs.0.instance_size", "M30", "M50", advancedClusterChangeRollingRestart},
+				{"replication_specs.1.region_configs.0.electable_specs.0.disk_size_gb", 40.0, 160.0, advancedClusterChangeInPlace},
+			},
+		},
+		"provider migration": {
+			changes: testResourceChanges{
+				"replication_specs": {replicationSpecs("AWS", "M10"), replicationSpecs("GCP", "M10")},
+			},
+			expected: []advancedClusterChange{
+				{"replication_specs.0.region_configs.0.provider_name", "AWS", "GCP", advancedClusterChangeProviderMigration},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := advancedClusterChanges(tc.changes)
+			if diff := deep.Equal(tc.expected, got); diff != nil {
+				t.Fatalf("Bad advancedClusterChanges return \n got = %#v\nwant = %#v \ndiff = %#v", got, tc.expected, diff)
+			}
+		})
+	}
+}
+
+func TestResourceAdvancedClusterChangeWarnings(t *testing.T) {
+	r := resourceMongoDBAtlasAdvancedCluster()
+	raw := func(instanceSize string) map[string]interface{} {
+		return map[string]interface{}{
+			"project_id":   "test-project",
+			"name":         "test-cluster",
+			"cluster_type": "REPLICASET",
+			"replication_specs": []interface{}{
+				map[string]interface{}{
+					"region_configs": []interface{}{
+						map[string]interface{}{
+							"provider_name": "AWS",
+							"region_name":   "US_EAST_1",
+							"priority":      7,
+							"electable_specs": []interface{}{
+								map[string]interface{}{"instance_size": instanceSize, "node_count": 3},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	d := schema.TestResourceDataRaw(t, r.Schema, raw("M10"))
+	d.SetId("test-cluster")
+
+	warnings := &planWarnings{}
+	ctx := context.WithValue(context.Background(), planWarningsKey{}, warnings)
+	if _, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(raw("M20")), &MongoDBClient{Config: &Config{}}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(warnings.diagnostics) != 1 {
+		t.Fatalf("Bad plan warnings: got %d, want 1", len(warnings.diagnostics))
+	}
+
+	warning := warnings.diagnostics[0]
+	if want := `Cluster "test-cluster" goes through a rolling restart`; warning.Summary != want {
+		t.Errorf("Bad plan warning summary: got %q, want %q", warning.Summary, want)
+	}
+	if want := "replication_specs.0.region_configs.0.electable_specs.0.instance_size: M10 => M20"; !strings.Contains(warning.Detail, want) {
+		t.Errorf("Bad plan warning detail: got %q, want it to contain %q", warning.Detail, want)
+	}
+}
@@ -0,0 +1,106 @@
+package mongodbatlas
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"go.mongodb.org/atlas-sdk/v20230201002/admin"
+	matlas "go.mongodb.org/atlas/mongodbatlas"
+)
+
+// upgradeServerlessInstance upgrades the serverless instance named after request to a dedicated cluster, which keeps
+// its data and connection strings. Atlas upgrades the serverless instance to a single region cluster through the
+// endpoint upgrading the shared-tier clusters, updateUpgradedServerlessInstance then updates the cluster to the rest
+// of request, e.g. its other regions and nodes, backups or labels.
+func upgradeServerlessInstance(ctx context.Context, conn *matlas.Client, connV2 *admin.APIClient, projectID string,
+	request *admin.AdvancedClusterDescription, timeout time.Duration) (*matlas.Cluster, error) {
+	name := request.GetName()
+
+	_, resp, err := connV2.ServerlessInstancesApi.GetServerlessInstance(ctx, projectID, name).Execute()
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("`upgrade_from_serverless` is set but there is no serverless instance named %s in project %s", name, projectID)
+		}
+		return nil, err
+	}
+
+	upgradeRequest, err := advancedClusterServerlessUpgradeRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[INFO] Upgrading the serverless instance %s to a dedicated cluster", name)
+
+	cluster, _, err := upgradeCluster(ctx, conn, upgradeRequest, projectID, name, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("error upgrading the serverless instance %s: %s", name, err)
+	}
+
+	return cluster, nil
+}
+
+// updateUpgradedServerlessInstance updates the cluster upgraded by upgradeServerlessInstance to request.
+func updateUpgradedServerlessInstance(ctx context.Context, connV2 *admin.APIClient, projectID string,
+	request *admin.AdvancedClusterDescription, timeout time.Duration) (*admin.AdvancedClusterDescription, error) {
+	name := request.GetName()
+
+	updateRequest := *request
+	updateRequest.Name = nil
+
+	cluster, _, err := updateAdvancedCluster(ctx, connV2, &updateRequest, projectID, name, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("the serverless instance %s was upgraded but the dedicated cluster couldn't be updated to its configuration: %s", name, err)
+	}
+
+	return cluster, nil
+}
+
+// advancedClusterServerlessUpgradeRequest returns the request upgrading a serverless instance to the first region of
+// the first replication spec of request, the region with the highest priority.
+func advancedClusterServerlessUpgradeRequest(request *admin.AdvancedClusterDescription) (*matlas.Cluster, error) {
+	if len(request.ReplicationSpecs) == 0 || len(request.ReplicationSpecs[0].RegionConfigs) == 0 {
+		return nil, fmt.Errorf("`upgrade_from_serverless` requires a region in `replication_specs`")
+	}
+
+	regionConfig := &request.ReplicationSpecs[0].RegionConfigs[0]
+	instanceSize := regionConfig.ElectableSpecs.GetInstanceSize()
+
+	if regionConfig.GetProviderName() == "TENANT" || isSharedTier(instanceSize) {
+		return nil, fmt.Errorf("`upgrade_from_serverless` requires a dedicated cluster, the serverless instance can't be upgraded to %s", instanceSize)
+	}
+
+	return &matlas.Cluster{
+		ProviderSettings: &matlas.ProviderSettings{
+			ProviderName:     regionConfig.GetProviderName(),
+			InstanceSizeName: instanceSize,
+			RegionName:       regionConfig.GetRegionName(),
+		},
+	}, nil
+}
+
+// resourceAdvancedClusterServerlessUpgradeCustomizeDiff rejects at plan time a cluster the serverless instance
+// couldn't be upgraded to. upgrade_from_serverless only applies to the creation of the cluster.
+func resourceAdvancedClusterServerlessUpgradeCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" || !d.Get("upgrade_from_serverless").(bool) {
+		return nil
+	}
+
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() || !rawConfig.GetAttr("replication_specs").IsWhollyKnown() {
+		return nil
+	}
+
+	replicationSpecs := expandAdvancedReplicationSpecs(d.Get("replication_specs").([]interface{}))
+	if isAdvancedClusterShardedIndependently(replicationSpecs) {
+		return fmt.Errorf("`upgrade_from_serverless` can't be used with shards described by their own replication_specs, " +
+			"upgrade the serverless instance to a cluster with num_shards and describe each shard once it's upgraded")
+	}
+
+	_, err := advancedClusterServerlessUpgradeRequest(&admin.AdvancedClusterDescription{ReplicationSpecs: replicationSpecs})
+
+	return err
+}
@@ -0,0 +1,312 @@
+package mongodbatlas
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"go.mongodb.org/atlas-sdk/v20230201002/admin"
+	matlas "go.mongodb.org/atlas/mongodbatlas"
+)
+
+func TestAdvancedClusterServerlessUpgradeRequest(t *testing.T) {
+	regionConfig := func(providerName, instanceSize, regionName string) admin.CloudRegionConfig {
+		return admin.CloudRegionConfig{
+			ProviderName:   admin.PtrString(providerName),
+			RegionName:     admin.PtrString(regionName),
+			ElectableSpecs: &admin.HardwareSpec{InstanceSize: admin.PtrString(instanceSize), NodeCount: admin.PtrInt(3)},
+		}
+	}
+
+	testCases := map[string]struct {
+		regionConfigs []admin.CloudRegionConfig
+		expected      *matlas.ProviderSettings
+		expectedError string
+	}{
+		"highest priority region": {
+			regionConfigs: []admin.CloudRegionConfig{
+				regionConfig("AWS", "M10", "US_EAST_1"),
+				regionConfig("AWS", "M10", "US_WEST_2"),
+			},
+			expected: &matlas.ProviderSettings{ProviderName: "AWS", InstanceSizeName: "M10", RegionName: "US_EAST_1"},
+		},
+		"shared tier": {
+			regionConfigs: []admin.CloudRegionConfig{regionConfig("TENANT", "M5", "US_EAST_1")},
+			expectedError: "the serverless instance can't be upgraded to M5",
+		},
+		"no region": {
+			expectedError: "requires a region",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			request := &admin.AdvancedClusterDescription{
+				ReplicationSpecs: []admin.ReplicationSpec{{RegionConfigs: tc.regionConfigs}},
+			}
+
+			actual, err := advancedClusterServerlessUpgradeRequest(request)
+			if tc.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+					t.Fatalf("Bad error, expected %q, got %v", tc.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Bad request: %s", err)
+			}
+
+			if *actual.ProviderSettings != *tc.expected {
+				t.Errorf("Bad provider settings, expected %+v, got %+v", tc.expected, actual.ProviderSettings)
+			}
+		})
+	}
+}
+
+func TestMockClusterAdvancedCluster_serverlessUpgrade(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		mock      = newAtlasMockServer(t)
+		client    = mock.client()
+		projectID = mock.newProject("test-mock-serverless-upgrade")
+	)
+
+	request := &admin.AdvancedClusterDescription{
+		Name:        admin.PtrString("missing-serverless"),
+		ClusterType: admin.PtrString("REPLICASET"),
+		ReplicationSpecs: []admin.ReplicationSpec{{
+			RegionConfigs: []admin.CloudRegionConfig{{
+				ProviderName:   admin.PtrString("AWS"),
+				RegionName:     admin.PtrString("US_EAST_1"),
+				Priority:       admin.PtrInt(7),
+				ElectableSpecs: &admin.HardwareSpec{InstanceSize: admin.PtrString("M10"), NodeCount: admin.PtrInt(3)},
+			}},
+		}},
+	}
+
+	_, err := upgradeServerlessInstance(ctx, client.Atlas, client.AtlasV2, projectID, request, time.Minute)
+	if err == nil || !strings.Contains(err.Error(), "there is no serverless instance named missing-serverless") {
+		t.Fatalf("Bad error, expected the serverless instance not to be found, got %v", err)
+	}
+
+	if count := mock.requestCount("POST", fmt.Sprintf("%s/groups/%s/clusters/tenantUpgrade", mockAtlasV1Path, projectID)); count != 0 {
+		t.Errorf("Bad upgrade, expected no upgrade request without a serverless instance, got %d", count)
+	}
+
+	testMockResourceUnitTest(t, mock, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:      testMockAdvancedClusterServerlessUpgradeConfig(projectID, "M5", "TENANT"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("the serverless instance can't be upgraded to M5"),
+			},
+		},
+	})
+}
+
+func testMockAdvancedClusterServerlessUpgradeConfig(projectID, instanceSize, providerName string) string {
+	return fmt.Sprintf(`
+		resource "mongodbatlas_advanced_cluster" "test" {
+			project_id              = %[1]q
+			name                    = "test-mock-serverless-upgrade"
+			cluster_type            = "REPLICASET"
+			upgrade_from_serverless = true
+
+			replication_specs {
+				region_configs {
+					electable_specs {
+						instance_size = %[2]q
+					}
+					provider_name         = %[3]q
+					backing_provider_name = "AWS"
+					priority              = 7
+					region_name           = "US_EAST_1"
+				}
+			}
+		}
+	`, projectID, instanceSize, providerName)
+}
+
+func TestAccClusterAdvancedCluster_upgradeFromServerless(t *testing.T) {
+	SkipTestForCI(t)
+	var (
+		cluster      matlas.AdvancedCluster
+		resourceName = "mongodbatlas_advanced_cluster.test"
+		projectID    = os.Getenv("MONGODB_ATLAS_PROJECT_ID")
+		rName        = acctest.RandomWithPrefix("test-acc")
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheckBasic(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckMongoDBAtlasAdvancedClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					ctx := context.Background()
+					conn := testAccProvider.Meta().(*MongoDBClient).Atlas
+
+					_, _, err := conn.ServerlessInstances.Create(ctx, projectID, &matlas.ServerlessCreateRequestParams{
+						Name: rName,
+						ProviderSettings: &matlas.ServerlessProviderSettings{
+							BackingProviderName: "AWS",
+							ProviderName:        "SERVERLESS",
+							RegionName:          "US_EAST_1",
+						},
+					})
+					if err != nil {
+						t.Fatalf("error creating the serverless instance %s: %s", rName, err)
+					}
+
+					stateConf := &retry.StateChangeConf{
+						Pending:    []string{"CREATING", "UPDATING", "REPAIRING", "REPEATING", "PENDING"},
+						Target:     []string{"IDLE"},
+						Refresh:    resourceServerlessInstanceRefreshFunc(ctx, rName, projectID, conn),
+						Timeout:    3 * time.Hour,
+						MinTimeout: 1 * time.Minute,
+						Delay:      3 * time.Minute,
+					}
+					if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+						t.Fatalf("error creating the serverless instance %s: %s", rName, err)
+					}
+				},
+				Config: fmt.Sprintf(`
+resource "mongodbatlas_advanced_cluster" "test" {
+  project_id              = %[1]q
+  name                    = %[2]q
+  cluster_type            = "REPLICASET"
+  backup_enabled          = true
+  upgrade_from_serverless = true
+
+  replication_specs {
+    region_configs {
+      electable_specs {
+        instance_size = "M10"
+        node_count    = 3
+      }
+      provider_name = "AWS"
+      priority      = 7
+      region_name   = "US_EAST_1"
+    }
+  }
+}
+				`, projectID, rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMongoDBAtlasAdvancedClusterExists(resourceName, &cluster),
+					testAccCheckMongoDBAtlasAdvancedClusterAttributes(&cluster, rName),
+					resource.TestCheckResourceAttr(resourceName, "backup_enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "replication_specs.0.region_configs.0.electable_specs.0.instance_size", "M10"),
+				),
+			},
+		},
+	})
+}
+
+func TestMockClusterAdvancedCluster_upgradeFromServerless(t *testing.T) {
+	var (
+		ctx          = context.Background()
+		mock         = newAtlasMockServer(t)
+		client       = mock.client()
+		projectID    = mock.newProject("test-mock-serverless-upgrade")
+		r            = resourceMongoDBAtlasAdvancedCluster()
+		clustersPath = fmt.Sprintf("%s/groups/%s/clusters", mockAtlasV2Path, projectID)
+	)
+
+	delay := clusterUpdateDelay
+	clusterUpdateDelay = 0
+	t.Cleanup(func() { clusterUpdateDelay = delay })
+
+	config := func(name string) map[string]interface{} {
+		return map[string]interface{}{
+			"project_id":              projectID,
+			"name":                    name,
+			"cluster_type":            "REPLICASET",
+			"upgrade_from_serverless": true,
+			"labels": []interface{}{
+				map[string]interface{}{"key": "env", "value": "test"},
+			},
+			"replication_specs": []interface{}{
+				map[string]interface{}{
+					"region_configs": []interface{}{
+						map[string]interface{}{
+							"provider_name":   "AWS",
+							"region_name":     "US_EAST_1",
+							"priority":        7,
+							"electable_specs": []interface{}{map[string]interface{}{"instance_size": "M30", "node_count": 3}},
+						},
+						map[string]interface{}{
+							"provider_name":   "AWS",
+							"region_name":     "US_WEST_2",
+							"priority":        6,
+							"electable_specs": []interface{}{map[string]interface{}{"instance_size": "M30", "node_count": 2}},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	for _, name := range []string{"upgraded", "not-updated"} {
+		mock.seed(fmt.Sprintf("%s/groups/%s/serverless", mockAtlasV2Path, projectID), map[string]interface{}{"name": name, "groupId": projectID})
+	}
+
+	d := schema.TestResourceDataRaw(t, r.Schema, config("upgraded"))
+	if diags := r.CreateWithoutTimeout(ctx, d, client); len(diags) > 0 {
+		t.Fatalf("Bad upgrade from serverless: %v", diags)
+	}
+
+	upgrades := mock.requestBodies(http.MethodPost, fmt.Sprintf("%s/groups/%s/clusters/tenantUpgrade", mockAtlasV1Path, projectID))
+	if len(upgrades) != 1 {
+		t.Fatalf("Bad upgrade, expected 1 upgrade request, got %d", len(upgrades))
+	}
+	if settings := upgrades[0].(map[string]interface{})["providerSettings"].(map[string]interface{}); settings["instanceSizeName"] != "M30" || settings["regionName"] != "US_EAST_1" {
+		t.Errorf("Bad upgrade, expected the serverless instance to be upgraded to the first region, got %v", settings)
+	}
+
+	updates := mock.requestBodies(http.MethodPatch, clustersPath+"/upgraded")
+	if len(updates) != 1 {
+		t.Fatalf("Bad upgrade, expected 1 update to the configuration, got %d", len(updates))
+	}
+	if specs := updates[0].(map[string]interface{})["replicationSpecs"].([]interface{}); len(specs[0].(map[string]interface{})["regionConfigs"].([]interface{})) != 2 {
+		t.Errorf("Bad upgrade, expected the cluster to be updated to both regions, got %v", specs)
+	}
+
+	if ids := decodeStateID(d.Id()); ids["cluster_name"] != "upgraded" || ids["cluster_id"] == "" {
+		t.Errorf("Bad ID after the upgrade: %#v", ids)
+	}
+	for k, want := range map[string]string{
+		"replication_specs.0.region_configs.#":                                 "2",
+		"replication_specs.0.region_configs.1.region_name":                     "US_WEST_2",
+		"replication_specs.0.region_configs.1.electable_specs.0.instance_size": "M30",
+		"state_name": "IDLE",
+	} {
+		if got := fmt.Sprint(d.Get(k)); got != want {
+			t.Errorf("Bad %s: got %s, want %s", k, got, want)
+		}
+	}
+
+	// the upgraded cluster is kept in the state without an error when it can't be updated to its configuration.
+	mock.failNext(http.MethodPatch, clustersPath+"/not-updated", http.StatusInternalServerError, 1, "")
+
+	d = schema.TestResourceDataRaw(t, r.Schema, config("not-updated"))
+	diags := r.CreateWithoutTimeout(ctx, d, client)
+	if diags.HasError() || len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Fatalf("Bad upgrade from serverless, expected a warning for the failed update, got %v", diags)
+	}
+	if ids := decodeStateID(d.Id()); ids["cluster_name"] != "not-updated" {
+		t.Errorf("Bad ID after the upgrade: %#v", ids)
+	}
+	if got := d.Get("replication_specs.0.region_configs.#").(int); got != 1 {
+		t.Errorf("Bad replication_specs, expected the single region of the upgraded cluster to be read, got %d regions", got)
+	}
+}
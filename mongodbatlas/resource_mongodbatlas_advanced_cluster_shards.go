@@ -0,0 +1,317 @@
+package mongodbatlas
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"go.mongodb.org/atlas-sdk/v20230201002/admin"
+)
+
+// advancedClusterShardsMediaType is the version of the Atlas Admin API describing each shard of a cluster in its own
+// replication spec, with its own instance sizes and disk. The Atlas SDK used by the provider predates it, and the
+// 2023-02-01 version it implements answers ASYMMETRIC_SHARD_UNSUPPORTED for a cluster whose shards differ.
+const advancedClusterShardsMediaType = "application/vnd.atlas.2024-08-05+json"
+
+// advancedClusterShardsDescription is a cluster in the 2024-08-05 version of the API, which only differs from
+// admin.AdvancedClusterDescription by its replication specs.
+type advancedClusterShardsDescription struct {
+	admin.AdvancedClusterDescription
+	ReplicationSpecs []advancedClusterShardSpec `json:"replicationSpecs,omitempty"`
+}
+
+// advancedClusterShardSpec is a single shard, it has no numShards.
+type advancedClusterShardSpec struct {
+	ID            *string                            `json:"id,omitempty"`
+	ZoneName      *string                            `json:"zoneName,omitempty"`
+	RegionConfigs []advancedClusterShardRegionConfig `json:"regionConfigs,omitempty"`
+}
+
+type advancedClusterShardRegionConfig struct {
+	admin.CloudRegionConfig
+	ElectableSpecs *advancedClusterShardHardwareSpec `json:"electableSpecs,omitempty"`
+	ReadOnlySpecs  *advancedClusterShardHardwareSpec `json:"readOnlySpecs,omitempty"`
+	AnalyticsSpecs *advancedClusterShardHardwareSpec `json:"analyticsSpecs,omitempty"`
+}
+
+// advancedClusterShardHardwareSpec is the hardware of the nodes of a shard, the disk size being set per shard.
+type advancedClusterShardHardwareSpec struct {
+	admin.HardwareSpec
+	DiskSizeGB *float64 `json:"diskSizeGB,omitempty"`
+}
+
+// advancedClusterShardsError is an error answered by Atlas to a request of the 2024-08-05 version of the API.
+type advancedClusterShardsError struct {
+	admin.ApiError
+}
+
+func (e *advancedClusterShardsError) Error() string {
+	return fmt.Sprintf("HTTP %d %s (Error code: %q) Detail: %s", e.GetError(), e.GetReason(), e.GetErrorCode(), e.GetDetail())
+}
+
+// isAdvancedClusterShardedIndependently reports whether the shards of a zone are described by several replication specs,
+// one for each shard, instead of a replication spec with num_shards.
+func isAdvancedClusterShardedIndependently(replicationSpecs []admin.ReplicationSpec) bool {
+	zones := make(map[string]bool)
+	for i := range replicationSpecs {
+		zoneName := replicationSpecs[i].GetZoneName()
+		if zones[zoneName] {
+			return true
+		}
+		zones[zoneName] = true
+	}
+
+	return false
+}
+
+// isAdvancedClusterErrorCode is admin.IsErrorCode for the errors of both versions of the API.
+func isAdvancedClusterErrorCode(err error, code string) bool {
+	if shardsErr, ok := err.(*advancedClusterShardsError); ok {
+		return shardsErr.GetErrorCode() == code
+	}
+
+	return admin.IsErrorCode(err, code)
+}
+
+// advancedClusterErrorStatus returns the HTTP status of an error of either version of the API, 0 if it isn't one.
+func advancedClusterErrorStatus(err error) int {
+	if shardsErr, ok := err.(*advancedClusterShardsError); ok {
+		return shardsErr.GetError()
+	}
+
+	if apiError, ok := admin.AsError(err); ok {
+		return apiError.GetError()
+	}
+
+	return 0
+}
+
+// hasAdvancedClusterSpecsDiskSize reports whether the disk_size_gb of the specs of a region is set in the configuration.
+func hasAdvancedClusterSpecsDiskSize(replicationSpecs cty.Value) bool {
+	if replicationSpecs.IsNull() || !replicationSpecs.IsKnown() {
+		return false
+	}
+
+	for specs := replicationSpecs.ElementIterator(); specs.Next(); {
+		_, spec := specs.Element()
+		regionConfigs := spec.GetAttr("region_configs")
+		if regionConfigs.IsNull() || !regionConfigs.IsKnown() {
+			continue
+		}
+
+		for regions := regionConfigs.ElementIterator(); regions.Next(); {
+			_, region := regions.Element()
+			for _, name := range []string{"electable_specs", "read_only_specs", "analytics_specs"} {
+				hardware := region.GetAttr(name)
+				if hardware.IsNull() || !hardware.IsKnown() {
+					continue
+				}
+
+				for it := hardware.ElementIterator(); it.Next(); {
+					_, v := it.Element()
+					if !v.GetAttr("disk_size_gb").IsNull() {
+						return true
+					}
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// expandAdvancedClusterShardSpecs expands the replication_specs of a cluster sharded independently, diskSizeGB being
+// the disk size of the cluster, used for the specs without a disk_size_gb when it's greater than 0.
+func expandAdvancedClusterShardSpecs(tfList []interface{}, diskSizeGB float64) []advancedClusterShardSpec {
+	var apiObjects []advancedClusterShardSpec
+
+	for _, tfMapRaw := range tfList {
+		tfMap, ok := tfMapRaw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		spec := expandAdvancedReplicationSpec(tfMap)
+		tfRegionConfigs, _ := tfMap["region_configs"].([]interface{})
+
+		apiObject := advancedClusterShardSpec{ZoneName: spec.ZoneName}
+		for i := range spec.RegionConfigs {
+			regionConfig := spec.RegionConfigs[i]
+			tfRegionConfig := mapAt(tfRegionConfigs, i)
+
+			apiObject.RegionConfigs = append(apiObject.RegionConfigs, advancedClusterShardRegionConfig{
+				CloudRegionConfig: regionConfig,
+				ElectableSpecs:    expandAdvancedClusterShardHardwareSpec(regionConfig.ElectableSpecs, tfRegionConfig["electable_specs"], diskSizeGB),
+				ReadOnlySpecs:     expandAdvancedClusterShardHardwareSpec(hardwareSpecFromDedicated(regionConfig.ReadOnlySpecs), tfRegionConfig["read_only_specs"], diskSizeGB),
+				AnalyticsSpecs:    expandAdvancedClusterShardHardwareSpec(hardwareSpecFromDedicated(regionConfig.AnalyticsSpecs), tfRegionConfig["analytics_specs"], diskSizeGB),
+			})
+		}
+
+		apiObjects = append(apiObjects, apiObject)
+	}
+
+	return apiObjects
+}
+
+func expandAdvancedClusterShardHardwareSpec(apiObject *admin.HardwareSpec, tfList interface{}, diskSizeGB float64) *advancedClusterShardHardwareSpec {
+	if apiObject == nil {
+		return nil
+	}
+
+	hardwareSpec := &advancedClusterShardHardwareSpec{HardwareSpec: *apiObject}
+	if v, ok := firstMap(tfList)["disk_size_gb"].(float64); ok && v > 0 && diskSizeGB <= 0 {
+		hardwareSpec.DiskSizeGB = admin.PtrFloat64(v)
+	} else if diskSizeGB > 0 {
+		hardwareSpec.DiskSizeGB = admin.PtrFloat64(diskSizeGB)
+	}
+
+	return hardwareSpec
+}
+
+// advancedClusterFromShards converts a cluster of the 2024-08-05 version of the API to the model of the Atlas SDK, with a
+// replication spec of a single shard for each shard. The disk size of the cluster is the one of its first shard.
+func advancedClusterFromShards(apiObject *advancedClusterShardsDescription) *admin.AdvancedClusterDescription {
+	cluster := apiObject.AdvancedClusterDescription
+	cluster.ReplicationSpecs = nil
+
+	for i := range apiObject.ReplicationSpecs {
+		shard := &apiObject.ReplicationSpecs[i]
+		spec := admin.ReplicationSpec{
+			Id:        shard.ID,
+			NumShards: admin.PtrInt(1),
+			ZoneName:  shard.ZoneName,
+		}
+
+		for j := range shard.RegionConfigs {
+			regionConfig := shard.RegionConfigs[j].CloudRegionConfig
+			regionConfig.ElectableSpecs = shardHardwareSpec(shard.RegionConfigs[j].ElectableSpecs)
+			regionConfig.ReadOnlySpecs = dedicatedFromHardwareSpec(shardHardwareSpec(shard.RegionConfigs[j].ReadOnlySpecs))
+			regionConfig.AnalyticsSpecs = dedicatedFromHardwareSpec(shardHardwareSpec(shard.RegionConfigs[j].AnalyticsSpecs))
+
+			if shard.RegionConfigs[j].ElectableSpecs != nil && cluster.DiskSizeGB == nil {
+				cluster.DiskSizeGB = shard.RegionConfigs[j].ElectableSpecs.DiskSizeGB
+			}
+
+			spec.RegionConfigs = append(spec.RegionConfigs, regionConfig)
+		}
+
+		cluster.ReplicationSpecs = append(cluster.ReplicationSpecs, spec)
+	}
+
+	return &cluster
+}
+
+func shardHardwareSpec(apiObject *advancedClusterShardHardwareSpec) *admin.HardwareSpec {
+	if apiObject == nil {
+		return nil
+	}
+
+	return &apiObject.HardwareSpec
+}
+
+// flattenAdvancedClusterShardDiskSizes sets the disk_size_gb of the specs of the replication specs flattened by
+// flattenAdvancedReplicationSpecs, from the shard with the same id.
+func flattenAdvancedClusterShardDiskSizes(tfList []map[string]interface{}, shards []advancedClusterShardSpec) {
+	for _, tfMap := range tfList {
+		for i := range shards {
+			if tfMap == nil || shards[i].ID == nil || tfMap["id"] != *shards[i].ID {
+				continue
+			}
+
+			tfRegionConfigs, _ := tfMap["region_configs"].([]map[string]interface{})
+			for j := 0; j < len(tfRegionConfigs) && j < len(shards[i].RegionConfigs); j++ {
+				regionConfig := &shards[i].RegionConfigs[j]
+				for name, hardwareSpec := range map[string]*advancedClusterShardHardwareSpec{
+					"electable_specs": regionConfig.ElectableSpecs,
+					"read_only_specs": regionConfig.ReadOnlySpecs,
+					"analytics_specs": regionConfig.AnalyticsSpecs,
+				} {
+					tfSpecs, _ := tfRegionConfigs[j][name].([]map[string]interface{})
+					if len(tfSpecs) > 0 && hardwareSpec != nil && hardwareSpec.DiskSizeGB != nil {
+						tfSpecs[0]["disk_size_gb"] = *hardwareSpec.DiskSizeGB
+					}
+				}
+			}
+		}
+	}
+}
+
+// getAdvancedCluster reads a cluster, through the 2024-08-05 version of the API when it's sharded independently. Otherwise the
+// cluster is read through the Atlas SDK, falling back to the 2024-08-05 version when its shards differ, e.g. when it's imported.
+func getAdvancedCluster(ctx context.Context, connV2 *admin.APIClient, projectID, clusterName string,
+	shardedIndependently bool) (*admin.AdvancedClusterDescription, []advancedClusterShardSpec, *http.Response, error) {
+	if !shardedIndependently {
+		cluster, resp, err := connV2.MultiCloudClustersApi.GetCluster(ctx, projectID, clusterName).Execute()
+		if err == nil || !admin.IsErrorCode(err, "ASYMMETRIC_SHARD_UNSUPPORTED") {
+			return cluster, nil, resp, err
+		}
+	}
+
+	shardsCluster := new(advancedClusterShardsDescription)
+	resp, err := doAdvancedClusterShardsRequest(ctx, connV2, http.MethodGet, advancedClusterShardsPath(projectID, clusterName), nil, shardsCluster)
+	if err != nil {
+		return nil, nil, resp, err
+	}
+
+	return advancedClusterFromShards(shardsCluster), shardsCluster.ReplicationSpecs, resp, nil
+}
+
+func createAdvancedClusterShards(ctx context.Context, connV2 *admin.APIClient, projectID string,
+	request *advancedClusterShardsDescription) (*admin.AdvancedClusterDescription, error) {
+	cluster := new(advancedClusterShardsDescription)
+	if _, err := doAdvancedClusterShardsRequest(ctx, connV2, http.MethodPost, advancedClusterShardsPath(projectID, ""), request, cluster); err != nil {
+		return nil, err
+	}
+
+	return advancedClusterFromShards(cluster), nil
+}
+
+// updateAdvancedClusterShards is updateAdvancedCluster for a cluster sharded independently.
+func updateAdvancedClusterShards(
+	ctx context.Context,
+	connV2 *admin.APIClient,
+	request *advancedClusterShardsDescription,
+	projectID, name string,
+	timeout time.Duration,
+) error {
+	if _, err := doAdvancedClusterShardsRequest(ctx, connV2, http.MethodPatch, advancedClusterShardsPath(projectID, name), request, nil); err != nil {
+		return err
+	}
+
+	stateConf := &retry.StateChangeConf{
+		Pending:    []string{"CREATING", "UPDATING", "REPAIRING"},
+		Target:     []string{"IDLE"},
+		Refresh:    resourceClusterAdvancedRefreshFunc(ctx, name, projectID, connV2, true),
+		Timeout:    timeout,
+		MinTimeout: 30 * time.Second,
+		Delay:      1 * time.Minute,
+	}
+
+	// Wait, catching any errors
+	_, err := stateConf.WaitForStateContext(ctx)
+
+	return err
+}
+
+func advancedClusterShardsPath(projectID, clusterName string) string {
+	path := fmt.Sprintf("/api/atlas/v2/groups/%s/clusters", url.PathEscape(projectID))
+	if clusterName != "" {
+		path += "/" + url.PathEscape(clusterName)
+	}
+
+	return path
+}
+
+// doAdvancedClusterShardsRequest sends a request of the 2024-08-05 version of the API.
+func doAdvancedClusterShardsRequest(ctx context.Context, connV2 *admin.APIClient, method, path string, body, result interface{}) (*http.Response, error) {
+	resp, err := doAtlasAPIRequest(ctx, connV2, advancedClusterShardsMediaType, method, path, body, result)
+	if apiError, ok := err.(*atlasAPIError); ok {
+		return resp, &advancedClusterShardsError{apiError.ApiError}
+	}
+
+	return resp, err
+}
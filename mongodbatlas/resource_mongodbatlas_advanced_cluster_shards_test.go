@@ -0,0 +1,306 @@
+package mongodbatlas
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"go.mongodb.org/atlas-sdk/v20230201002/admin"
+)
+
+func TestExpandAdvancedClusterShardSpecs(t *testing.T) {
+	shard := func(instanceSize string, diskSizeGB float64) interface{} {
+		return map[string]interface{}{
+			"num_shards": 1,
+			"zone_name":  "Zone 1",
+			"region_configs": []interface{}{
+				map[string]interface{}{
+					"provider_name": "AWS",
+					"region_name":   "US_EAST_1",
+					"priority":      7,
+					"electable_specs": []interface{}{
+						map[string]interface{}{"instance_size": instanceSize, "node_count": 3, "disk_size_gb": diskSizeGB},
+					},
+					"read_only_specs": []interface{}{
+						map[string]interface{}{"instance_size": instanceSize, "node_count": 1, "disk_size_gb": diskSizeGB},
+					},
+				},
+			},
+		}
+	}
+	specs := []interface{}{shard("M30", 40), shard("M50", 0)}
+
+	testCases := map[string]struct {
+		diskSizeGB float64
+		expected   string
+	}{
+		"disk of each shard": {
+			expected: `[{"zoneName":"Zone 1","regionConfigs":[{"priority":7,"providerName":"AWS","regionName":"US_EAST_1",` +
+				`"electableSpecs":{"instanceSize":"M30","nodeCount":3,"diskSizeGB":40},"readOnlySpecs":{"instanceSize":"M30","nodeCount":1,"diskSizeGB":40}}]},` +
+				`{"zoneName":"Zone 1","regionConfigs":[{"priority":7,"providerName":"AWS","regionName":"US_EAST_1",` +
+				`"electableSpecs":{"instanceSize":"M50","nodeCount":3},"readOnlySpecs":{"instanceSize":"M50","nodeCount":1}}]}]`,
+		},
+		"disk of the cluster": {
+			diskSizeGB: 100,
+			expected: `[{"zoneName":"Zone 1","regionConfigs":[{"priority":7,"providerName":"AWS","regionName":"US_EAST_1",` +
+				`"electableSpecs":{"instanceSize":"M30","nodeCount":3,"diskSizeGB":100},"readOnlySpecs":{"instanceSize":"M30","nodeCount":1,"diskSizeGB":100}}]},` +
+				`{"zoneName":"Zone 1","regionConfigs":[{"priority":7,"providerName":"AWS","regionName":"US_EAST_1",` +
+				`"electableSpecs":{"instanceSize":"M50","nodeCount":3,"diskSizeGB":100},"readOnlySpecs":{"instanceSize":"M50","nodeCount":1,"diskSizeGB":100}}]}]`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := json.Marshal(expandAdvancedClusterShardSpecs(specs, tc.diskSizeGB))
+			if err != nil {
+				t.Fatalf("Bad marshal: %s", err)
+			}
+
+			if string(got) != tc.expected {
+				t.Fatalf("Bad expandAdvancedClusterShardSpecs\n got = %s\nwant = %s", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestAdvancedClusterFromShards(t *testing.T) {
+	var cluster advancedClusterShardsDescription
+	err := json.Unmarshal([]byte(`{
+		"name": "sharded",
+		"clusterType": "SHARDED",
+		"replicationSpecs": [
+			{"id": "shard-0", "zoneName": "Zone 1", "regionConfigs": [{"providerName": "AWS", "regionName": "US_EAST_1", "priority": 7,
+				"electableSpecs": {"instanceSize": "M30", "nodeCount": 3, "diskSizeGB": 40}}]},
+			{"id": "shard-1", "zoneName": "Zone 1", "regionConfigs": [{"providerName": "AWS", "regionName": "US_EAST_1", "priority": 7,
+				"electableSpecs": {"instanceSize": "M50", "nodeCount": 3, "diskSizeGB": 160}}]}
+		]
+	}`), &cluster)
+	if err != nil {
+		t.Fatalf("Bad unmarshal: %s", err)
+	}
+
+	apiObject := advancedClusterFromShards(&cluster)
+	if apiObject.GetName() != "sharded" || apiObject.GetDiskSizeGB() != 40 || len(apiObject.ReplicationSpecs) != 2 {
+		t.Fatalf("Bad advancedClusterFromShards: %+v", apiObject)
+	}
+
+	for i, want := range []string{"M30", "M50"} {
+		spec := apiObject.ReplicationSpecs[i]
+		if spec.GetNumShards() != 1 || spec.RegionConfigs[0].ElectableSpecs.GetInstanceSize() != want {
+			t.Errorf("Bad replication spec %d: %+v", i, spec)
+		}
+	}
+
+	// the replication specs are matched by id, whatever their order in the state.
+	tfList := []map[string]interface{}{
+		{"id": "shard-1", "region_configs": []map[string]interface{}{{"electable_specs": []map[string]interface{}{{"instance_size": "M50"}}}}},
+		{"id": "shard-0", "region_configs": []map[string]interface{}{{"electable_specs": []map[string]interface{}{{"instance_size": "M30"}}}}},
+	}
+	flattenAdvancedClusterShardDiskSizes(tfList, cluster.ReplicationSpecs)
+
+	for i, want := range []float64{160, 40} {
+		specs := tfList[i]["region_configs"].([]map[string]interface{})[0]["electable_specs"].([]map[string]interface{})
+		if got := specs[0]["disk_size_gb"]; got != want {
+			t.Errorf("Bad disk_size_gb of %s: got %v, want %v", tfList[i]["id"], got, want)
+		}
+	}
+}
+
+func TestIsAdvancedClusterErrorCode(t *testing.T) {
+	err := &advancedClusterShardsError{admin.ApiError{
+		Error:     admin.PtrInt(http.StatusConflict),
+		ErrorCode: admin.PtrString("CANNOT_UPDATE_PAUSED_CLUSTER"),
+	}}
+
+	if !isAdvancedClusterErrorCode(err, "CANNOT_UPDATE_PAUSED_CLUSTER") || isAdvancedClusterErrorCode(err, "CLUSTER_NOT_FOUND") {
+		t.Errorf("Bad isAdvancedClusterErrorCode for %s", err)
+	}
+
+	if got := advancedClusterErrorStatus(err); got != http.StatusConflict {
+		t.Errorf("Bad advancedClusterErrorStatus: got %d, want %d", got, http.StatusConflict)
+	}
+}
+
+func TestMockClusterAdvancedCluster_independentShards(t *testing.T) {
+	var (
+		ctx         = context.Background()
+		mock        = newAtlasMockServer(t)
+		client      = mock.client()
+		projectID   = mock.newProject("test-mock-shards")
+		clusterName = "test-mock-shards"
+		clusterPath = fmt.Sprintf("%s/groups/%s/clusters/%s", mockAtlasV2Path, projectID, clusterName)
+		r           = resourceMongoDBAtlasAdvancedCluster()
+	)
+
+	shard := func(instanceSize string, diskSizeGB float64) interface{} {
+		return map[string]interface{}{
+			"num_shards": 1,
+			"zone_name":  "Zone 1",
+			"region_configs": []interface{}{
+				map[string]interface{}{
+					"provider_name": "AWS",
+					"region_name":   "US_EAST_1",
+					"priority":      7,
+					"electable_specs": []interface{}{
+						map[string]interface{}{"instance_size": instanceSize, "node_count": 3, "disk_size_gb": diskSizeGB},
+					},
+				},
+			},
+		}
+	}
+	replicationSpecs := []interface{}{shard("M30", 40), shard("M50", 160)}
+
+	cluster, err := createAdvancedClusterShards(ctx, client.AtlasV2, projectID, &advancedClusterShardsDescription{
+		AdvancedClusterDescription: admin.AdvancedClusterDescription{
+			Name:        admin.PtrString(clusterName),
+			ClusterType: admin.PtrString("SHARDED"),
+		},
+		ReplicationSpecs: expandAdvancedClusterShardSpecs(replicationSpecs, 0),
+	})
+	if err != nil {
+		t.Fatalf("Bad create advanced cluster: %s", err)
+	}
+	if cluster.GetName() != clusterName || len(cluster.ReplicationSpecs) != 2 {
+		t.Fatalf("Bad created advanced cluster: %+v", cluster)
+	}
+
+	// Atlas answers with the project of the cluster, which the mock doesn't know.
+	mock.get(clusterPath)["groupId"] = projectID
+
+	for i, spec := range mock.get(clusterPath)["replicationSpecs"].([]interface{}) {
+		if _, ok := spec.(map[string]interface{})["numShards"]; ok {
+			t.Errorf("Bad replicationSpecs.%d: numShards must not be sent with each shard", i)
+		}
+	}
+
+	// the Atlas SDK can't read the cluster, it's read through the version of the API describing each shard.
+	if _, _, err := client.AtlasV2.MultiCloudClustersApi.GetCluster(ctx, projectID, clusterName).Execute(); !admin.IsErrorCode(err, "ASYMMETRIC_SHARD_UNSUPPORTED") {
+		t.Fatalf("Bad read through the Atlas SDK: got %v, want ASYMMETRIC_SHARD_UNSUPPORTED", err)
+	}
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	d.SetId(fmt.Sprintf("%s-%s", projectID, clusterName))
+
+	if _, err := r.Importer.StateContext(ctx, d, client); err != nil {
+		t.Fatalf("Bad import advanced cluster: %s", err)
+	}
+
+	if diags := r.ReadWithoutTimeout(ctx, d, client); diags.HasError() {
+		t.Fatalf("Bad read advanced cluster: %v", diags)
+	}
+
+	checks := map[string]string{
+		"cluster_type":                   "SHARDED",
+		"disk_size_gb":                   "40",
+		"replication_specs.#":            "2",
+		"replication_specs.0.num_shards": "1",
+		"replication_specs.0.region_configs.0.electable_specs.0.instance_size": "M30",
+		"replication_specs.0.region_configs.0.electable_specs.0.disk_size_gb":  "40",
+		"replication_specs.1.num_shards":                                       "1",
+		"replication_specs.1.region_configs.0.electable_specs.0.instance_size": "M50",
+		"replication_specs.1.region_configs.0.electable_specs.0.disk_size_gb":  "160",
+	}
+	for k, want := range checks {
+		if got := fmt.Sprint(d.Get(k)); got != want {
+			t.Errorf("Bad %s: got %s, want %s", k, got, want)
+		}
+	}
+
+	// only the hot shard is scaled up.
+	replicationSpecs[1] = shard("M60", 320)
+	_, err = doAdvancedClusterShardsRequest(ctx, client.AtlasV2, http.MethodPatch, advancedClusterShardsPath(projectID, clusterName),
+		&advancedClusterShardsDescription{ReplicationSpecs: expandAdvancedClusterShardSpecs(replicationSpecs, 0)}, nil)
+	if err != nil {
+		t.Fatalf("Bad update advanced cluster: %s", err)
+	}
+
+	if diags := r.ReadWithoutTimeout(ctx, d, client); diags.HasError() {
+		t.Fatalf("Bad read advanced cluster: %v", diags)
+	}
+
+	checks = map[string]string{
+		"replication_specs.0.region_configs.0.electable_specs.0.instance_size": "M30",
+		"replication_specs.0.region_configs.0.electable_specs.0.disk_size_gb":  "40",
+		"replication_specs.1.region_configs.0.electable_specs.0.instance_size": "M60",
+		"replication_specs.1.region_configs.0.electable_specs.0.disk_size_gb":  "320",
+	}
+	for k, want := range checks {
+		if got := fmt.Sprint(d.Get(k)); got != want {
+			t.Errorf("Bad %s: got %s, want %s", k, got, want)
+		}
+	}
+
+	testMockResourceUnitTest(t, mock, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:      testMockAdvancedClusterShardsConfig(projectID, "disk_size_gb = 100", "disk_size_gb = 40", ""),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`disk_size_gb` conflicts with the `disk_size_gb` of the specs"),
+			},
+			{
+				Config:      testMockAdvancedClusterShardsConfig(projectID, "", "disk_size_gb = 40", "num_shards = 2"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`num_shards` must be 1 when each shard is described by its own replication_specs"),
+			},
+		},
+	})
+
+	ds := dataSourceMongoDBAtlasAdvancedCluster()
+	dsData := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{
+		"project_id": projectID,
+		"name":       clusterName,
+	})
+	if diags := ds.ReadContext(ctx, dsData, client); diags.HasError() {
+		t.Fatalf("Bad read advanced cluster data source: %v", diags)
+	}
+	if got := dsData.Get("replication_specs").(*schema.Set).Len(); got != 2 {
+		t.Errorf("Bad data source replication_specs: got %d, want 2", got)
+	}
+
+	if got := mock.requestCount(http.MethodPost, mockAtlasV2Path); got != 1 {
+		t.Errorf("Bad create requests: got %d, want 1", got)
+	}
+}
+
+func testMockAdvancedClusterShardsConfig(projectID, clusterDisk, shardDisk, shardExtra string) string {
+	return fmt.Sprintf(`
+		resource "mongodbatlas_advanced_cluster" "test" {
+			project_id   = %[1]q
+			name         = "test-mock-shards-plan"
+			cluster_type = "SHARDED"
+			%[2]s
+
+			replication_specs {
+				region_configs {
+					electable_specs {
+						instance_size = "M30"
+						node_count    = 3
+					}
+					provider_name = "AWS"
+					priority      = 7
+					region_name   = "US_EAST_1"
+				}
+			}
+
+			replication_specs {
+				%[4]s
+
+				region_configs {
+					electable_specs {
+						instance_size = "M50"
+						node_count    = 3
+						%[3]s
+					}
+					provider_name = "AWS"
+					priority      = 7
+					region_name   = "US_EAST_1"
+				}
+			}
+		}
+	`, projectID, clusterDisk, shardDisk, shardExtra)
+}
@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"regexp"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
@@ -1017,3 +1020,151 @@ resource "mongodbatlas_advanced_cluster" "test" {
 
 	`, orgID, projectName, name, *p.Compute.Enabled, *p.DiskGBEnabled, p.Compute.MaxInstanceSize)
 }
+
+func TestMockClusterAdvancedCluster_import(t *testing.T) {
+	var (
+		ctx         = context.Background()
+		mock        = newAtlasMockServer(t)
+		client      = mock.client()
+		projectID   = mock.newProject("test-mock")
+		clusterName = "test-mock-cluster"
+		r           = resourceMongoDBAtlasAdvancedCluster()
+	)
+
+	mock.seed(fmt.Sprintf("%s/groups/%s/clusters", mockAtlasV15Path, projectID), map[string]interface{}{
+		"name":        clusterName,
+		"groupId":     projectID,
+		"clusterType": "REPLICASET",
+		"diskSizeGB":  10,
+		"labels": []interface{}{
+			map[string]interface{}{"key": "env", "value": "test"},
+			map[string]interface{}{"key": defaultLabel.Key, "value": defaultLabel.Value},
+		},
+		"replicationSpecs": []interface{}{
+			map[string]interface{}{
+				"numShards": 1,
+				"regionConfigs": []interface{}{
+					map[string]interface{}{
+						"providerName": "AWS",
+						"regionName":   "US_EAST_1",
+						"priority":     7,
+						"electableSpecs": map[string]interface{}{
+							"instanceSize": "M10",
+							"nodeCount":    3,
+						},
+					},
+				},
+			},
+		},
+	})
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	d.SetId(fmt.Sprintf("%s-%s", projectID, clusterName))
+
+	if _, err := r.Importer.StateContext(ctx, d, client); err != nil {
+		t.Fatalf("Bad import advanced cluster: %s", err)
+	}
+
+	if diags := r.ReadWithoutTimeout(ctx, d, client); diags.HasError() {
+		t.Fatalf("Bad read advanced cluster: %v", diags)
+	}
+
+	checks := map[string]string{
+		"name":         clusterName,
+		"cluster_type": "REPLICASET",
+		"state_name":   "IDLE",
+		"replication_specs.0.region_configs.0.provider_name":                   "AWS",
+		"replication_specs.0.region_configs.0.region_name":                     "US_EAST_1",
+		"replication_specs.0.region_configs.0.electable_specs.0.instance_size": "M10",
+	}
+	for k, want := range checks {
+		if got := fmt.Sprint(d.Get(k)); got != want {
+			t.Errorf("Bad %s: got %s, want %s", k, got, want)
+		}
+	}
+
+	// the internal label added by the provider must not leak into the state
+	if got := d.Get("labels").(*schema.Set).Len(); got != 1 {
+		t.Errorf("Bad labels: got %d, want 1", got)
+	}
+}
+
+func TestMockClusterAdvancedCluster_finalSnapshot(t *testing.T) {
+	var (
+		ctx         = context.Background()
+		mock        = newAtlasMockServer(t)
+		client      = mock.client()
+		projectID   = mock.newProject("test-mock-final-snapshot")
+		clusterName = "test-mock-cluster"
+	)
+
+	snapshotID, err := takeAdvancedClusterFinalSnapshot(ctx, client.AtlasV2, projectID, clusterName, map[string]interface{}{
+		"description":       "",
+		"retention_in_days": 3,
+	}, time.Minute)
+	if err != nil {
+		t.Fatalf("Bad final snapshot: %s", err)
+	}
+
+	snapshot := mock.get(fmt.Sprintf("%s/groups/%s/clusters/%s/backup/snapshots/%s", mockAtlasV2Path, projectID, clusterName, snapshotID))
+	if snapshot == nil {
+		t.Fatalf("final snapshot %s not found", snapshotID)
+	}
+
+	checks := map[string]string{
+		"description":     "Final snapshot of cluster " + clusterName,
+		"retentionInDays": "3",
+	}
+	for k, want := range checks {
+		if got := fmt.Sprint(snapshot[k]); got != want {
+			t.Errorf("Bad %s: got %s, want %s", k, got, want)
+		}
+	}
+
+	testMockResourceUnitTest(t, mock, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:      testMockAdvancedClusterFinalSnapshotConfig(projectID, "retain_backups_enabled = false"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`final_snapshot` requires `retain_backups_enabled` to be true"),
+			},
+			{
+				Config:      testMockAdvancedClusterFinalSnapshotConfig(projectID, ""),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`final_snapshot` requires `retain_backups_enabled` to be true"),
+			},
+			{
+				Config:      testMockAdvancedClusterFinalSnapshotConfig(projectID, "retain_backups_enabled = true\nbackup_enabled = false"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`final_snapshot` requires `backup_enabled` to be true"),
+			},
+		},
+	})
+}
+
+func testMockAdvancedClusterFinalSnapshotConfig(projectID, extra string) string {
+	return fmt.Sprintf(`
+		resource "mongodbatlas_advanced_cluster" "test" {
+			project_id   = %[1]q
+			name         = "test-mock-final-snapshot"
+			cluster_type = "REPLICASET"
+			%[2]s
+
+			final_snapshot {
+				retention_in_days = 3
+			}
+
+			replication_specs {
+				region_configs {
+					electable_specs {
+						instance_size = "M10"
+						node_count    = 3
+					}
+					provider_name = "AWS"
+					priority      = 7
+					region_name   = "US_EAST_1"
+				}
+			}
+		}
+	`, projectID, extra)
+}
@@ -0,0 +1,306 @@
+package mongodbatlas
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"go.mongodb.org/atlas-sdk/v20230201002/admin"
+)
+
+// advancedClusterElectableNodes holds the allowed totals of electable nodes of a shard.
+var advancedClusterElectableNodes = []int{3, 5, 7}
+
+// advancedClusterInstanceSizeFormats holds the format of the instance sizes of each cloud provider, the instance sizes
+// available in the regions of the project are checked against Atlas when the project is known.
+var advancedClusterInstanceSizeFormats = map[string]*regexp.Regexp{
+	"AWS":    regexp.MustCompile(`^[MR][0-9]+(_NVME)?$`),
+	"AZURE":  regexp.MustCompile(`^[MR][0-9]+(_NVME)?$`),
+	"GCP":    regexp.MustCompile(`^[MR][0-9]+$`),
+	"TENANT": regexp.MustCompile(`^M[025]$`),
+}
+
+// resourceAdvancedClusterCustomizeDiff validates the topology of the cluster at plan time, so that an invalid
+// replication_specs is reported before the cluster is created or updated. Existing clusters are only validated
+// when the topology changes, and the validation is skipped while parts of the topology are unknown.
+func resourceAdvancedClusterCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return nil
+	}
+
+	if err := validateAdvancedClusterOplogSize(rawConfig.GetAttr("advanced_configuration")); err != nil {
+		return err
+	}
+
+	if d.Id() != "" && !d.HasChanges("replication_specs", "cluster_type") {
+		return nil
+	}
+
+	if !rawConfig.GetAttr("replication_specs").IsWhollyKnown() || !rawConfig.GetAttr("cluster_type").IsWhollyKnown() {
+		return nil
+	}
+
+	replicationSpecs := expandAdvancedReplicationSpecs(d.Get("replication_specs").([]interface{}))
+	if err := validateAdvancedClusterTopology(d.Get("cluster_type").(string), replicationSpecs); err != nil {
+		return err
+	}
+
+	if client, ok := meta.(*MongoDBClient); ok && d.NewValueKnown("project_id") && d.Get("project_id").(string) != "" {
+		if err := validateAdvancedClusterInstanceSizes(ctx, client.AtlasV2, d.Get("project_id").(string), replicationSpecs); err != nil {
+			return err
+		}
+	}
+
+	if hasAdvancedClusterSpecsDiskSize(rawConfig.GetAttr("replication_specs")) {
+		if !isAdvancedClusterShardedIndependently(replicationSpecs) {
+			return fmt.Errorf("replication_specs: the `disk_size_gb` of the specs can only be set when each shard is described by its own replication_specs, " +
+				"use the `disk_size_gb` of the cluster instead")
+		}
+
+		if !rawConfig.GetAttr("disk_size_gb").IsNull() {
+			return fmt.Errorf("`disk_size_gb` conflicts with the `disk_size_gb` of the specs, set the disk size either for the whole cluster or for each shard")
+		}
+	}
+
+	return nil
+}
+
+func validateAdvancedClusterTopology(clusterType string, replicationSpecs []admin.ReplicationSpec) error {
+	shardedIndependently := isAdvancedClusterShardedIndependently(replicationSpecs)
+	if shardedIndependently && clusterType != "SHARDED" && clusterType != "GEOSHARDED" {
+		return fmt.Errorf("replication_specs: several replication_specs in the same zone can only be set for a SHARDED or GEOSHARDED cluster_type, got %s", clusterType)
+	}
+
+	for i := range replicationSpecs {
+		spec := &replicationSpecs[i]
+
+		if spec.GetNumShards() > 1 && clusterType != "SHARDED" && clusterType != "GEOSHARDED" {
+			return fmt.Errorf("replication_specs.%d: `num_shards` can only be set for a SHARDED or GEOSHARDED cluster_type, got %s", i, clusterType)
+		}
+
+		if spec.GetNumShards() > 1 && shardedIndependently {
+			return fmt.Errorf("replication_specs.%d: `num_shards` must be 1 when each shard is described by its own replication_specs, got %d", i, spec.GetNumShards())
+		}
+
+		if err := validateAdvancedClusterRegionConfigs(spec.RegionConfigs); err != nil {
+			return fmt.Errorf("replication_specs.%d.%w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func validateAdvancedClusterRegionConfigs(regionConfigs []admin.CloudRegionConfig) error {
+	var (
+		electableNodes int
+		hasElectable   bool
+		isTenant       bool
+		lastPriority   int
+	)
+
+	for i := range regionConfigs {
+		regionConfig := &regionConfigs[i]
+		providerName := regionConfig.GetProviderName()
+
+		if err := validateAdvancedClusterInstanceSizeFormats(regionConfig); err != nil {
+			return fmt.Errorf("region_configs.%d: %w", i, err)
+		}
+
+		if err := validateAdvancedClusterAutoScaling(regionConfig.AutoScaling); err != nil {
+			return fmt.Errorf("region_configs.%d.auto_scaling: %w", i, err)
+		}
+
+		if err := validateAdvancedClusterAutoScaling(regionConfig.AnalyticsAutoScaling); err != nil {
+			return fmt.Errorf("region_configs.%d.analytics_auto_scaling: %w", i, err)
+		}
+
+		if providerName == "TENANT" {
+			isTenant = true
+		}
+
+		// regions with read-only or analytics nodes only don't take part in elections.
+		if regionConfig.ElectableSpecs == nil {
+			continue
+		}
+
+		priority := regionConfig.GetPriority()
+		if hasElectable && priority >= lastPriority {
+			return fmt.Errorf("region_configs.%d: `priority` must be unique and lower than the one of the previous region, got %d after %d", i, priority, lastPriority)
+		}
+
+		electableNodes += regionConfig.ElectableSpecs.GetNodeCount()
+		hasElectable = true
+		lastPriority = priority
+	}
+
+	// shared-tier clusters have a fixed topology, and node_count can be left out when a shared-tier
+	// cluster is upgraded to a dedicated one.
+	if isTenant || electableNodes == 0 {
+		return nil
+	}
+
+	for _, v := range advancedClusterElectableNodes {
+		if electableNodes == v {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("region_configs: the total of `electable_specs.node_count` must be 3, 5 or 7, got %d", electableNodes)
+}
+
+func validateAdvancedClusterInstanceSizeFormats(regionConfig *admin.CloudRegionConfig) error {
+	providerName := regionConfig.GetProviderName()
+
+	format, ok := advancedClusterInstanceSizeFormats[providerName]
+	if !ok {
+		return fmt.Errorf("`provider_name` must be one of AWS, AZURE, GCP or TENANT, got %s", providerName)
+	}
+
+	for name, spec := range advancedClusterRegionConfigSpecs(regionConfig) {
+		if instanceSize := spec.GetInstanceSize(); instanceSize != "" && !format.MatchString(instanceSize) {
+			return fmt.Errorf("%s: `instance_size` %s isn't an instance size of %s", name, instanceSize, providerName)
+		}
+	}
+
+	return nil
+}
+
+// validateAdvancedClusterInstanceSizes checks that the instance sizes are available for their provider in the regions
+// of the project. The providers that Atlas doesn't list, e.g. the shared tier, are left to Atlas.
+func validateAdvancedClusterInstanceSizes(ctx context.Context, connV2 *admin.APIClient, projectID string, replicationSpecs []admin.ReplicationSpec) error {
+	var providerNames []string
+	for i := range replicationSpecs {
+		for j := range replicationSpecs[i].RegionConfigs {
+			if providerName := replicationSpecs[i].RegionConfigs[j].GetProviderName(); providerName != "TENANT" && !containsString(providerNames, providerName) {
+				providerNames = append(providerNames, providerName)
+			}
+		}
+	}
+
+	if len(providerNames) == 0 {
+		return nil
+	}
+
+	instanceSizes := make(map[string][]string)
+	for pageNum := 1; ; pageNum++ {
+		page, _, err := connV2.ClustersApi.ListCloudProviderRegions(ctx, projectID).Providers(providerNames).
+			PageNum(pageNum).ItemsPerPage(listItemsPerPage).Execute()
+		if err != nil {
+			log.Printf("[WARN] The instance sizes of the cluster couldn't be checked against the regions of project %s: %s", projectID, err)
+			return nil
+		}
+
+		for i := range page.Results {
+			providerName := page.Results[i].GetProvider()
+			for j := range page.Results[i].InstanceSizes {
+				instanceSizes[providerName] = append(instanceSizes[providerName], page.Results[i].InstanceSizes[j].GetName())
+			}
+		}
+
+		if len(page.Results) < listItemsPerPage {
+			break
+		}
+	}
+
+	for i := range replicationSpecs {
+		for j := range replicationSpecs[i].RegionConfigs {
+			regionConfig := &replicationSpecs[i].RegionConfigs[j]
+
+			sizes, ok := instanceSizes[regionConfig.GetProviderName()]
+			if !ok {
+				continue
+			}
+
+			for name, spec := range advancedClusterRegionConfigSpecs(regionConfig) {
+				if instanceSize := spec.GetInstanceSize(); instanceSize != "" && !containsString(sizes, instanceSize) {
+					return fmt.Errorf("replication_specs.%d.region_configs.%d.%s: `instance_size` %s isn't available for %s, use one of %s",
+						i, j, name, instanceSize, regionConfig.GetProviderName(), strings.Join(sizes, ", "))
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// advancedClusterRegionConfigSpecs returns the specs of the nodes of a region by their attribute name.
+func advancedClusterRegionConfigSpecs(regionConfig *admin.CloudRegionConfig) map[string]*admin.DedicatedHardwareSpec {
+	specs := make(map[string]*admin.DedicatedHardwareSpec)
+	if regionConfig.ElectableSpecs != nil {
+		specs["electable_specs"] = dedicatedFromHardwareSpec(regionConfig.ElectableSpecs)
+	}
+	if regionConfig.AnalyticsSpecs != nil {
+		specs["analytics_specs"] = regionConfig.AnalyticsSpecs
+	}
+	if regionConfig.ReadOnlySpecs != nil {
+		specs["read_only_specs"] = regionConfig.ReadOnlySpecs
+	}
+
+	return specs
+}
+
+// validateAdvancedClusterOplogSize rejects an `oplog_size_mb` lower than 1 of the advanced_configuration in the
+// configuration, Atlas would only reject it once the cluster is created.
+func validateAdvancedClusterOplogSize(advancedConfiguration cty.Value) error {
+	if !advancedConfiguration.IsKnown() || advancedConfiguration.IsNull() || advancedConfiguration.LengthInt() == 0 {
+		return nil
+	}
+
+	oplogSizeMB := advancedConfiguration.Index(cty.NumberIntVal(0)).GetAttr("oplog_size_mb")
+	if !oplogSizeMB.IsKnown() || oplogSizeMB.IsNull() {
+		return nil
+	}
+
+	if oplogSizeMB.AsBigFloat().Sign() <= 0 {
+		return fmt.Errorf("`advanced_configuration.oplog_size_mb` cannot be <= 0")
+	}
+
+	return nil
+}
+
+func validateAdvancedClusterAutoScaling(autoScaling *admin.AdvancedAutoScalingSettings) error {
+	if autoScaling == nil || autoScaling.Compute == nil {
+		return nil
+	}
+
+	minSize := autoScaling.Compute.GetMinInstanceSize()
+	maxSize := autoScaling.Compute.GetMaxInstanceSize()
+	if minSize == "" || maxSize == "" {
+		return nil
+	}
+
+	minTier, minOk := instanceSizeTier(minSize)
+	maxTier, maxOk := instanceSizeTier(maxSize)
+	if minOk && maxOk && minTier > maxTier {
+		return fmt.Errorf("`compute_min_instance_size` %s must not be larger than `compute_max_instance_size` %s", minSize, maxSize)
+	}
+
+	return nil
+}
+
+// instanceSizeTier returns the tier of an instance size, e.g. 30 for M30 or 40 for M40_NVME.
+func instanceSizeTier(instanceSize string) (int, bool) {
+	if len(instanceSize) < 2 {
+		return 0, false
+	}
+
+	tier, err := strconv.Atoi(strings.SplitN(instanceSize[1:], "_", 2)[0])
+
+	return tier, err == nil
+}
+
+func containsString(list []string, item string) bool {
+	for _, v := range list {
+		if v == item {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,228 @@
+package mongodbatlas
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestValidateAdvancedClusterTopology(t *testing.T) {
+	regionConfig := func(providerName, instanceSize string, nodeCount, priority int) map[string]interface{} {
+		return map[string]interface{}{
+			"provider_name": providerName,
+			"region_name":   "US_EAST_1",
+			"priority":      priority,
+			"electable_specs": []interface{}{
+				map[string]interface{}{"instance_size": instanceSize, "node_count": nodeCount},
+			},
+		}
+	}
+	replicationSpec := func(numShards int, regionConfigs ...interface{}) interface{} {
+		return map[string]interface{}{
+			"num_shards":     numShards,
+			"zone_name":      "Zone 1",
+			"region_configs": regionConfigs,
+		}
+	}
+
+	readOnly := regionConfig("AWS", "M10", 0, 0)
+	delete(readOnly, "electable_specs")
+	readOnly["read_only_specs"] = []interface{}{map[string]interface{}{"instance_size": "M10", "node_count": 2}}
+
+	autoScaling := regionConfig("AWS", "M30", 3, 7)
+	autoScaling["auto_scaling"] = []interface{}{
+		map[string]interface{}{
+			"compute_enabled":            true,
+			"compute_scale_down_enabled": true,
+			"compute_min_instance_size":  "M40",
+			"compute_max_instance_size":  "M30",
+		},
+	}
+
+	testCases := map[string]struct {
+		clusterType      string
+		replicationSpecs []interface{}
+		expectedError    string
+	}{
+		"replica set": {
+			clusterType:      "REPLICASET",
+			replicationSpecs: []interface{}{replicationSpec(1, regionConfig("AWS", "M10", 3, 7))},
+		},
+		"multi-cloud replica set with read-only region": {
+			clusterType: "REPLICASET",
+			replicationSpecs: []interface{}{
+				replicationSpec(1, regionConfig("AWS", "M10", 3, 7), regionConfig("GCP", "M10", 2, 6), readOnly),
+			},
+		},
+		"shared tier": {
+			clusterType:      "REPLICASET",
+			replicationSpecs: []interface{}{replicationSpec(1, regionConfig("TENANT", "M5", 0, 7))},
+		},
+		"sharded": {
+			clusterType:      "SHARDED",
+			replicationSpecs: []interface{}{replicationSpec(3, regionConfig("AZURE", "M30", 5, 7))},
+		},
+		"even electable nodes": {
+			clusterType:      "REPLICASET",
+			replicationSpecs: []interface{}{replicationSpec(1, regionConfig("AWS", "M10", 2, 7), regionConfig("GCP", "M10", 2, 6))},
+			expectedError:    "replication_specs.0.region_configs: the total of `electable_specs.node_count` must be 3, 5 or 7, got 4",
+		},
+		"duplicate priorities": {
+			clusterType:      "REPLICASET",
+			replicationSpecs: []interface{}{replicationSpec(1, regionConfig("AWS", "M10", 3, 7), regionConfig("GCP", "M10", 2, 7))},
+			expectedError:    "replication_specs.0.region_configs.1: `priority` must be unique and lower than the one of the previous region, got 7 after 7",
+		},
+		"ascending priorities": {
+			clusterType:      "REPLICASET",
+			replicationSpecs: []interface{}{replicationSpec(1, regionConfig("AWS", "M10", 3, 6), regionConfig("GCP", "M10", 2, 7))},
+			expectedError:    "replication_specs.0.region_configs.1: `priority` must be unique and lower than the one of the previous region, got 7 after 6",
+		},
+		"instance size in the format of the provider": {
+			// the instance sizes available in the regions of the project are checked against Atlas.
+			clusterType:      "REPLICASET",
+			replicationSpecs: []interface{}{replicationSpec(1, regionConfig("AZURE", "M100", 3, 7))},
+		},
+		"instance size of another provider": {
+			clusterType:      "REPLICASET",
+			replicationSpecs: []interface{}{replicationSpec(1, regionConfig("AWS", "M10", 3, 7), regionConfig("GCP", "M40_NVME", 2, 6))},
+			expectedError:    "replication_specs.0.region_configs.1: electable_specs: `instance_size` M40_NVME isn't an instance size of GCP",
+		},
+		"dedicated instance size of the shared tier": {
+			clusterType:      "REPLICASET",
+			replicationSpecs: []interface{}{replicationSpec(1, regionConfig("TENANT", "M10", 0, 7))},
+			expectedError:    "replication_specs.0.region_configs.0: electable_specs: `instance_size` M10 isn't an instance size of TENANT",
+		},
+		"unknown provider": {
+			clusterType:      "REPLICASET",
+			replicationSpecs: []interface{}{replicationSpec(1, regionConfig("OCI", "M10", 3, 7))},
+			expectedError:    "replication_specs.0.region_configs.0: `provider_name` must be one of AWS, AZURE, GCP or TENANT, got OCI",
+		},
+		"auto-scaling min larger than max": {
+			clusterType:      "REPLICASET",
+			replicationSpecs: []interface{}{replicationSpec(1, autoScaling)},
+			expectedError:    "replication_specs.0.region_configs.0.auto_scaling: `compute_min_instance_size` M40 must not be larger than `compute_max_instance_size` M30",
+		},
+		"num_shards for a replica set": {
+			clusterType:      "REPLICASET",
+			replicationSpecs: []interface{}{replicationSpec(2, regionConfig("AWS", "M30", 3, 7))},
+			expectedError:    "replication_specs.0: `num_shards` can only be set for a SHARDED or GEOSHARDED cluster_type, got REPLICASET",
+		},
+		"sharded independently": {
+			clusterType: "SHARDED",
+			replicationSpecs: []interface{}{
+				replicationSpec(1, regionConfig("AWS", "M30", 3, 7)),
+				replicationSpec(1, regionConfig("AWS", "M50", 3, 7)),
+			},
+		},
+		"shards of a replica set": {
+			clusterType: "REPLICASET",
+			replicationSpecs: []interface{}{
+				replicationSpec(1, regionConfig("AWS", "M30", 3, 7)),
+				replicationSpec(1, regionConfig("AWS", "M50", 3, 7)),
+			},
+			expectedError: "replication_specs: several replication_specs in the same zone can only be set for a SHARDED or GEOSHARDED cluster_type, got REPLICASET",
+		},
+		"num_shards of a shard": {
+			clusterType: "SHARDED",
+			replicationSpecs: []interface{}{
+				replicationSpec(1, regionConfig("AWS", "M30", 3, 7)),
+				replicationSpec(2, regionConfig("AWS", "M50", 3, 7)),
+			},
+			expectedError: "replication_specs.1: `num_shards` must be 1 when each shard is described by its own replication_specs, got 2",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := validateAdvancedClusterTopology(tc.clusterType, expandAdvancedReplicationSpecs(tc.replicationSpecs))
+
+			if tc.expectedError == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+
+			if err == nil || !strings.HasPrefix(err.Error(), tc.expectedError) {
+				t.Fatalf("Bad validateAdvancedClusterTopology error \n got = %v\nwant = %s", err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestMockClusterAdvancedCluster_planValidation(t *testing.T) {
+	var (
+		mock      = newAtlasMockServer(t)
+		projectID = mock.newProject("test-mock-validation")
+	)
+
+	mock.seed(fmt.Sprintf("%s/groups/%s/clusters/provider/regions", mockAtlasV2Path, projectID), map[string]interface{}{
+		"provider": "AWS",
+		"instanceSizes": []interface{}{
+			map[string]interface{}{"name": "M10", "availableRegions": []interface{}{map[string]interface{}{"name": "US_EAST_1"}}},
+			map[string]interface{}{"name": "M30", "availableRegions": []interface{}{map[string]interface{}{"name": "US_EAST_1"}}},
+		},
+	})
+
+	testMockResourceUnitTest(t, mock, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:      testMockAdvancedClusterValidationConfig(projectID, "REPLICASET", 1, 4, "M30", ""),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("the total of `electable_specs.node_count` must be 3, 5 or 7, got 4"),
+			},
+			{
+				Config:      testMockAdvancedClusterValidationConfig(projectID, "REPLICASET", 2, 3, "M30", ""),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`num_shards` can only be set for a SHARDED or GEOSHARDED cluster_type"),
+			},
+			{
+				Config:      testMockAdvancedClusterValidationConfig(projectID, "REPLICASET", 1, 3, "M40", ""),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`instance_size` M40 isn't available for AWS, use one of M10, M30"),
+			},
+			{
+				Config: testMockAdvancedClusterValidationConfig(projectID, "REPLICASET", 1, 3, "M30", `
+					advanced_configuration {
+						oplog_size_mb = 0
+					}
+				`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`advanced_configuration.oplog_size_mb` cannot be <= 0"),
+			},
+		},
+	})
+
+	if got := mock.requestCount(http.MethodPost, mockAtlasV2Path); got != 0 {
+		t.Errorf("Bad create requests: got %d, want 0", got)
+	}
+}
+
+func testMockAdvancedClusterValidationConfig(projectID, clusterType string, numShards, nodeCount int, instanceSize, extra string) string {
+	return fmt.Sprintf(`
+		resource "mongodbatlas_advanced_cluster" "test" {
+			project_id   = %[1]q
+			name         = "test-mock-validation"
+			cluster_type = %[2]q
+
+			replication_specs {
+				num_shards = %[3]d
+
+				region_configs {
+					electable_specs {
+						instance_size = %[5]q
+						node_count    = %[4]d
+					}
+					provider_name = "AWS"
+					priority      = 7
+					region_name   = "US_EAST_1"
+				}
+			}
+			%[6]s
+		}
+	`, projectID, clusterType, numShards, nodeCount, instanceSize, extra)
+}
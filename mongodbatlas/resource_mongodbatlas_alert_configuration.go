@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
 	"reflect"
 	"strings"
@@ -11,9 +12,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	"github.com/mwielbut/pointy"
 	"github.com/spf13/cast"
-	matlas "go.mongodb.org/atlas/mongodbatlas"
+	"go.mongodb.org/atlas-sdk/v20230201002/admin"
 )
 
 const (
@@ -101,6 +101,13 @@ func resourceMongoDBAtlasAlertConfiguration() *schema.Resource {
 				},
 				ConflictsWith: []string{"threshold_config"},
 				Deprecated:    fmt.Sprintf(DeprecationMessageParameterToResource, "v1.12.0", "threshold_config"),
+				ValidateFunc: func(i interface{}, k string) ([]string, []error) {
+					// a value that isn't a number yet is interpolated and checked once known.
+					if v, err := cast.ToFloat64E(i.(map[string]interface{})["threshold"]); err == nil {
+						return validateAlertConfigurationThreshold(v, k+".threshold")
+					}
+					return nil, nil
+				},
 			},
 			"metric_threshold_config": {
 				Type:          schema.TypeList,
@@ -145,8 +152,9 @@ func resourceMongoDBAtlasAlertConfiguration() *schema.Resource {
 							Optional: true,
 						},
 						"threshold": {
-							Type:     schema.TypeFloat,
-							Optional: true,
+							Type:         schema.TypeFloat,
+							Optional:     true,
+							ValidateFunc: validateAlertConfigurationThreshold,
 						},
 						"units": {
 							Type:     schema.TypeString,
@@ -294,13 +302,13 @@ func resourceMongoDBAtlasAlertConfiguration() *schema.Resource {
 }
 
 func resourceMongoDBAtlasAlertConfigurationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 
 	projectID := d.Get("project_id").(string)
 
-	req := &matlas.AlertConfiguration{
-		EventTypeName:   d.Get("event_type").(string),
-		Enabled:         pointy.Bool(d.Get("enabled").(bool)),
+	req := &admin.GroupAlertsConfig{
+		EventTypeName:   admin.PtrString(d.Get("event_type").(string)),
+		Enabled:         admin.PtrBool(d.Get("enabled").(bool)),
 		Matchers:        expandAlertConfigurationMatchers(d),
 		MetricThreshold: expandAlertConfigurationMetricThresholdConfig(d),
 		Threshold:       expandAlertConfigurationThresholdConfig(d),
@@ -312,13 +320,13 @@ func resourceMongoDBAtlasAlertConfigurationCreate(ctx context.Context, d *schema
 	}
 	req.Notifications = notifications
 
-	resp, _, err := conn.AlertConfigurations.Create(ctx, projectID, req)
+	resp, _, err := connV2.AlertConfigurationsApi.CreateAlertConfiguration(ctx, projectID, req).Execute()
 	if err != nil {
 		return diag.FromErr(fmt.Errorf(errorCreateAlertConf, err))
 	}
 
 	d.SetId(encodeStateID(map[string]string{
-		"id":         resp.ID,
+		"id":         resp.GetId(),
 		"project_id": projectID,
 	}))
 
@@ -326,10 +334,10 @@ func resourceMongoDBAtlasAlertConfigurationCreate(ctx context.Context, d *schema
 }
 
 func resourceMongoDBAtlasAlertConfigurationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 	ids := decodeStateID(d.Id())
 
-	alert, resp, err := conn.AlertConfigurations.GetAnAlertConfig(context.Background(), ids["project_id"], ids["id"])
+	alert, resp, err := connV2.AlertConfigurationsApi.GetAlertConfiguration(context.Background(), ids["project_id"], ids["id"]).Execute()
 	if err != nil {
 		// deleted in the backend case
 		if resp != nil && resp.StatusCode == http.StatusNotFound {
@@ -340,19 +348,19 @@ func resourceMongoDBAtlasAlertConfigurationRead(ctx context.Context, d *schema.R
 		return diag.FromErr(fmt.Errorf(errorReadAlertConf, err))
 	}
 
-	if err := d.Set("alert_configuration_id", alert.ID); err != nil {
+	if err := d.Set("alert_configuration_id", alert.GetId()); err != nil {
 		return diag.FromErr(fmt.Errorf(errorAlertConfSetting, "alert_configuration_id", ids["id"], err))
 	}
 
-	if err := d.Set("event_type", alert.EventTypeName); err != nil {
+	if err := d.Set("event_type", alert.GetEventTypeName()); err != nil {
 		return diag.FromErr(fmt.Errorf(errorAlertConfSetting, "event_type", ids["id"], err))
 	}
 
-	if err := d.Set("created", alert.Created); err != nil {
+	if err := d.Set("created", timeToString(alert.Created)); err != nil {
 		return diag.FromErr(fmt.Errorf(errorAlertConfSetting, "created", ids["id"], err))
 	}
 
-	if err := d.Set("updated", alert.Updated); err != nil {
+	if err := d.Set("updated", timeToString(alert.Updated)); err != nil {
 		return diag.FromErr(fmt.Errorf(errorAlertConfSetting, "updated", ids["id"], err))
 	}
 
@@ -377,21 +385,22 @@ func resourceMongoDBAtlasAlertConfigurationRead(ctx context.Context, d *schema.R
 
 func resourceMongoDBAtlasAlertConfigurationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var (
-		conn = meta.(*MongoDBClient).Atlas
-		ids  = decodeStateID(d.Id())
-		err  error
+		connV2 = meta.(*MongoDBClient).AtlasV2
+		ids    = decodeStateID(d.Id())
+		err    error
 	)
 
 	// In order to update an alert config it is necessary to send the original alert configuration request again, if not the
 	// server returns an error 500
-	req, _, err := conn.AlertConfigurations.GetAnAlertConfig(ctx, ids["project_id"], ids["id"])
+	req, _, err := connV2.AlertConfigurationsApi.GetAlertConfiguration(ctx, ids["project_id"], ids["id"]).Execute()
 	if err != nil {
 		return diag.FromErr(fmt.Errorf(errorReadAlertConf, err))
 	}
 	// Removing the computed attributes to recreate the original request
-	req.GroupID = ""
-	req.Created = ""
-	req.Updated = ""
+	req.GroupId = nil
+	req.Created = nil
+	req.Updated = nil
+	req.Links = nil
 
 	// If matchers changes ensure we are sending the information like the Terraform state
 	// because the req variable above doesn't have the "field_name" in each matcher item attribute
@@ -400,11 +409,11 @@ func resourceMongoDBAtlasAlertConfigurationUpdate(ctx context.Context, d *schema
 
 	// Only changes the updated fields
 	if d.HasChange("enabled") {
-		req.Enabled = pointy.Bool(d.Get("enabled").(bool))
+		req.Enabled = admin.PtrBool(d.Get("enabled").(bool))
 	}
 
 	if d.HasChange("event_type") {
-		req.EventTypeName = d.Get("event_type").(string)
+		req.EventTypeName = admin.PtrString(d.Get("event_type").(string))
 	}
 
 	if d.HasChange("metric_threshold") {
@@ -431,11 +440,11 @@ func resourceMongoDBAtlasAlertConfigurationUpdate(ctx context.Context, d *schema
 	req.Notifications = notifications
 
 	// Cannot enable/disable ONLY via update (if only send enable as changed field server returns a 500 error) so have to use different method to change enabled.
-	if reflect.DeepEqual(req, &matlas.AlertConfiguration{Enabled: pointy.Bool(true)}) ||
-		reflect.DeepEqual(req, &matlas.AlertConfiguration{Enabled: pointy.Bool(false)}) {
-		_, _, err = conn.AlertConfigurations.EnableAnAlertConfig(ctx, ids["project_id"], ids["id"], req.Enabled)
+	if reflect.DeepEqual(req, &admin.GroupAlertsConfig{Enabled: admin.PtrBool(true)}) ||
+		reflect.DeepEqual(req, &admin.GroupAlertsConfig{Enabled: admin.PtrBool(false)}) {
+		_, _, err = connV2.AlertConfigurationsApi.ToggleAlertConfiguration(ctx, ids["project_id"], ids["id"], &admin.AlertsToggle{Enabled: req.Enabled}).Execute()
 	} else {
-		_, _, err = conn.AlertConfigurations.Update(ctx, ids["project_id"], ids["id"], req)
+		_, _, err = connV2.AlertConfigurationsApi.UpdateAlertConfiguration(ctx, ids["project_id"], ids["id"], req).Execute()
 	}
 
 	if err != nil {
@@ -446,10 +455,10 @@ func resourceMongoDBAtlasAlertConfigurationUpdate(ctx context.Context, d *schema
 }
 
 func resourceMongoDBAtlasAlertConfigurationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 	ids := decodeStateID(d.Id())
 
-	_, err := conn.AlertConfigurations.Delete(ctx, ids["project_id"], ids["id"])
+	_, err := connV2.AlertConfigurationsApi.DeleteAlertConfiguration(ctx, ids["project_id"], ids["id"]).Execute()
 	if err != nil {
 		return diag.FromErr(fmt.Errorf(errorDeleteAlertConf, err))
 	}
@@ -458,7 +467,7 @@ func resourceMongoDBAtlasAlertConfigurationDelete(ctx context.Context, d *schema
 }
 
 func resourceMongoDBAtlasAlertConfigurationImportState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 	parts := strings.SplitN(d.Id(), "-", 2)
 
 	if len(parts) != 2 {
@@ -468,20 +477,20 @@ func resourceMongoDBAtlasAlertConfigurationImportState(ctx context.Context, d *s
 	projectID := parts[0]
 	id := parts[1]
 
-	alert, _, err := conn.AlertConfigurations.GetAnAlertConfig(ctx, projectID, id)
+	alert, _, err := connV2.AlertConfigurationsApi.GetAlertConfiguration(ctx, projectID, id).Execute()
 	if err != nil {
 		return nil, fmt.Errorf(errorImportAlertConf, id, projectID, err)
 	}
 
-	if err := d.Set("project_id", alert.GroupID); err != nil {
+	if err := d.Set("project_id", alert.GetGroupId()); err != nil {
 		return nil, fmt.Errorf(errorAlertConfSetting, "project_id", id, err)
 	}
 
-	if err := d.Set("event_type", alert.EventTypeName); err != nil {
+	if err := d.Set("event_type", alert.GetEventTypeName()); err != nil {
 		return nil, fmt.Errorf(errorAlertConfSetting, "event_type", id, err)
 	}
 
-	if err := d.Set("enabled", alert.Enabled); err != nil {
+	if err := d.Set("enabled", alert.GetEnabled()); err != nil {
 		return nil, fmt.Errorf(errorAlertConfSetting, "enabled", id, err)
 	}
 
@@ -502,15 +511,15 @@ func resourceMongoDBAtlasAlertConfigurationImportState(ctx context.Context, d *s
 	}
 
 	d.SetId(encodeStateID(map[string]string{
-		"id":         alert.ID,
+		"id":         alert.GetId(),
 		"project_id": projectID,
 	}))
 
 	return []*schema.ResourceData{d}, nil
 }
 
-func expandAlertConfigurationMatchers(d *schema.ResourceData) []matlas.Matcher {
-	matchers := make([]matlas.Matcher, 0)
+func expandAlertConfigurationMatchers(d *schema.ResourceData) []map[string]interface{} {
+	matchers := make([]map[string]interface{}, 0)
 
 	if m, ok := d.GetOk("matcher"); ok {
 		for _, value := range m.([]interface{}) {
@@ -518,10 +527,10 @@ func expandAlertConfigurationMatchers(d *schema.ResourceData) []matlas.Matcher {
 			if !ok {
 				break
 			}
-			matchers = append(matchers, matlas.Matcher{
-				FieldName: v["field_name"].(string),
-				Operator:  v["operator"].(string),
-				Value:     v["value"].(string),
+			matchers = append(matchers, map[string]interface{}{
+				"fieldName": v["field_name"].(string),
+				"operator":  v["operator"].(string),
+				"value":     v["value"].(string),
 			})
 		}
 	}
@@ -529,84 +538,88 @@ func expandAlertConfigurationMatchers(d *schema.ResourceData) []matlas.Matcher {
 	return matchers
 }
 
-func flattenAlertConfigurationMatchers(matchers []matlas.Matcher) []map[string]interface{} {
+func flattenAlertConfigurationMatchers(matchers []map[string]interface{}) []map[string]interface{} {
 	mts := make([]map[string]interface{}, len(matchers))
 
 	for i, m := range matchers {
 		mts[i] = map[string]interface{}{
-			"field_name": m.FieldName,
-			"operator":   m.Operator,
-			"value":      m.Value,
+			"field_name": cast.ToString(m["fieldName"]),
+			"operator":   cast.ToString(m["operator"]),
+			"value":      cast.ToString(m["value"]),
 		}
 	}
 
 	return mts
 }
 
-func expandAlertConfigurationMetricThreshold(d *schema.ResourceData) *matlas.MetricThreshold {
+func expandAlertConfigurationMetricThreshold(d *schema.ResourceData) *admin.ServerlessMetricThreshold {
 	if value, ok := d.GetOk("metric_threshold"); ok {
 		v := value.(map[string]interface{})
 
-		return &matlas.MetricThreshold{
-			MetricName: cast.ToString(v["metric_name"]),
-			Operator:   cast.ToString(v["operator"]),
-			Threshold:  cast.ToFloat64(v["threshold"]),
-			Units:      cast.ToString(v["units"]),
-			Mode:       cast.ToString(v["mode"]),
+		return &admin.ServerlessMetricThreshold{
+			MetricName: admin.PtrString(cast.ToString(v["metric_name"])),
+			Operator:   admin.PtrString(cast.ToString(v["operator"])),
+			Threshold:  admin.PtrFloat64(cast.ToFloat64(v["threshold"])),
+			Units:      admin.PtrString(cast.ToString(v["units"])),
+			Mode:       admin.PtrString(cast.ToString(v["mode"])),
 		}
 	}
 
 	return nil
 }
 
-func expandAlertConfigurationThreshold(d *schema.ResourceData) *matlas.Threshold {
+// validateAlertConfigurationThreshold rejects the thresholds with a fractional part at plan time, Atlas only accepts
+// integer thresholds for the alerts that aren't based on a metric.
+func validateAlertConfigurationThreshold(i interface{}, k string) (warnings []string, errs []error) {
+	v, ok := i.(float64)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be float", k)}
+	}
+
+	if v != math.Trunc(v) {
+		return nil, []error{fmt.Errorf("expected %s to be an integer, got %v", k, v)}
+	}
+
+	return nil, nil
+}
+
+// Atlas only accepts integer thresholds for the alerts that aren't based on a metric.
+func expandAlertConfigurationThreshold(d *schema.ResourceData) *admin.GreaterThanRawThreshold {
 	if value, ok := d.GetOk("threshold"); ok {
 		v := value.(map[string]interface{})
 
-		return &matlas.Threshold{
-			Operator:  cast.ToString(v["operator"]),
-			Units:     cast.ToString(v["units"]),
-			Threshold: cast.ToFloat64(v["threshold"]),
+		return &admin.GreaterThanRawThreshold{
+			Operator:  admin.PtrString(cast.ToString(v["operator"])),
+			Units:     admin.PtrString(cast.ToString(v["units"])),
+			Threshold: admin.PtrInt(int(cast.ToFloat64(v["threshold"]))),
 		}
 	}
 
 	return nil
 }
 
-func expandAlertConfigurationMetricThresholdConfig(d *schema.ResourceData) *matlas.MetricThreshold {
+func expandAlertConfigurationMetricThresholdConfig(d *schema.ResourceData) *admin.ServerlessMetricThreshold {
 	if value, ok := d.GetOk("metric_threshold_config"); ok {
 		metricThresholdConfigLists := value.([]interface{})
 
 		if len(metricThresholdConfigLists) == 1 && metricThresholdConfigLists[0] != nil {
 			v := metricThresholdConfigLists[0].(map[string]interface{})
 
-			return &matlas.MetricThreshold{
-				MetricName: cast.ToString(v["metric_name"]),
-				Operator:   cast.ToString(v["operator"]),
-				Threshold:  cast.ToFloat64(v["threshold"]),
-				Units:      cast.ToString(v["units"]),
-				Mode:       cast.ToString(v["mode"]),
+			return &admin.ServerlessMetricThreshold{
+				MetricName: admin.PtrString(cast.ToString(v["metric_name"])),
+				Operator:   admin.PtrString(cast.ToString(v["operator"])),
+				Threshold:  admin.PtrFloat64(cast.ToFloat64(v["threshold"])),
+				Units:      admin.PtrString(cast.ToString(v["units"])),
+				Mode:       admin.PtrString(cast.ToString(v["mode"])),
 			}
 		}
 	}
 
 	// Deprecated, will be removed later
-	if value, ok := d.GetOk("metric_threshold"); ok {
-		v := value.(map[string]interface{})
-
-		return &matlas.MetricThreshold{
-			MetricName: cast.ToString(v["metric_name"]),
-			Operator:   cast.ToString(v["operator"]),
-			Threshold:  cast.ToFloat64(v["threshold"]),
-			Units:      cast.ToString(v["units"]),
-			Mode:       cast.ToString(v["mode"]),
-		}
-	}
-
-	return nil
+	return expandAlertConfigurationMetricThreshold(d)
 }
 
-func expandAlertConfigurationThresholdConfig(d *schema.ResourceData) *matlas.Threshold {
+func expandAlertConfigurationThresholdConfig(d *schema.ResourceData) *admin.GreaterThanRawThreshold {
 	if value, ok := d.GetOk("threshold_config"); ok {
 		vL := value.([]interface{})
 
@@ -615,88 +628,78 @@ func expandAlertConfigurationThresholdConfig(d *schema.ResourceData) *matlas.Thr
 			if !ok {
 				return nil
 			}
-			return &matlas.Threshold{
-				Operator:  cast.ToString(v["operator"]),
-				Units:     cast.ToString(v["units"]),
-				Threshold: cast.ToFloat64(v["threshold"]),
+			return &admin.GreaterThanRawThreshold{
+				Operator:  admin.PtrString(cast.ToString(v["operator"])),
+				Units:     admin.PtrString(cast.ToString(v["units"])),
+				Threshold: admin.PtrInt(int(cast.ToFloat64(v["threshold"]))),
 			}
 		}
 	}
 
 	// Deprecated, will be removed later
-	if value, ok := d.GetOk("threshold"); ok {
-		v := value.(map[string]interface{})
-
-		return &matlas.Threshold{
-			Operator:  cast.ToString(v["operator"]),
-			Units:     cast.ToString(v["units"]),
-			Threshold: cast.ToFloat64(v["threshold"]),
-		}
-	}
-
-	return nil
+	return expandAlertConfigurationThreshold(d)
 }
 
-func flattenAlertConfigurationMetricThreshold(m *matlas.MetricThreshold) map[string]interface{} {
+func flattenAlertConfigurationMetricThreshold(m *admin.ServerlessMetricThreshold) map[string]interface{} {
 	if m != nil {
 		return map[string]interface{}{
-			"metric_name": m.MetricName,
-			"operator":    m.Operator,
-			"threshold":   cast.ToString(m.Threshold),
-			"units":       m.Units,
-			"mode":        m.Mode,
+			"metric_name": m.GetMetricName(),
+			"operator":    m.GetOperator(),
+			"threshold":   cast.ToString(m.GetThreshold()),
+			"units":       m.GetUnits(),
+			"mode":        m.GetMode(),
 		}
 	}
 
 	return map[string]interface{}{}
 }
 
-func flattenAlertConfigurationThreshold(m *matlas.Threshold) map[string]interface{} {
+func flattenAlertConfigurationThreshold(m *admin.GreaterThanRawThreshold) map[string]interface{} {
 	if m != nil {
 		return map[string]interface{}{
-			"operator":  m.Operator,
-			"units":     m.Units,
-			"threshold": cast.ToString(m.Threshold),
+			"operator":  m.GetOperator(),
+			"units":     m.GetUnits(),
+			"threshold": cast.ToString(m.GetThreshold()),
 		}
 	}
 
 	return map[string]interface{}{}
 }
 
-func flattenAlertConfigurationMetricThresholdConfig(m *matlas.MetricThreshold) []interface{} {
+func flattenAlertConfigurationMetricThresholdConfig(m *admin.ServerlessMetricThreshold) []interface{} {
 	if m != nil {
 		return []interface{}{map[string]interface{}{
-			"metric_name": m.MetricName,
-			"operator":    m.Operator,
-			"threshold":   m.Threshold,
-			"units":       m.Units,
-			"mode":        m.Mode,
+			"metric_name": m.GetMetricName(),
+			"operator":    m.GetOperator(),
+			"threshold":   m.GetThreshold(),
+			"units":       m.GetUnits(),
+			"mode":        m.GetMode(),
 		}}
 	}
 
 	return []interface{}{}
 }
 
-func flattenAlertConfigurationThresholdConfig(m *matlas.Threshold) []interface{} {
+func flattenAlertConfigurationThresholdConfig(m *admin.GreaterThanRawThreshold) []interface{} {
 	if m != nil {
 		return []interface{}{map[string]interface{}{
-			"operator":  m.Operator,
-			"units":     m.Units,
-			"threshold": m.Threshold,
+			"operator":  m.GetOperator(),
+			"units":     m.GetUnits(),
+			"threshold": float64(m.GetThreshold()),
 		}}
 	}
 
 	return []interface{}{}
 }
 
-func expandAlertConfigurationNotification(d *schema.ResourceData) ([]matlas.Notification, error) {
+func expandAlertConfigurationNotification(d *schema.ResourceData) ([]admin.AlertsNotificationRootForGroup, error) {
 	notificationCount := 0
 
 	if notifications, ok := d.GetOk("notification"); ok {
 		notificationCount = len(notifications.([]interface{}))
 	}
 
-	notifications := make([]matlas.Notification, notificationCount)
+	notifications := make([]admin.AlertsNotificationRootForGroup, notificationCount)
 
 	if notificationCount == 0 {
 		return notifications, nil
@@ -710,36 +713,39 @@ func expandAlertConfigurationNotification(d *schema.ResourceData) ([]matlas.Noti
 				return nil, fmt.Errorf(`'interval_min' doesn't need to be set if type_name is 'PAGER_DUTY', 'OPS_GENIE' or 'VICTOR_OPS'`)
 			}
 		}
-		notifications[i] = matlas.Notification{
-			APIToken:                 cast.ToString(v["api_token"]),
-			ChannelName:              cast.ToString(v["channel_name"]),
-			DatadogAPIKey:            cast.ToString(v["datadog_api_key"]),
-			DatadogRegion:            cast.ToString(v["datadog_region"]),
-			DelayMin:                 pointy.Int(v["delay_min"].(int)),
-			EmailAddress:             cast.ToString(v["email_address"]),
-			EmailEnabled:             pointy.Bool(v["email_enabled"].(bool)),
-			IntervalMin:              cast.ToInt(v["interval_min"]),
-			MobileNumber:             cast.ToString(v["mobile_number"]),
-			OpsGenieAPIKey:           cast.ToString(v["ops_genie_api_key"]),
-			OpsGenieRegion:           cast.ToString(v["ops_genie_region"]),
-			ServiceKey:               cast.ToString(v["service_key"]),
-			SMSEnabled:               pointy.Bool(v["sms_enabled"].(bool)),
-			TeamID:                   cast.ToString(v["team_id"]),
-			TypeName:                 cast.ToString(v["type_name"]),
-			Username:                 cast.ToString(v["username"]),
-			VictorOpsAPIKey:          cast.ToString(v["victor_ops_api_key"]),
-			VictorOpsRoutingKey:      cast.ToString(v["victor_ops_routing_key"]),
+		notifications[i] = admin.AlertsNotificationRootForGroup{
+			ApiToken:                 stringPtrOrNil(cast.ToString(v["api_token"])),
+			ChannelName:              stringPtrOrNil(cast.ToString(v["channel_name"])),
+			DatadogApiKey:            stringPtrOrNil(cast.ToString(v["datadog_api_key"])),
+			DatadogRegion:            stringPtrOrNil(cast.ToString(v["datadog_region"])),
+			DelayMin:                 admin.PtrInt(v["delay_min"].(int)),
+			EmailAddress:             stringPtrOrNil(cast.ToString(v["email_address"])),
+			EmailEnabled:             admin.PtrBool(v["email_enabled"].(bool)),
+			MobileNumber:             stringPtrOrNil(cast.ToString(v["mobile_number"])),
+			OpsGenieApiKey:           stringPtrOrNil(cast.ToString(v["ops_genie_api_key"])),
+			OpsGenieRegion:           stringPtrOrNil(cast.ToString(v["ops_genie_region"])),
+			ServiceKey:               stringPtrOrNil(cast.ToString(v["service_key"])),
+			SmsEnabled:               admin.PtrBool(v["sms_enabled"].(bool)),
+			TeamId:                   stringPtrOrNil(cast.ToString(v["team_id"])),
+			TypeName:                 stringPtrOrNil(cast.ToString(v["type_name"])),
+			Username:                 stringPtrOrNil(cast.ToString(v["username"])),
+			VictorOpsApiKey:          stringPtrOrNil(cast.ToString(v["victor_ops_api_key"])),
+			VictorOpsRoutingKey:      stringPtrOrNil(cast.ToString(v["victor_ops_routing_key"])),
 			Roles:                    cast.ToStringSlice(v["roles"]),
-			MicrosoftTeamsWebhookURL: cast.ToString(v["microsoft_teams_webhook_url"]),
-			WebhookSecret:            cast.ToString(v["webhook_secret"]),
-			WebhookURL:               cast.ToString(v["webhook_url"]),
+			MicrosoftTeamsWebhookUrl: stringPtrOrNil(cast.ToString(v["microsoft_teams_webhook_url"])),
+			WebhookSecret:            stringPtrOrNil(cast.ToString(v["webhook_secret"])),
+			WebhookUrl:               stringPtrOrNil(cast.ToString(v["webhook_url"])),
+		}
+
+		if intervalMin := cast.ToInt(v["interval_min"]); intervalMin > 0 {
+			notifications[i].IntervalMin = admin.PtrInt(intervalMin)
 		}
 	}
 
 	return notifications, nil
 }
 
-func flattenAlertConfigurationNotifications(d *schema.ResourceData, notifications []matlas.Notification) []map[string]interface{} {
+func flattenAlertConfigurationNotifications(d *schema.ResourceData, notifications []admin.AlertsNotificationRootForGroup) []map[string]interface{} {
 	notificationsSchema, err := expandAlertConfigurationNotification(d)
 	if err != nil {
 		return nil
@@ -747,17 +753,17 @@ func flattenAlertConfigurationNotifications(d *schema.ResourceData, notification
 
 	if len(notificationsSchema) > 0 {
 		for i := range notificationsSchema {
-			notifications[i].APIToken = notificationsSchema[i].APIToken
-			notifications[i].DatadogAPIKey = notificationsSchema[i].DatadogAPIKey
-			notifications[i].OpsGenieAPIKey = notificationsSchema[i].OpsGenieAPIKey
+			notifications[i].ApiToken = notificationsSchema[i].ApiToken
+			notifications[i].DatadogApiKey = notificationsSchema[i].DatadogApiKey
+			notifications[i].OpsGenieApiKey = notificationsSchema[i].OpsGenieApiKey
 			notifications[i].ServiceKey = notificationsSchema[i].ServiceKey
-			notifications[i].VictorOpsAPIKey = notificationsSchema[i].VictorOpsAPIKey
+			notifications[i].VictorOpsApiKey = notificationsSchema[i].VictorOpsApiKey
 			notifications[i].VictorOpsRoutingKey = notificationsSchema[i].VictorOpsRoutingKey
-			notifications[i].WebhookURL = notificationsSchema[i].WebhookURL
+			notifications[i].WebhookUrl = notificationsSchema[i].WebhookUrl
 			notifications[i].WebhookSecret = notificationsSchema[i].WebhookSecret
-			notifications[i].SMSEnabled = notificationsSchema[i].SMSEnabled
+			notifications[i].SmsEnabled = notificationsSchema[i].SmsEnabled
 			notifications[i].EmailEnabled = notificationsSchema[i].EmailEnabled
-			notifications[i].MicrosoftTeamsWebhookURL = notificationsSchema[i].MicrosoftTeamsWebhookURL
+			notifications[i].MicrosoftTeamsWebhookUrl = notificationsSchema[i].MicrosoftTeamsWebhookUrl
 		}
 	}
 
@@ -765,28 +771,28 @@ func flattenAlertConfigurationNotifications(d *schema.ResourceData, notification
 
 	for i := range notifications {
 		nts[i] = map[string]interface{}{
-			"api_token":                   notifications[i].APIToken,
-			"channel_name":                notifications[i].ChannelName,
-			"datadog_api_key":             notifications[i].DatadogAPIKey,
-			"datadog_region":              notifications[i].DatadogRegion,
-			"delay_min":                   notifications[i].DelayMin,
-			"email_address":               notifications[i].EmailAddress,
-			"email_enabled":               notifications[i].EmailEnabled,
-			"interval_min":                notifications[i].IntervalMin,
-			"mobile_number":               notifications[i].MobileNumber,
-			"ops_genie_api_key":           notifications[i].OpsGenieAPIKey,
-			"ops_genie_region":            notifications[i].OpsGenieRegion,
-			"service_key":                 notifications[i].ServiceKey,
-			"sms_enabled":                 notifications[i].SMSEnabled,
-			"team_id":                     notifications[i].TeamID,
-			"team_name":                   notifications[i].TeamName,
-			"type_name":                   notifications[i].TypeName,
-			"username":                    notifications[i].Username,
-			"victor_ops_api_key":          notifications[i].VictorOpsAPIKey,
-			"victor_ops_routing_key":      notifications[i].VictorOpsRoutingKey,
-			"microsoft_teams_webhook_url": notifications[i].MicrosoftTeamsWebhookURL,
-			"webhook_secret":              notifications[i].WebhookSecret,
-			"webhook_url":                 notifications[i].WebhookURL,
+			"api_token":                   notifications[i].GetApiToken(),
+			"channel_name":                notifications[i].GetChannelName(),
+			"datadog_api_key":             notifications[i].GetDatadogApiKey(),
+			"datadog_region":              notifications[i].GetDatadogRegion(),
+			"delay_min":                   notifications[i].GetDelayMin(),
+			"email_address":               notifications[i].GetEmailAddress(),
+			"email_enabled":               notifications[i].GetEmailEnabled(),
+			"interval_min":                notifications[i].GetIntervalMin(),
+			"mobile_number":               notifications[i].GetMobileNumber(),
+			"ops_genie_api_key":           notifications[i].GetOpsGenieApiKey(),
+			"ops_genie_region":            notifications[i].GetOpsGenieRegion(),
+			"service_key":                 notifications[i].GetServiceKey(),
+			"sms_enabled":                 notifications[i].GetSmsEnabled(),
+			"team_id":                     notifications[i].GetTeamId(),
+			"team_name":                   notifications[i].GetTeamName(),
+			"type_name":                   notifications[i].GetTypeName(),
+			"username":                    notifications[i].GetUsername(),
+			"victor_ops_api_key":          notifications[i].GetVictorOpsApiKey(),
+			"victor_ops_routing_key":      notifications[i].GetVictorOpsRoutingKey(),
+			"microsoft_teams_webhook_url": notifications[i].GetMicrosoftTeamsWebhookUrl(),
+			"webhook_secret":              notifications[i].GetWebhookSecret(),
+			"webhook_url":                 notifications[i].GetWebhookUrl(),
 		}
 
 		// We need to validate it due to the datasource haven't the roles attribute
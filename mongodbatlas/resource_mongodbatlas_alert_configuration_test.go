@@ -3,9 +3,11 @@ package mongodbatlas
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
@@ -877,3 +879,159 @@ resource "mongodbatlas_alert_configuration" "test" {
 }
 	`, orgID, projectName, enabled)
 }
+
+func TestValidateAlertConfigurationThreshold(t *testing.T) {
+	thresholdMap := resourceMongoDBAtlasAlertConfiguration().Schema["threshold"].ValidateFunc
+
+	testCases := []struct {
+		name     string
+		validate func() ([]string, []error)
+		wantErr  bool
+	}{
+		{"integer", func() ([]string, []error) { return validateAlertConfigurationThreshold(1.0, "threshold") }, false},
+		{"fractional", func() ([]string, []error) { return validateAlertConfigurationThreshold(1.5, "threshold") }, true},
+		{"map integer", func() ([]string, []error) {
+			return thresholdMap(map[string]interface{}{"operator": "GREATER_THAN", "threshold": "99"}, "threshold")
+		}, false},
+		{"map fractional", func() ([]string, []error) {
+			return thresholdMap(map[string]interface{}{"operator": "GREATER_THAN", "threshold": "99.9"}, "threshold")
+		}, true},
+		{"map without threshold", func() ([]string, []error) {
+			return thresholdMap(map[string]interface{}{"operator": "GREATER_THAN"}, "threshold")
+		}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, errs := tc.validate()
+			if got := len(errs) > 0; got != tc.wantErr {
+				t.Errorf("Bad threshold validation, got errors %v, want error %t", errs, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestMockConfigRSAlertConfiguration_basic(t *testing.T) {
+	var (
+		mock         = newAtlasMockServer(t)
+		resourceName = "mongodbatlas_alert_configuration.test"
+		orgID        = newMockObjectID()
+	)
+
+	testMockResourceUnitTest(t, mock, resource.TestCase{
+		CheckDestroy: func(s *terraform.State) error {
+			for _, rs := range s.RootModule().Resources {
+				if rs.Type != "mongodbatlas_alert_configuration" {
+					continue
+				}
+				ids := decodeStateID(rs.Primary.ID)
+				if mock.get(fmt.Sprintf("%s/groups/%s/alertConfigs/%s", mockAtlasV2Path, ids["project_id"], ids["id"])) != nil {
+					return fmt.Errorf("alert configuration (%s) still exists", ids["id"])
+				}
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongoDBAtlasAlertConfigurationConfig(orgID, "test-mock-project", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "alert_configuration_id"),
+					resource.TestCheckResourceAttr(resourceName, "notification.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "metric_threshold_config.0.metric_name", "ASSERT_REGULAR"),
+					resource.TestCheckResourceAttr(resourceName, "metric_threshold_config.0.threshold", "99"),
+					func(s *terraform.State) error {
+						projectID := s.RootModule().Resources[resourceName].Primary.Attributes["project_id"]
+						if got := mock.requestCount(http.MethodPost, mockAtlasV2Path+"/groups/"+projectID+"/alertConfigs"); got != 1 {
+							return fmt.Errorf("expected the alert configuration to be created with the Atlas SDK, got %d requests", got)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccMongoDBAtlasAlertConfigurationConfig(orgID, "test-mock-project", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "enabled", "false"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportStateIdFunc:       testAccCheckMongoDBAtlasAlertConfigurationImportStateIDFunc(resourceName),
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"project_id"},
+			},
+		},
+	})
+}
+
+func TestMockConfigRSAlertConfiguration_crud(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		mock      = newAtlasMockServer(t)
+		client    = mock.client()
+		projectID = mock.newProject("test-mock")
+		r         = resourceMongoDBAtlasAlertConfiguration()
+		config    = map[string]interface{}{
+			"project_id": projectID,
+			"event_type": "OUTSIDE_METRIC_THRESHOLD",
+			"enabled":    true,
+			"notification": []interface{}{
+				map[string]interface{}{
+					"type_name":     "GROUP",
+					"interval_min":  5,
+					"delay_min":     0,
+					"email_enabled": true,
+					"roles":         []interface{}{"GROUP_OWNER"},
+				},
+			},
+			"metric_threshold_config": []interface{}{
+				map[string]interface{}{
+					"metric_name": "ASSERT_REGULAR",
+					"operator":    "LESS_THAN",
+					"threshold":   99.0,
+					"units":       "RAW",
+					"mode":        "AVERAGE",
+				},
+			},
+		}
+	)
+
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("Bad create alert configuration: %v", diags)
+	}
+
+	alertID := d.Get("alert_configuration_id").(string)
+	if alertID == "" {
+		t.Fatal("alert_configuration_id must be set after create")
+	}
+
+	if got := d.Get("metric_threshold_config.0.threshold").(float64); got != 99.0 {
+		t.Errorf("Bad metric_threshold_config.0.threshold: got %v", got)
+	}
+
+	if got := mock.requestCount(http.MethodPost, mockAtlasV2Path+"/groups/"+projectID+"/alertConfigs"); got != 1 {
+		t.Errorf("expected the alert configuration to be created with the Atlas SDK, got %d requests", got)
+	}
+
+	config["metric_threshold_config"].([]interface{})[0].(map[string]interface{})["threshold"] = 50.0
+	updated := schema.TestResourceDataRaw(t, r.Schema, config)
+	updated.SetId(d.Id())
+
+	if diags := r.UpdateContext(ctx, updated, client); diags.HasError() {
+		t.Fatalf("Bad update alert configuration: %v", diags)
+	}
+
+	if got := updated.Get("metric_threshold_config.0.threshold").(float64); got != 50.0 {
+		t.Errorf("Bad metric_threshold_config.0.threshold after update: got %v", got)
+	}
+
+	if diags := r.DeleteContext(ctx, updated, client); diags.HasError() {
+		t.Fatalf("Bad delete alert configuration: %v", diags)
+	}
+
+	if mock.get(fmt.Sprintf("%s/groups/%s/alertConfigs/%s", mockAtlasV1Path, projectID, alertID)) != nil {
+		t.Error("alert configuration must be deleted in the mock server")
+	}
+}
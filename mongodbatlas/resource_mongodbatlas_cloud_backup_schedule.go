@@ -9,9 +9,8 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/mwielbut/pointy"
 	"github.com/spf13/cast"
-	matlas "go.mongodb.org/atlas/mongodbatlas"
+	"go.mongodb.org/atlas-sdk/v20230201002/admin"
 )
 
 const (
@@ -281,7 +280,7 @@ func resourceMongoDBAtlasCloudBackupSchedule() *schema.Resource {
 
 func resourceMongoDBAtlasCloudBackupScheduleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 	projectID := d.Get("project_id").(string)
 	clusterName := d.Get("cluster_name").(string)
 
@@ -289,7 +288,7 @@ func resourceMongoDBAtlasCloudBackupScheduleCreate(ctx context.Context, d *schem
 	// MongoDB Atlas automatically generates a default backup policy for that cluster.
 	// As a result, we need to first delete the default policies to avoid having
 	// the infrastructure differs from the TF configuration file.
-	if _, _, err := conn.CloudProviderSnapshotBackupPolicies.Delete(ctx, projectID, clusterName); err != nil {
+	if _, _, err := connV2.CloudBackupsApi.DeleteAllBackupSchedules(ctx, projectID, clusterName).Execute(); err != nil {
 		diagWarning := diag.Diagnostic{
 			Severity: diag.Warning,
 			Summary:  "Error deleting default backup schedule",
@@ -298,7 +297,7 @@ func resourceMongoDBAtlasCloudBackupScheduleCreate(ctx context.Context, d *schem
 		diags = append(diags, diagWarning)
 	}
 
-	if err := cloudBackupScheduleCreateOrUpdate(ctx, conn, d, projectID, clusterName); err != nil {
+	if err := cloudBackupScheduleCreateOrUpdate(ctx, connV2, d, projectID, clusterName); err != nil {
 		diags = append(diags, diag.Errorf(errorSnapshotBackupScheduleCreate, err)...)
 		return diags
 	}
@@ -313,38 +312,38 @@ func resourceMongoDBAtlasCloudBackupScheduleCreate(ctx context.Context, d *schem
 
 func resourceMongoDBAtlasCloudBackupScheduleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// Get client connection.
-	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 
 	ids := decodeStateID(d.Id())
 	projectID := ids["project_id"]
 	clusterName := ids["cluster_name"]
 
-	backupPolicy, _, err := conn.CloudProviderSnapshotBackupPolicies.Get(context.Background(), projectID, clusterName)
+	backupPolicy, _, err := connV2.CloudBackupsApi.GetBackupSchedule(ctx, projectID, clusterName).Execute()
 	if err != nil {
 		return diag.Errorf(errorSnapshotBackupScheduleRead, clusterName, err)
 	}
 
-	if err := d.Set("cluster_id", backupPolicy.ClusterID); err != nil {
+	if err := d.Set("cluster_id", backupPolicy.GetClusterId()); err != nil {
 		return diag.Errorf(errorSnapshotBackupScheduleSetting, "cluster_id", clusterName, err)
 	}
 
-	if err := d.Set("reference_hour_of_day", backupPolicy.ReferenceHourOfDay); err != nil {
+	if err := d.Set("reference_hour_of_day", backupPolicy.GetReferenceHourOfDay()); err != nil {
 		return diag.Errorf(errorSnapshotBackupScheduleSetting, "reference_hour_of_day", clusterName, err)
 	}
 
-	if err := d.Set("reference_minute_of_hour", backupPolicy.ReferenceMinuteOfHour); err != nil {
+	if err := d.Set("reference_minute_of_hour", backupPolicy.GetReferenceMinuteOfHour()); err != nil {
 		return diag.Errorf(errorSnapshotBackupScheduleSetting, "reference_minute_of_hour", clusterName, err)
 	}
 
-	if err := d.Set("restore_window_days", backupPolicy.RestoreWindowDays); err != nil {
+	if err := d.Set("restore_window_days", backupPolicy.GetRestoreWindowDays()); err != nil {
 		return diag.Errorf(errorSnapshotBackupScheduleSetting, "restore_window_days", clusterName, err)
 	}
 
-	if err := d.Set("next_snapshot", backupPolicy.NextSnapshot); err != nil {
+	if err := d.Set("next_snapshot", timeToString(backupPolicy.NextSnapshot)); err != nil {
 		return diag.Errorf(errorSnapshotBackupScheduleSetting, "next_snapshot", clusterName, err)
 	}
 
-	if err := d.Set("id_policy", backupPolicy.Policies[0].ID); err != nil {
+	if err := d.Set("id_policy", backupPolicy.Policies[0].GetId()); err != nil {
 		return diag.Errorf(errorSnapshotBackupScheduleSetting, "id_policy", clusterName, err)
 	}
 
@@ -352,11 +351,11 @@ func resourceMongoDBAtlasCloudBackupScheduleRead(ctx context.Context, d *schema.
 		return diag.Errorf(errorSnapshotBackupScheduleSetting, "export", clusterName, err)
 	}
 
-	if err := d.Set("auto_export_enabled", backupPolicy.AutoExportEnabled); err != nil {
+	if err := d.Set("auto_export_enabled", backupPolicy.GetAutoExportEnabled()); err != nil {
 		return diag.Errorf(errorSnapshotBackupScheduleSetting, "auto_export_enabled", clusterName, err)
 	}
 
-	if err := d.Set("use_org_and_group_names_in_export_prefix", backupPolicy.UseOrgAndGroupNamesInExportPrefix); err != nil {
+	if err := d.Set("use_org_and_group_names_in_export_prefix", backupPolicy.GetUseOrgAndGroupNamesInExportPrefix()); err != nil {
 		return diag.Errorf(errorSnapshotBackupScheduleSetting, "use_org_and_group_names_in_export_prefix", clusterName, err)
 	}
 
@@ -384,7 +383,7 @@ func resourceMongoDBAtlasCloudBackupScheduleRead(ctx context.Context, d *schema.
 }
 
 func resourceMongoDBAtlasCloudBackupScheduleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 
 	ids := decodeStateID(d.Id())
 	projectID := ids["project_id"]
@@ -396,7 +395,7 @@ func resourceMongoDBAtlasCloudBackupScheduleUpdate(ctx context.Context, d *schem
 		}
 	}
 
-	err := cloudBackupScheduleCreateOrUpdate(ctx, conn, d, projectID, clusterName)
+	err := cloudBackupScheduleCreateOrUpdate(ctx, connV2, d, projectID, clusterName)
 	if err != nil {
 		return diag.Errorf(errorSnapshotBackupScheduleUpdate, err)
 	}
@@ -406,12 +405,12 @@ func resourceMongoDBAtlasCloudBackupScheduleUpdate(ctx context.Context, d *schem
 
 func resourceMongoDBAtlasCloudBackupScheduleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// Get client connection.
-	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 	ids := decodeStateID(d.Id())
 	projectID := ids["project_id"]
 	clusterName := ids["cluster_name"]
 
-	_, _, err := conn.CloudProviderSnapshotBackupPolicies.Delete(ctx, projectID, clusterName)
+	_, _, err := connV2.CloudBackupsApi.DeleteAllBackupSchedules(ctx, projectID, clusterName).Execute()
 	if err != nil {
 		return diag.Errorf("error deleting MongoDB Cloud Backup Schedule (%s): %s", clusterName, err)
 	}
@@ -422,7 +421,7 @@ func resourceMongoDBAtlasCloudBackupScheduleDelete(ctx context.Context, d *schem
 }
 
 func resourceMongoDBAtlasCloudBackupScheduleImportState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 
 	parts := strings.SplitN(d.Id(), "-", 2)
 	if len(parts) != 2 {
@@ -432,7 +431,7 @@ func resourceMongoDBAtlasCloudBackupScheduleImportState(ctx context.Context, d *
 	projectID := parts[0]
 	clusterName := parts[1]
 
-	_, _, err := conn.CloudProviderSnapshotBackupPolicies.Get(ctx, projectID, clusterName)
+	_, _, err := connV2.CloudBackupsApi.GetBackupSchedule(ctx, projectID, clusterName).Execute()
 	if err != nil {
 		return nil, fmt.Errorf(errorSnapshotBackupScheduleRead, clusterName, err)
 	}
@@ -453,113 +452,88 @@ func resourceMongoDBAtlasCloudBackupScheduleImportState(ctx context.Context, d *
 	return []*schema.ResourceData{d}, nil
 }
 
-func cloudBackupScheduleCreateOrUpdate(ctx context.Context, conn *matlas.Client, d *schema.ResourceData, projectID, clusterName string) error {
+func cloudBackupScheduleCreateOrUpdate(ctx context.Context, connV2 *admin.APIClient, d *schema.ResourceData, projectID, clusterName string) error {
 	// Get policies items
-	resp, _, err := conn.CloudProviderSnapshotBackupPolicies.Get(ctx, projectID, clusterName)
+	resp, _, err := connV2.CloudBackupsApi.GetBackupSchedule(ctx, projectID, clusterName).Execute()
 	if err != nil {
 		log.Printf("error getting MongoDB Cloud Backup Schedule (%s): %s", clusterName, err)
 	}
 
-	req := &matlas.CloudProviderSnapshotBackupPolicy{}
-	policy := matlas.Policy{}
-	policyItem := matlas.PolicyItem{}
-	var policiesItem []matlas.PolicyItem
-	export := matlas.Export{}
+	req := &admin.DiskBackupSnapshotSchedule{}
+	policy := admin.AdvancedDiskBackupSnapshotSchedulePolicy{}
+	var policiesItem []admin.DiskBackupApiPolicyItem
 
-	req.CopySettings = []matlas.CopySetting{}
+	// an empty list, and not a nil one, so that the copy settings removed from the configuration are removed in Atlas.
+	req.CopySettings = []admin.DiskBackupCopySetting{}
 	if v, ok := d.GetOk("copy_settings"); ok && len(v.([]interface{})) > 0 {
 		req.CopySettings = expandCopySettings(v.([]interface{}))
 	}
 
 	if v, ok := d.GetOk("policy_item_hourly"); ok {
 		item := v.([]interface{})
-		itemObj := item[0].(map[string]interface{})
-		policyItem.ID = policyItemID(itemObj)
-		policyItem.FrequencyType = snapshotScheduleHourly
-		policyItem.RetentionUnit = itemObj["retention_unit"].(string)
-		policyItem.FrequencyInterval = itemObj["frequency_interval"].(int)
-		policyItem.RetentionValue = itemObj["retention_value"].(int)
-		policiesItem = append(policiesItem, policyItem)
+		policiesItem = append(policiesItem, expandPolicyItem(item[0].(map[string]interface{}), snapshotScheduleHourly))
 	}
 	if v, ok := d.GetOk("policy_item_daily"); ok {
 		item := v.([]interface{})
-		itemObj := item[0].(map[string]interface{})
-		policyItem.ID = policyItemID(itemObj)
-		policyItem.FrequencyType = snapshotScheduleDaily
-		policyItem.RetentionUnit = itemObj["retention_unit"].(string)
-		policyItem.FrequencyInterval = itemObj["frequency_interval"].(int)
-		policyItem.RetentionValue = itemObj["retention_value"].(int)
-		policiesItem = append(policiesItem, policyItem)
+		policiesItem = append(policiesItem, expandPolicyItem(item[0].(map[string]interface{}), snapshotScheduleDaily))
 	}
 	if v, ok := d.GetOk("policy_item_weekly"); ok {
 		items := v.([]interface{})
 		for _, s := range items {
-			itemObj := s.(map[string]interface{})
-			policyItem.ID = policyItemID(itemObj)
-			policyItem.FrequencyType = snapshotScheduleWeekly
-			policyItem.RetentionUnit = itemObj["retention_unit"].(string)
-			policyItem.FrequencyInterval = itemObj["frequency_interval"].(int)
-			policyItem.RetentionValue = itemObj["retention_value"].(int)
-			policiesItem = append(policiesItem, policyItem)
+			policiesItem = append(policiesItem, expandPolicyItem(s.(map[string]interface{}), snapshotScheduleWeekly))
 		}
 	}
 	if v, ok := d.GetOk("policy_item_monthly"); ok {
 		items := v.([]interface{})
 		for _, s := range items {
-			itemObj := s.(map[string]interface{})
-			policyItem.ID = policyItemID(itemObj)
-			policyItem.FrequencyType = snapshotScheduleMonthly
-			policyItem.RetentionUnit = itemObj["retention_unit"].(string)
-			policyItem.FrequencyInterval = itemObj["frequency_interval"].(int)
-			policyItem.RetentionValue = itemObj["retention_value"].(int)
-			policiesItem = append(policiesItem, policyItem)
+			policiesItem = append(policiesItem, expandPolicyItem(s.(map[string]interface{}), snapshotScheduleMonthly))
 		}
 	}
 
 	if d.HasChange("auto_export_enabled") {
-		req.AutoExportEnabled = pointy.Bool(d.Get("auto_export_enabled").(bool))
+		req.AutoExportEnabled = admin.PtrBool(d.Get("auto_export_enabled").(bool))
 	}
 
 	if v, ok := d.GetOk("export"); ok {
 		item := v.([]interface{})
 		itemObj := item[0].(map[string]interface{})
-		export.ExportBucketID = itemObj["export_bucket_id"].(string)
-		export.FrequencyType = itemObj["frequency_type"].(string)
 		req.Export = nil
 		if autoExportEnabled := d.Get("auto_export_enabled"); autoExportEnabled != nil && autoExportEnabled.(bool) {
-			req.Export = &export
+			req.Export = &admin.AutoExportPolicy{
+				ExportBucketId: admin.PtrString(itemObj["export_bucket_id"].(string)),
+				FrequencyType:  admin.PtrString(itemObj["frequency_type"].(string)),
+			}
 		}
 	}
 
 	if d.HasChange("use_org_and_group_names_in_export_prefix") {
-		req.UseOrgAndGroupNamesInExportPrefix = pointy.Bool(d.Get("use_org_and_group_names_in_export_prefix").(bool))
+		req.UseOrgAndGroupNamesInExportPrefix = admin.PtrBool(d.Get("use_org_and_group_names_in_export_prefix").(bool))
 	}
 
-	if len(resp.Policies) == 1 {
-		policy.ID = resp.Policies[0].ID
+	if resp != nil && len(resp.Policies) == 1 {
+		policy.Id = resp.Policies[0].Id
 	}
 
 	policy.PolicyItems = policiesItem
 	if len(policiesItem) > 0 {
-		req.Policies = []matlas.Policy{policy}
+		req.Policies = []admin.AdvancedDiskBackupSnapshotSchedulePolicy{policy}
 	}
 
 	if v, ok := d.GetOkExists("reference_hour_of_day"); ok {
-		req.ReferenceHourOfDay = pointy.Int64(cast.ToInt64(v))
+		req.ReferenceHourOfDay = admin.PtrInt(cast.ToInt(v))
 	}
 	if v, ok := d.GetOkExists("reference_minute_of_hour"); ok {
-		req.ReferenceMinuteOfHour = pointy.Int64(cast.ToInt64(v))
+		req.ReferenceMinuteOfHour = admin.PtrInt(cast.ToInt(v))
 	}
 	if v, ok := d.GetOkExists("restore_window_days"); ok {
-		req.RestoreWindowDays = pointy.Int64(cast.ToInt64(v))
+		req.RestoreWindowDays = admin.PtrInt(cast.ToInt(v))
 	}
 
-	value := pointy.Bool(d.Get("update_snapshots").(bool))
-	if *value {
-		req.UpdateSnapshots = value
+	if value := d.Get("update_snapshots").(bool); value {
+		req.UpdateSnapshots = admin.PtrBool(value)
 	}
 
-	_, _, err = conn.CloudProviderSnapshotBackupPolicies.Update(context.Background(), projectID, clusterName, req)
+	_, _, err = connV2.CloudBackupsApi.UpdateBackupSchedule(ctx, projectID, clusterName, req).Execute()
 	if err != nil {
 		return err
 	}
@@ -567,12 +541,22 @@ func cloudBackupScheduleCreateOrUpdate(ctx context.Context, conn *matlas.Client,
 	return nil
 }
 
-func flattenPolicyItem(items []matlas.PolicyItem, frequencyType string) []map[string]interface{} {
+func expandPolicyItem(itemObj map[string]interface{}, frequencyType string) admin.DiskBackupApiPolicyItem {
+	return admin.DiskBackupApiPolicyItem{
+		Id:                stringPtrOrNil(policyItemID(itemObj)),
+		FrequencyType:     frequencyType,
+		RetentionUnit:     itemObj["retention_unit"].(string),
+		FrequencyInterval: itemObj["frequency_interval"].(int),
+		RetentionValue:    itemObj["retention_value"].(int),
+	}
+}
+
+func flattenPolicyItem(items []admin.DiskBackupApiPolicyItem, frequencyType string) []map[string]interface{} {
 	policyItems := make([]map[string]interface{}, 0)
 	for _, v := range items {
 		if frequencyType == v.FrequencyType {
 			policyItems = append(policyItems, map[string]interface{}{
-				"id":                 v.ID,
+				"id":                 v.GetId(),
 				"frequency_interval": v.FrequencyInterval,
 				"frequency_type":     v.FrequencyType,
 				"retention_unit":     v.RetentionUnit,
@@ -584,53 +568,52 @@ func flattenPolicyItem(items []matlas.PolicyItem, frequencyType string) []map[st
 	return policyItems
 }
 
-func flattenExport(roles *matlas.CloudProviderSnapshotBackupPolicy) []map[string]interface{} {
+func flattenExport(roles *admin.DiskBackupSnapshotSchedule) []map[string]interface{} {
 	exportList := make([]map[string]interface{}, 0)
-	emptyStruct := matlas.CloudProviderSnapshotBackupPolicy{}
-	if emptyStruct.Export != roles.Export {
+	if roles.Export != nil {
 		exportList = append(exportList, map[string]interface{}{
-			"frequency_type":   roles.Export.FrequencyType,
-			"export_bucket_id": roles.Export.ExportBucketID,
+			"frequency_type":   roles.Export.GetFrequencyType(),
+			"export_bucket_id": roles.Export.GetExportBucketId(),
 		})
 	}
 	return exportList
 }
 
-func flattenCopySettings(copySettingList []matlas.CopySetting) []map[string]interface{} {
+func flattenCopySettings(copySettingList []admin.DiskBackupCopySetting) []map[string]interface{} {
 	copySettings := make([]map[string]interface{}, 0)
 	for _, v := range copySettingList {
 		copySettings = append(copySettings, map[string]interface{}{
-			"cloud_provider":      v.CloudProvider,
+			"cloud_provider":      v.GetCloudProvider(),
 			"frequencies":         v.Frequencies,
-			"region_name":         v.RegionName,
-			"replication_spec_id": v.ReplicationSpecID,
-			"should_copy_oplogs":  v.ShouldCopyOplogs,
+			"region_name":         v.GetRegionName(),
+			"replication_spec_id": v.GetReplicationSpecId(),
+			"should_copy_oplogs":  v.GetShouldCopyOplogs(),
 		})
 	}
 	return copySettings
 }
 
-func expandCopySetting(tfMap map[string]interface{}) *matlas.CopySetting {
+func expandCopySetting(tfMap map[string]interface{}) *admin.DiskBackupCopySetting {
 	if tfMap == nil {
 		return nil
 	}
 
-	copySetting := &matlas.CopySetting{
-		CloudProvider:     pointy.String(tfMap["cloud_provider"].(string)),
+	copySetting := &admin.DiskBackupCopySetting{
+		CloudProvider:     admin.PtrString(tfMap["cloud_provider"].(string)),
 		Frequencies:       expandStringList(tfMap["frequencies"].(*schema.Set).List()),
-		RegionName:        pointy.String(tfMap["region_name"].(string)),
-		ReplicationSpecID: pointy.String(tfMap["replication_spec_id"].(string)),
-		ShouldCopyOplogs:  pointy.Bool(tfMap["should_copy_oplogs"].(bool)),
+		RegionName:        admin.PtrString(tfMap["region_name"].(string)),
+		ReplicationSpecId: admin.PtrString(tfMap["replication_spec_id"].(string)),
+		ShouldCopyOplogs:  admin.PtrBool(tfMap["should_copy_oplogs"].(bool)),
 	}
 	return copySetting
 }
 
-func expandCopySettings(tfList []interface{}) []matlas.CopySetting {
+func expandCopySettings(tfList []interface{}) []admin.DiskBackupCopySetting {
 	if len(tfList) == 0 {
 		return nil
 	}
 
-	var copySettings []matlas.CopySetting
+	var copySettings []admin.DiskBackupCopySetting
 
 	for _, tfMapRaw := range tfList {
 		tfMap, ok := tfMapRaw.(map[string]interface{})
@@ -3,9 +3,11 @@ package mongodbatlas
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
@@ -917,3 +919,52 @@ func testAccCheckMongoDBAtlasCloudProviderSnapshotBackupPolicyImportStateIDFunc(
 		return fmt.Sprintf("%s-%s", ids["project_id"], ids["cluster_name"]), nil
 	}
 }
+
+func TestMockBackupRSCloudBackupSchedule_basic(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		mock      = newAtlasMockServer(t)
+		client    = mock.client()
+		projectID = mock.newProject("test-mock")
+		r         = resourceMongoDBAtlasCloudBackupSchedule()
+		config    = map[string]interface{}{
+			"project_id":               projectID,
+			"cluster_name":             "test-mock-cluster",
+			"reference_hour_of_day":    3,
+			"reference_minute_of_hour": 45,
+			"restore_window_days":      4,
+			"policy_item_hourly": []interface{}{
+				map[string]interface{}{"frequency_interval": 1, "retention_unit": "days", "retention_value": 1},
+			},
+			"policy_item_weekly": []interface{}{
+				map[string]interface{}{"frequency_interval": 4, "retention_unit": "weeks", "retention_value": 2},
+			},
+		}
+	)
+
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("Bad create cloud backup schedule: %v", diags)
+	}
+
+	schedulePath := mockAtlasV2Path + "/groups/" + projectID + "/clusters/test-mock-cluster/backup/schedule"
+	if got := mock.requestCount(http.MethodPatch, schedulePath); got != 1 {
+		t.Errorf("expected the cloud backup schedule to be updated with the Atlas SDK, got %d requests", got)
+	}
+
+	if got := d.Get("reference_minute_of_hour").(int); got != 45 {
+		t.Errorf("Bad reference_minute_of_hour: got %d", got)
+	}
+
+	if got := d.Get("policy_item_weekly.0.frequency_interval").(int); got != 4 {
+		t.Errorf("Bad policy_item_weekly frequency_interval: got %d", got)
+	}
+
+	if got := d.Get("policy_item_daily").([]interface{}); len(got) != 0 {
+		t.Errorf("Bad policy_item_daily: got %v", got)
+	}
+
+	if d.Get("id_policy").(string) == "" {
+		t.Error("id_policy must be set after create")
+	}
+}
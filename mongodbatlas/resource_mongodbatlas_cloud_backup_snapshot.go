@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/spf13/cast"
+	"go.mongodb.org/atlas-sdk/v20230201002/admin"
 	matlas "go.mongodb.org/atlas/mongodbatlas"
 )
 
@@ -120,21 +121,19 @@ func resourceMongoDBAtlasCloudBackupSnapshot() *schema.Resource {
 				},
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(1 * time.Hour),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 	}
 }
 
 func resourceMongoDBAtlasCloudBackupSnapshotRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// Get client connection.
-	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 	ids := decodeStateID(d.Id())
 
-	requestParameters := &matlas.SnapshotReqPathParameters{
-		SnapshotID:  ids["snapshot_id"],
-		GroupID:     ids["project_id"],
-		ClusterName: ids["cluster_name"],
-	}
-
-	snapshot, resp, err := conn.CloudProviderSnapshots.GetOneCloudProviderSnapshot(context.Background(), requestParameters)
+	snapshot, resp, err := getCloudBackupSnapshot(ctx, connV2, ids["project_id"], ids["cluster_name"], ids["snapshot_id"])
 	if err != nil {
 		if resp != nil && resp.StatusCode == http.StatusNotFound {
 			d.SetId("")
@@ -144,43 +143,43 @@ func resourceMongoDBAtlasCloudBackupSnapshotRead(ctx context.Context, d *schema.
 		return diag.FromErr(fmt.Errorf("error getting snapshot Information: %s", err))
 	}
 
-	if err = d.Set("snapshot_id", snapshot.ID); err != nil {
+	if err = d.Set("snapshot_id", snapshot.GetId()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `snapshot_id` for snapshot (%s): %s", ids["snapshot_id"], err))
 	}
 
-	if err = d.Set("created_at", snapshot.CreatedAt); err != nil {
+	if err = d.Set("created_at", timeToString(snapshot.CreatedAt)); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `created_at` for snapshot (%s): %s", ids["snapshot_id"], err))
 	}
 
-	if err = d.Set("expires_at", snapshot.ExpiresAt); err != nil {
+	if err = d.Set("expires_at", timeToString(snapshot.ExpiresAt)); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `expires_at` for snapshot (%s): %s", ids["snapshot_id"], err))
 	}
 
-	if err = d.Set("master_key_uuid", snapshot.MasterKeyUUID); err != nil {
+	if err = d.Set("master_key_uuid", snapshot.GetMasterKeyUUID()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `master_key_uuid` for snapshot (%s): %s", ids["snapshot_id"], err))
 	}
 
-	if err = d.Set("mongod_version", snapshot.MongodVersion); err != nil {
+	if err = d.Set("mongod_version", snapshot.GetMongodVersion()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `mongod_version` for snapshot (%s): %s", ids["snapshot_id"], err))
 	}
 
-	if err = d.Set("snapshot_type", snapshot.SnapshotType); err != nil {
+	if err = d.Set("snapshot_type", snapshot.GetSnapshotType()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `snapshot_type` for snapshot (%s): %s", ids["snapshot_id"], err))
 	}
 
-	if err = d.Set("status", snapshot.Status); err != nil {
+	if err = d.Set("status", snapshot.GetStatus()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `status` for snapshot (%s): %s", ids["snapshot_id"], err))
 	}
 
-	if err = d.Set("storage_size_bytes", snapshot.StorageSizeBytes); err != nil {
+	if err = d.Set("storage_size_bytes", snapshot.GetStorageSizeBytes()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `storage_size_bytes` for snapshot (%s): %s", ids["snapshot_id"], err))
 	}
 
-	if err = d.Set("type", snapshot.Type); err != nil {
+	if err = d.Set("type", snapshot.GetType()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `type` for snapshot (%s): %s", ids["snapshot_id"], err))
 	}
 
-	if err = d.Set("cloud_provider", snapshot.CloudProvider); err != nil {
+	if err = d.Set("cloud_provider", snapshot.GetCloudProvider()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `cloud_provider` for snapshot (%s): %s", ids["snapshot_id"], err))
 	}
 
@@ -188,11 +187,11 @@ func resourceMongoDBAtlasCloudBackupSnapshotRead(ctx context.Context, d *schema.
 		return diag.FromErr(fmt.Errorf("error setting `members` for snapshot (%s): %s", ids["snapshot_id"], err))
 	}
 
-	if err = d.Set("replica_set_name", snapshot.ReplicaSetName); err != nil {
+	if err = d.Set("replica_set_name", snapshot.GetReplicaSetName()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `replica_set_name` for snapshot (%s): %s", ids["snapshot_id"], err))
 	}
 
-	if err = d.Set("snapshot_ids", snapshot.SnapshotsIds); err != nil {
+	if err = d.Set("snapshot_ids", snapshot.SnapshotIds); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `snapshot_ids` for snapshot (%s): %s", ids["snapshot_id"], err))
 	}
 
@@ -202,22 +201,23 @@ func resourceMongoDBAtlasCloudBackupSnapshotRead(ctx context.Context, d *schema.
 func resourceMongoDBAtlasCloudBackupSnapshotCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// Get client connection.
 	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
+	projectID := d.Get("project_id").(string)
+	clusterName := d.Get("cluster_name").(string)
 
-	requestParameters := &matlas.SnapshotReqPathParameters{
-		GroupID:     d.Get("project_id").(string),
-		ClusterName: d.Get("cluster_name").(string),
+	snapshotReq := &admin.DiskBackupOnDemandSnapshotRequest{
+		Description:     admin.PtrString(d.Get("description").(string)),
+		RetentionInDays: admin.PtrInt(d.Get("retention_in_days").(int)),
 	}
 
-	snapshotReq := &matlas.CloudProviderSnapshot{
-		Description:     d.Get("description").(string),
-		RetentionInDays: d.Get("retention_in_days").(int),
-	}
+	// the wait for the cluster to be IDLE and the wait for the snapshot to complete share the timeout of the creation.
+	deadline := time.Now().Add(d.Timeout(schema.TimeoutCreate))
 
 	stateConf := &retry.StateChangeConf{
 		Pending:    []string{"CREATING", "UPDATING", "REPAIRING", "REPEATING"},
 		Target:     []string{"IDLE"},
-		Refresh:    resourceClusterRefreshFunc(ctx, d.Get("cluster_name").(string), d.Get("project_id").(string), conn),
-		Timeout:    10 * time.Minute,
+		Refresh:    resourceClusterRefreshFunc(ctx, clusterName, projectID, conn),
+		Timeout:    time.Until(deadline),
 		MinTimeout: 10 * time.Second,
 		Delay:      3 * time.Minute,
 	}
@@ -228,18 +228,16 @@ func resourceMongoDBAtlasCloudBackupSnapshotCreate(ctx context.Context, d *schem
 		return diag.FromErr(err)
 	}
 
-	snapshot, _, err := conn.CloudProviderSnapshots.Create(ctx, requestParameters, snapshotReq)
+	snapshot, _, err := connV2.CloudBackupsApi.TakeSnapshot(ctx, projectID, clusterName, snapshotReq).Execute()
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error taking a snapshot: %s", err))
 	}
 
-	requestParameters.SnapshotID = snapshot.ID
-
 	stateConf = &retry.StateChangeConf{
 		Pending:    []string{"queued", "inProgress"},
 		Target:     []string{"completed", "failed"},
-		Refresh:    resourceCloudBackupSnapshotRefreshFunc(ctx, requestParameters, conn),
-		Timeout:    1 * time.Hour,
+		Refresh:    resourceCloudBackupSnapshotRefreshFunc(ctx, connV2, projectID, clusterName, snapshot.GetId()),
+		Timeout:    time.Until(deadline),
 		MinTimeout: 60 * time.Second,
 		Delay:      1 * time.Minute,
 	}
@@ -251,9 +249,9 @@ func resourceMongoDBAtlasCloudBackupSnapshotCreate(ctx context.Context, d *schem
 	}
 
 	d.SetId(encodeStateID(map[string]string{
-		"project_id":   d.Get("project_id").(string),
-		"cluster_name": d.Get("cluster_name").(string),
-		"snapshot_id":  snapshot.ID,
+		"project_id":   projectID,
+		"cluster_name": clusterName,
+		"snapshot_id":  snapshot.GetId(),
 	}))
 
 	return resourceMongoDBAtlasCloudBackupSnapshotRead(ctx, d, meta)
@@ -261,16 +259,24 @@ func resourceMongoDBAtlasCloudBackupSnapshotCreate(ctx context.Context, d *schem
 
 func resourceMongoDBAtlasCloudBackupSnapshotDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// Get client connection.
-	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 	ids := decodeStateID(d.Id())
 
-	requestParameters := &matlas.SnapshotReqPathParameters{
-		SnapshotID:  ids["snapshot_id"],
-		GroupID:     ids["project_id"],
-		ClusterName: ids["cluster_name"],
+	_, _, err := connV2.CloudBackupsApi.DeleteReplicaSetBackup(ctx, ids["project_id"], ids["cluster_name"], ids["snapshot_id"]).Execute()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting a snapshot (%s): %s", ids["snapshot_id"], err))
 	}
 
-	_, err := conn.CloudProviderSnapshots.Delete(ctx, requestParameters)
+	stateConf := &retry.StateChangeConf{
+		Pending:    []string{"queued", "inProgress", "completed"},
+		Target:     []string{"DELETED"},
+		Refresh:    resourceCloudBackupSnapshotRefreshFunc(ctx, connV2, ids["project_id"], ids["cluster_name"], ids["snapshot_id"]),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		MinTimeout: 10 * time.Second,
+	}
+
+	// Wait, catching any errors
+	_, err = stateConf.WaitForStateContext(ctx)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error deleting a snapshot (%s): %s", ids["snapshot_id"], err))
 	}
@@ -278,36 +284,36 @@ func resourceMongoDBAtlasCloudBackupSnapshotDelete(ctx context.Context, d *schem
 	return nil
 }
 
-func resourceCloudBackupSnapshotRefreshFunc(ctx context.Context, requestParameters *matlas.SnapshotReqPathParameters, client *matlas.Client) retry.StateRefreshFunc {
+func resourceCloudBackupSnapshotRefreshFunc(ctx context.Context, connV2 *admin.APIClient, projectID, clusterName, snapshotID string) retry.StateRefreshFunc {
 	return func() (interface{}, string, error) {
-		c, resp, err := client.CloudProviderSnapshots.GetOneCloudProviderSnapshot(ctx, requestParameters)
+		c, resp, err := connV2.CloudBackupsApi.GetReplicaSetBackup(ctx, projectID, clusterName, snapshotID).Execute()
 
 		switch {
+		case resp != nil && resp.StatusCode == http.StatusNotFound:
+			return "", "DELETED", nil
 		case err != nil:
 			return nil, "failed", err
-		case resp.StatusCode == http.StatusNotFound:
-			return "", "DELETED", nil
-		case c.Status == "failed":
-			return nil, c.Status, fmt.Errorf("error creating MongoDB snapshot(%s) status was: %s", requestParameters.SnapshotID, c.Status)
+		case c.GetStatus() == "failed":
+			return nil, c.GetStatus(), fmt.Errorf("error creating MongoDB snapshot(%s) status was: %s", snapshotID, c.GetStatus())
 		}
 
-		if c.Status != "" {
-			log.Printf("[DEBUG] status for MongoDB snapshot: %s: %s", requestParameters.SnapshotID, c.Status)
+		if c.GetStatus() != "" {
+			log.Printf("[DEBUG] status for MongoDB snapshot: %s: %s", snapshotID, c.GetStatus())
 		}
 
-		return c, c.Status, nil
+		return c, c.GetStatus(), nil
 	}
 }
 
 func resourceMongoDBAtlasCloudBackupSnapshotImportState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 
 	requestParameters, err := splitSnapshotImportID(d.Id())
 	if err != nil {
 		return nil, err
 	}
 
-	u, _, err := conn.CloudProviderSnapshots.GetOneCloudProviderSnapshot(ctx, requestParameters)
+	u, _, err := connV2.CloudBackupsApi.GetReplicaSetBackup(ctx, requestParameters.GroupID, requestParameters.ClusterName, requestParameters.SnapshotID).Execute()
 	if err != nil {
 		return nil, fmt.Errorf("couldn't import snapshot %s in project %s, error: %s", requestParameters.ClusterName, requestParameters.GroupID, err)
 	}
@@ -326,7 +332,7 @@ func resourceMongoDBAtlasCloudBackupSnapshotImportState(ctx context.Context, d *
 		log.Printf("[WARN] Error setting cluster_name for (%s): %s", requestParameters.SnapshotID, err)
 	}
 
-	if err := d.Set("description", u.Description); err != nil {
+	if err := d.Set("description", u.GetDescription()); err != nil {
 		log.Printf("[WARN] Error setting description for (%s): %s", requestParameters.SnapshotID, err)
 	}
 
@@ -349,7 +355,56 @@ func splitSnapshotImportID(id string) (*matlas.SnapshotReqPathParameters, error)
 	}, nil
 }
 
-func flattenCloudMember(apiObject *matlas.Member) map[string]interface{} {
+// getCloudBackupSnapshot returns the snapshot of a replica set or of a sharded cluster.
+func getCloudBackupSnapshot(ctx context.Context, connV2 *admin.APIClient, projectID, clusterName, snapshotID string) (*admin.DiskBackupSnapshot, *http.Response, error) {
+	replicaSet, resp, err := connV2.CloudBackupsApi.GetReplicaSetBackup(ctx, projectID, clusterName, snapshotID).Execute()
+	if err != nil {
+		return nil, resp, err
+	}
+
+	snapshot, err := newCloudBackupSnapshot(ctx, connV2, projectID, clusterName, replicaSet)
+
+	return snapshot, resp, err
+}
+
+// newCloudBackupSnapshot converts a snapshot returned by the replica set endpoints, which are the only ones
+// listing the snapshots of both kinds of clusters, into a snapshot. The members and the snapshot ids of a
+// sharded cluster snapshot are only returned by the sharded cluster endpoint.
+func newCloudBackupSnapshot(ctx context.Context, connV2 *admin.APIClient, projectID, clusterName string, replicaSet *admin.DiskBackupReplicaSet) (*admin.DiskBackupSnapshot, error) {
+	snapshot := &admin.DiskBackupSnapshot{
+		CloudProvider:    replicaSet.CloudProvider,
+		CopyRegions:      replicaSet.CopyRegions,
+		CreatedAt:        replicaSet.CreatedAt,
+		Description:      replicaSet.Description,
+		ExpiresAt:        replicaSet.ExpiresAt,
+		FrequencyType:    replicaSet.FrequencyType,
+		Id:               replicaSet.Id,
+		MasterKeyUUID:    replicaSet.MasterKeyUUID,
+		MongodVersion:    replicaSet.MongodVersion,
+		PolicyItems:      replicaSet.PolicyItems,
+		ReplicaSetName:   replicaSet.ReplicaSetName,
+		SnapshotType:     replicaSet.SnapshotType,
+		Status:           replicaSet.Status,
+		StorageSizeBytes: replicaSet.StorageSizeBytes,
+		Type:             replicaSet.Type,
+	}
+
+	if replicaSet.GetType() != "shardedCluster" {
+		return snapshot, nil
+	}
+
+	shardedCluster, _, err := connV2.CloudBackupsApi.GetShardedClusterBackup(ctx, projectID, clusterName, replicaSet.GetId()).Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot.Members = shardedCluster.Members
+	snapshot.SnapshotIds = shardedCluster.SnapshotIds
+
+	return snapshot, nil
+}
+
+func flattenCloudMember(apiObject *admin.DiskBackupShardedClusterSnapshotMember) map[string]interface{} {
 	if apiObject == nil {
 		return nil
 	}
@@ -357,25 +412,21 @@ func flattenCloudMember(apiObject *matlas.Member) map[string]interface{} {
 	tfMap := map[string]interface{}{}
 
 	tfMap["cloud_provider"] = apiObject.CloudProvider
-	tfMap["id"] = apiObject.ID
+	tfMap["id"] = apiObject.Id
 	tfMap["replica_set_name"] = apiObject.ReplicaSetName
 
 	return tfMap
 }
 
-func flattenCloudMembers(apiObjects []*matlas.Member) []interface{} {
+func flattenCloudMembers(apiObjects []admin.DiskBackupShardedClusterSnapshotMember) []interface{} {
 	if len(apiObjects) == 0 {
 		return nil
 	}
 
 	var tfList []interface{}
 
-	for _, apiObject := range apiObjects {
-		if apiObject == nil {
-			continue
-		}
-
-		tfList = append(tfList, flattenCloudMember(apiObject))
+	for i := range apiObjects {
+		tfList = append(tfList, flattenCloudMember(&apiObjects[i]))
 	}
 
 	return tfList
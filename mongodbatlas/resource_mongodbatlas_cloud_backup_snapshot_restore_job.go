@@ -326,7 +326,7 @@ func resourceMongoDBAtlasCloudBackupSnapshotRestoreJobDelete(ctx context.Context
 	}
 
 	if shouldDelete {
-		_, err := conn.CloudProviderSnapshotRestoreJobs.Delete(context.Background(), requestParameters)
+		_, err := conn.CloudProviderSnapshotRestoreJobs.Delete(ctx, requestParameters)
 		if err != nil {
 			return diag.FromErr(fmt.Errorf("error deleting a cloudProviderSnapshotRestoreJob (%s): %s", ids["snapshot_restore_job_id"], err))
 		}
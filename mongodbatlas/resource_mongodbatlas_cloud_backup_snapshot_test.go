@@ -4,10 +4,14 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-test/deep"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
@@ -207,3 +211,116 @@ func TestResourceMongoDBAtlasCloudBackupSnapshot_snapshotID(t *testing.T) {
 		t.Error("splitSnapshotImportID expected to have error")
 	}
 }
+
+func TestMockBackupRSCloudBackupSnapshot_basic(t *testing.T) {
+	var (
+		ctx         = context.Background()
+		mock        = newAtlasMockServer(t)
+		client      = mock.client()
+		projectID   = mock.newProject("test-mock")
+		clusterName = "test-mock-cluster"
+		r           = resourceMongoDBAtlasCloudBackupSnapshot()
+	)
+
+	snapshot := mock.seed(fmt.Sprintf("%s/groups/%s/clusters/%s/backup/snapshots", mockAtlasV2Path, projectID, clusterName), map[string]interface{}{
+		"id":               newMockObjectID(),
+		"createdAt":        "2023-05-04T10:11:12Z",
+		"description":      "test-mock-snapshot",
+		"status":           "completed",
+		"storageSizeBytes": 1024,
+		"type":             "shardedCluster",
+		"members": []interface{}{
+			map[string]interface{}{"cloudProvider": "AWS", "id": newMockObjectID(), "replicaSetName": "shard-0"},
+		},
+		"snapshotIds": []interface{}{"5cf5a45a9ccf6400e60981b7"},
+	})
+	snapshotID := fmt.Sprint(snapshot["id"])
+
+	d := r.TestResourceData()
+	d.SetId(encodeStateID(map[string]string{
+		"project_id":   projectID,
+		"cluster_name": clusterName,
+		"snapshot_id":  snapshotID,
+	}))
+
+	if diags := r.ReadContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("Bad read cloud backup snapshot: %v", diags)
+	}
+
+	if got := d.Get("created_at").(string); got != "2023-05-04T10:11:12Z" {
+		t.Errorf("Bad created_at: got %q", got)
+	}
+
+	if got := d.Get("members.0.replica_set_name").(string); got != "shard-0" {
+		t.Errorf("Bad members of a sharded cluster snapshot: got replica set %q", got)
+	}
+
+	if got := d.Get("snapshot_ids.#").(int); got != 1 {
+		t.Errorf("Bad snapshot_ids of a sharded cluster snapshot: got %d ids", got)
+	}
+
+	ds := dataSourceMongoDBAtlasCloudBackupSnapshots()
+	dd := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{
+		"project_id":   projectID,
+		"cluster_name": clusterName,
+	})
+
+	if diags := ds.ReadContext(ctx, dd, client); diags.HasError() {
+		t.Fatalf("Bad read cloud backup snapshots: %v", diags)
+	}
+
+	if got := dd.Get("results.0.storage_size_bytes").(int); got != 1024 {
+		t.Errorf("Bad storage_size_bytes: got %d", got)
+	}
+
+	if got := dd.Get("results.0.members.#").(int); got != 1 {
+		t.Errorf("Bad members of a listed sharded cluster snapshot: got %d members", got)
+	}
+
+	if diags := r.DeleteContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("Bad delete cloud backup snapshot: %v", diags)
+	}
+
+	if got := mock.requestCount(http.MethodDelete, fmt.Sprintf("%s/groups/%s/clusters/%s/backup/snapshots/%s", mockAtlasV2Path, projectID, clusterName, snapshotID)); got != 1 {
+		t.Errorf("expected the snapshot to be deleted with the Atlas SDK, got %d requests", got)
+	}
+}
+
+func TestMockBackupRSCloudBackupSnapshot_deleteTimeout(t *testing.T) {
+	var (
+		ctx          = context.Background()
+		mock         = newAtlasMockServer(t)
+		client       = mock.client()
+		projectID    = mock.newProject("test-mock")
+		clusterName  = "test-mock-cluster"
+		snapshotPath = fmt.Sprintf("%s/groups/%s/clusters/%s/backup/snapshots", mockAtlasV2Path, projectID, clusterName)
+		r            = resourceMongoDBAtlasCloudBackupSnapshot()
+	)
+
+	snapshot := mock.seed(snapshotPath, map[string]interface{}{
+		"id":          newMockObjectID(),
+		"description": "test-mock-snapshot",
+		"status":      "completed",
+	})
+	snapshotID := fmt.Sprint(snapshot["id"])
+
+	// Atlas accepts the deletion but never removes the snapshot.
+	mock.failNext(http.MethodDelete, snapshotPath, http.StatusAccepted, 1, "")
+
+	r.Timeouts = &schema.ResourceTimeout{Delete: schema.DefaultTimeout(time.Second)}
+	d := r.Data(nil)
+	d.SetId(encodeStateID(map[string]string{
+		"project_id":   projectID,
+		"cluster_name": clusterName,
+		"snapshot_id":  snapshotID,
+	}))
+
+	start := time.Now()
+	diags := r.DeleteContext(ctx, d, client)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "timeout while waiting") {
+		t.Fatalf("expected the delete timeout to stop the wait, got %v", diags)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("the delete timeout wasn't honored, the wait took %s", elapsed)
+	}
+}
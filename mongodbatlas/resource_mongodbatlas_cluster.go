@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -34,6 +35,10 @@ const (
 
 var defaultLabel = matlas.Label{Key: "Infrastructure Tool", Value: "MongoDB Atlas Terraform Provider"}
 
+// clusterUpdateDelay is the time Atlas is given to start updating or upgrading a cluster before its state is polled,
+// the mock server tests shorten it.
+var clusterUpdateDelay = 1 * time.Minute
+
 func resourceMongoDBAtlasCluster() *schema.Resource {
 	return &schema.Resource{
 		CreateWithoutTimeout: resourceMongoDBAtlasClusterCreate,
@@ -346,6 +351,7 @@ func resourceMongoDBAtlasCluster() *schema.Resource {
 					},
 				},
 			},
+			"labels_all":             labelsAllSchema(),
 			"snapshot_backup_policy": computedCloudProviderSnapshotBackupPolicySchema(),
 			"termination_protection_enabled": {
 				Type:     schema.TypeBool,
@@ -363,7 +369,7 @@ func resourceMongoDBAtlasCluster() *schema.Resource {
 				ValidateFunc: validation.StringInSlice([]string{"LTS", "CONTINUOUS"}, false),
 			},
 		},
-		CustomizeDiff: resourceClusterCustomizeDiff,
+		CustomizeDiff: customdiff.Sequence(resourceClusterCustomizeDiff, customizeDiffDefaultLabels),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(3 * time.Hour),
 			Update: schema.DefaultTimeout(3 * time.Hour),
@@ -524,7 +530,7 @@ func resourceMongoDBAtlasClusterCreate(ctx context.Context, d *schema.ResourceDa
 		return diag.FromErr(fmt.Errorf("you should not set `Infrastructure Tool` label, it is used for internal purposes"))
 	}
 
-	clusterRequest.Labels = append(expandLabelSliceFromSetSchema(d), defaultLabel)
+	clusterRequest.Labels = append(expandLabelsWithDefaults(d, meta), defaultLabel)
 
 	if v, ok := d.GetOk("disk_size_gb"); ok {
 		clusterRequest.DiskSizeGB = pointy.Float64(v.(float64))
@@ -752,7 +758,7 @@ func resourceMongoDBAtlasClusterRead(ctx context.Context, d *schema.ResourceData
 		return diag.FromErr(fmt.Errorf(errorClusterSetting, "replication_factor", clusterName, err))
 	}
 
-	if err := d.Set("labels", flattenLabels(removeLabel(cluster.Labels, defaultLabel))); err != nil {
+	if err := setLabelsWithDefaults(d, meta, removeLabel(cluster.Labels, defaultLabel)); err != nil {
 		return diag.FromErr(fmt.Errorf(errorClusterSetting, "labels", clusterName, err))
 	}
 
@@ -913,12 +919,12 @@ func resourceMongoDBAtlasClusterUpdate(ctx context.Context, d *schema.ResourceDa
 		cluster.TerminationProtectionEnabled = pointy.Bool(d.Get("termination_protection_enabled").(bool))
 	}
 
-	if d.HasChange("labels") {
+	if d.HasChange("labels") || d.HasChange("labels_all") {
 		if containsLabelOrKey(expandLabelSliceFromSetSchema(d), defaultLabel) {
 			return diag.FromErr(fmt.Errorf("you should not set `Infrastructure Tool` label, it is used for internal purposes"))
 		}
 
-		cluster.Labels = append(expandLabelSliceFromSetSchema(d), defaultLabel)
+		cluster.Labels = append(expandLabelsWithDefaults(d, meta), defaultLabel)
 	}
 
 	// when Provider instance type changes this argument must be passed explicitly in patch request
@@ -1812,7 +1818,7 @@ func upgradeCluster(ctx context.Context, conn *matlas.Client, request *matlas.Cl
 		Refresh:    resourceClusterRefreshFunc(ctx, name, projectID, conn),
 		Timeout:    timeout,
 		MinTimeout: 30 * time.Second,
-		Delay:      1 * time.Minute,
+		Delay:      clusterUpdateDelay,
 	}
 
 	// Wait, catching any errors
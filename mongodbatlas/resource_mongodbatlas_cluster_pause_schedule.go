@@ -0,0 +1,564 @@
+package mongodbatlas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mwielbut/pointy"
+	"go.mongodb.org/realm/realm"
+)
+
+const (
+	errorClusterPauseScheduleCreate  = "error creating MongoDB Cluster Pause Schedule (%s): %s"
+	errorClusterPauseScheduleRead    = "error reading MongoDB Cluster Pause Schedule (%s): %s"
+	errorClusterPauseScheduleUpdate  = "error updating MongoDB Cluster Pause Schedule (%s): %s"
+	errorClusterPauseScheduleDelete  = "error deleting MongoDB Cluster Pause Schedule (%s): %s"
+	errorClusterPauseScheduleSetting = "error setting `%s` for MongoDB Cluster Pause Schedule (%s): %s"
+
+	realmFunctionsPath = "groups/%s/apps/%s/functions"
+	realmSecretsPath   = "groups/%s/apps/%s/secrets"
+	realmValuesPath    = "groups/%s/apps/%s/values"
+
+	clusterPauseScheduleMediaType = "application/vnd.atlas.2023-02-01+json"
+)
+
+var cronScheduleRegexp = regexp.MustCompile(`^\S+( \S+){4}$`)
+
+// realmFunction, realmSecret and realmValue are the App Services functions, secrets and values
+// the realm client doesn't support yet.
+type realmFunction struct {
+	ID      string `json:"_id,omitempty"`
+	Name    string `json:"name,omitempty"`
+	Source  string `json:"source,omitempty"`
+	Private bool   `json:"private"`
+}
+
+type realmSecret struct {
+	ID    string `json:"_id,omitempty"`
+	Name  string `json:"name,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+type realmValue struct {
+	ID         string `json:"_id,omitempty"`
+	Name       string `json:"name,omitempty"`
+	Value      string `json:"value,omitempty"`
+	FromSecret bool   `json:"from_secret"`
+	Private    bool   `json:"private"`
+}
+
+func resourceMongoDBAtlasClusterPauseSchedule() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceMongoDBAtlasClusterPauseScheduleCreate,
+		ReadContext:   resourceMongoDBAtlasClusterPauseScheduleRead,
+		UpdateContext: resourceMongoDBAtlasClusterPauseScheduleUpdate,
+		DeleteContext: resourceMongoDBAtlasClusterPauseScheduleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceMongoDBAtlasClusterPauseScheduleImportState,
+		},
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"app_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"cluster_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"pause_schedule": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringMatch(cronScheduleRegexp, "must be a CRON expression with 5 fields"),
+			},
+			"resume_schedule": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringMatch(cronScheduleRegexp, "must be a CRON expression with 5 fields"),
+			},
+			"public_key": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"private_key": {
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+			"disabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"pause_trigger_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"resume_trigger_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"pause_function_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"resume_function_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"secret_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"value_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceMongoDBAtlasClusterPauseScheduleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(*MongoDBClient).GetRealmClient(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	projectID := d.Get("project_id").(string)
+	appID := d.Get("app_id").(string)
+	clusterName := d.Get("cluster_name").(string)
+	names := clusterPauseScheduleNames(clusterName)
+
+	d.SetId(encodeStateID(map[string]string{
+		"project_id":   projectID,
+		"app_id":       appID,
+		"cluster_name": clusterName,
+	}))
+
+	// the objects of a schedule dropped from the state because one of its triggers was deleted outside of
+	// Terraform are still there and would collide with the ones created below.
+	if err := deleteClusterPauseScheduleLeftovers(ctx, conn, projectID, appID, names); err != nil {
+		d.SetId("")
+		return diag.FromErr(fmt.Errorf(errorClusterPauseScheduleCreate, clusterName, err))
+	}
+
+	// every object is saved as soon as it's created, so that a failed create can be cleaned up by a destroy.
+	secret, _, err := createRealmSecret(ctx, conn, projectID, appID, &realmSecret{Name: names["secret"], Value: d.Get("private_key").(string)})
+	if err != nil {
+		d.SetId("")
+		return diag.FromErr(fmt.Errorf(errorClusterPauseScheduleCreate, clusterName, err))
+	}
+	if err := d.Set("secret_id", secret.ID); err != nil {
+		return diag.FromErr(fmt.Errorf(errorClusterPauseScheduleSetting, "secret_id", clusterName, err))
+	}
+
+	value, _, err := createRealmValue(ctx, conn, projectID, appID, &realmValue{Name: names["value"], Value: names["secret"], FromSecret: true, Private: true})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf(errorClusterPauseScheduleCreate, clusterName, err))
+	}
+	if err := d.Set("value_id", value.ID); err != nil {
+		return diag.FromErr(fmt.Errorf(errorClusterPauseScheduleSetting, "value_id", clusterName, err))
+	}
+
+	for _, action := range []string{"pause", "resume"} {
+		function, _, err := createRealmFunction(ctx, conn, projectID, appID, expandClusterPauseScheduleFunction(d, meta, action))
+		if err != nil {
+			return diag.FromErr(fmt.Errorf(errorClusterPauseScheduleCreate, clusterName, err))
+		}
+		if err := d.Set(action+"_function_id", function.ID); err != nil {
+			return diag.FromErr(fmt.Errorf(errorClusterPauseScheduleSetting, action+"_function_id", clusterName, err))
+		}
+
+		trigger, _, err := conn.EventTriggers.Create(ctx, projectID, appID, expandClusterPauseScheduleTrigger(d, action))
+		if err != nil {
+			return diag.FromErr(fmt.Errorf(errorClusterPauseScheduleCreate, clusterName, err))
+		}
+		if err := d.Set(action+"_trigger_id", trigger.ID); err != nil {
+			return diag.FromErr(fmt.Errorf(errorClusterPauseScheduleSetting, action+"_trigger_id", clusterName, err))
+		}
+	}
+
+	return resourceMongoDBAtlasClusterPauseScheduleRead(ctx, d, meta)
+}
+
+func resourceMongoDBAtlasClusterPauseScheduleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(*MongoDBClient).GetRealmClient(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ids := decodeStateID(d.Id())
+	projectID := ids["project_id"]
+	appID := ids["app_id"]
+	clusterName := ids["cluster_name"]
+
+	triggers := make(map[string]*realm.EventTrigger)
+	for _, action := range []string{"pause", "resume"} {
+		trigger, resp, err := conn.EventTriggers.Get(ctx, projectID, appID, d.Get(action+"_trigger_id").(string))
+		if err != nil {
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				d.SetId("")
+				return nil
+			}
+
+			return diag.FromErr(fmt.Errorf(errorClusterPauseScheduleRead, clusterName, err))
+		}
+
+		triggers[action] = trigger
+	}
+
+	if err := d.Set("project_id", projectID); err != nil {
+		return diag.FromErr(fmt.Errorf(errorClusterPauseScheduleSetting, "project_id", clusterName, err))
+	}
+	if err := d.Set("app_id", appID); err != nil {
+		return diag.FromErr(fmt.Errorf(errorClusterPauseScheduleSetting, "app_id", clusterName, err))
+	}
+	if err := d.Set("cluster_name", clusterName); err != nil {
+		return diag.FromErr(fmt.Errorf(errorClusterPauseScheduleSetting, "cluster_name", clusterName, err))
+	}
+	if err := d.Set("pause_schedule", triggers["pause"].Config.Schedule); err != nil {
+		return diag.FromErr(fmt.Errorf(errorClusterPauseScheduleSetting, "pause_schedule", clusterName, err))
+	}
+	if err := d.Set("resume_schedule", triggers["resume"].Config.Schedule); err != nil {
+		return diag.FromErr(fmt.Errorf(errorClusterPauseScheduleSetting, "resume_schedule", clusterName, err))
+	}
+	if err := d.Set("disabled", pointy.BoolValue(triggers["pause"].Disabled, false)); err != nil {
+		return diag.FromErr(fmt.Errorf(errorClusterPauseScheduleSetting, "disabled", clusterName, err))
+	}
+	if err := d.Set("pause_function_id", triggers["pause"].FunctionID); err != nil {
+		return diag.FromErr(fmt.Errorf(errorClusterPauseScheduleSetting, "pause_function_id", clusterName, err))
+	}
+	if err := d.Set("resume_function_id", triggers["resume"].FunctionID); err != nil {
+		return diag.FromErr(fmt.Errorf(errorClusterPauseScheduleSetting, "resume_function_id", clusterName, err))
+	}
+
+	return nil
+}
+
+func resourceMongoDBAtlasClusterPauseScheduleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(*MongoDBClient).GetRealmClient(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ids := decodeStateID(d.Id())
+	projectID := ids["project_id"]
+	appID := ids["app_id"]
+	clusterName := ids["cluster_name"]
+	names := clusterPauseScheduleNames(clusterName)
+
+	if d.HasChange("private_key") {
+		secret := &realmSecret{ID: d.Get("secret_id").(string), Name: names["secret"], Value: d.Get("private_key").(string)}
+		if _, err := updateRealmSecret(ctx, conn, projectID, appID, secret); err != nil {
+			return diag.FromErr(fmt.Errorf(errorClusterPauseScheduleUpdate, clusterName, err))
+		}
+	}
+
+	for _, action := range []string{"pause", "resume"} {
+		if d.HasChange("public_key") {
+			function := expandClusterPauseScheduleFunction(d, meta, action)
+			function.ID = d.Get(action + "_function_id").(string)
+			if _, err := updateRealmFunction(ctx, conn, projectID, appID, function); err != nil {
+				return diag.FromErr(fmt.Errorf(errorClusterPauseScheduleUpdate, clusterName, err))
+			}
+		}
+
+		if d.HasChanges(action+"_schedule", "disabled") {
+			triggerID := d.Get(action + "_trigger_id").(string)
+			if _, _, err := conn.EventTriggers.Update(ctx, projectID, appID, triggerID, expandClusterPauseScheduleTrigger(d, action)); err != nil {
+				return diag.FromErr(fmt.Errorf(errorClusterPauseScheduleUpdate, clusterName, err))
+			}
+		}
+	}
+
+	return resourceMongoDBAtlasClusterPauseScheduleRead(ctx, d, meta)
+}
+
+func resourceMongoDBAtlasClusterPauseScheduleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(*MongoDBClient).GetRealmClient(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ids := decodeStateID(d.Id())
+	projectID := ids["project_id"]
+	appID := ids["app_id"]
+	clusterName := ids["cluster_name"]
+
+	// the triggers use the functions, which use the value of the secret.
+	for _, action := range []string{"pause", "resume"} {
+		if triggerID := d.Get(action + "_trigger_id").(string); triggerID != "" {
+			if resp, err := conn.EventTriggers.Delete(ctx, projectID, appID, triggerID); err != nil && !isRealmNotFound(resp) {
+				return diag.FromErr(fmt.Errorf(errorClusterPauseScheduleDelete, clusterName, err))
+			}
+		}
+	}
+
+	objects := []struct {
+		path string
+		id   string
+	}{
+		{realmFunctionsPath, d.Get("pause_function_id").(string)},
+		{realmFunctionsPath, d.Get("resume_function_id").(string)},
+		{realmValuesPath, d.Get("value_id").(string)},
+		{realmSecretsPath, d.Get("secret_id").(string)},
+	}
+
+	for _, object := range objects {
+		if object.id == "" {
+			continue
+		}
+
+		if resp, err := deleteRealmObject(ctx, conn, object.path, projectID, appID, object.id); err != nil && !isRealmNotFound(resp) {
+			return diag.FromErr(fmt.Errorf(errorClusterPauseScheduleDelete, clusterName, err))
+		}
+	}
+
+	return nil
+}
+
+func resourceMongoDBAtlasClusterPauseScheduleImportState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	conn, err := meta.(*MongoDBClient).GetRealmClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.Split(d.Id(), "--")
+	if len(parts) != 3 {
+		return nil, errors.New("import format error: to import a MongoDB Cluster Pause Schedule, use the format {project_id}--{app_id}--{cluster_name}")
+	}
+
+	projectID, appID, clusterName := parts[0], parts[1], parts[2]
+	names := clusterPauseScheduleNames(clusterName)
+
+	triggers, _, err := conn.EventTriggers.List(ctx, projectID, appID)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't import the pause schedule of cluster %s in project %s, error: %s", clusterName, projectID, err)
+	}
+
+	for _, action := range []string{"pause", "resume"} {
+		var triggerID string
+		for i := range triggers {
+			if triggers[i].Name == names[action] {
+				triggerID = triggers[i].ID
+			}
+		}
+
+		if triggerID == "" {
+			return nil, fmt.Errorf("couldn't import the pause schedule of cluster %s in project %s, the trigger %s doesn't exist", clusterName, projectID, names[action])
+		}
+
+		if err := d.Set(action+"_trigger_id", triggerID); err != nil {
+			return nil, fmt.Errorf(errorClusterPauseScheduleSetting, action+"_trigger_id", clusterName, err)
+		}
+	}
+
+	var secrets []realmSecret
+	if _, err := listRealmObjects(ctx, conn, realmSecretsPath, projectID, appID, &secrets); err != nil {
+		return nil, fmt.Errorf("couldn't import the pause schedule of cluster %s in project %s, error: %s", clusterName, projectID, err)
+	}
+	for i := range secrets {
+		if secrets[i].Name == names["secret"] {
+			if err := d.Set("secret_id", secrets[i].ID); err != nil {
+				return nil, fmt.Errorf(errorClusterPauseScheduleSetting, "secret_id", clusterName, err)
+			}
+		}
+	}
+
+	var values []realmValue
+	if _, err := listRealmObjects(ctx, conn, realmValuesPath, projectID, appID, &values); err != nil {
+		return nil, fmt.Errorf("couldn't import the pause schedule of cluster %s in project %s, error: %s", clusterName, projectID, err)
+	}
+	for i := range values {
+		if values[i].Name == names["value"] {
+			if err := d.Set("value_id", values[i].ID); err != nil {
+				return nil, fmt.Errorf(errorClusterPauseScheduleSetting, "value_id", clusterName, err)
+			}
+		}
+	}
+
+	d.SetId(encodeStateID(map[string]string{
+		"project_id":   projectID,
+		"app_id":       appID,
+		"cluster_name": clusterName,
+	}))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// clusterPauseScheduleNames returns the names of the App Services objects of the pause schedule of a cluster.
+// App Services names can't have hyphens, the hash of the cluster name keeps e.g. dev-cluster and dev_cluster apart.
+func clusterPauseScheduleNames(clusterName string) map[string]string {
+	name := fmt.Sprintf("%s_%08x", strings.ReplaceAll(clusterName, "-", "_"), crc32.ChecksumIEEE([]byte(clusterName)))
+
+	return map[string]string{
+		"pause":  "pause_" + name,
+		"resume": "resume_" + name,
+		"secret": "pause_schedule_" + name + "_private_key",
+		"value":  "pause_schedule_" + name + "_private_key",
+	}
+}
+
+// deleteClusterPauseScheduleLeftovers deletes the triggers, functions, value and secret named after the pause schedule
+// of the cluster, in the same order as a destroy.
+func deleteClusterPauseScheduleLeftovers(ctx context.Context, conn *realm.Client, projectID, appID string, names map[string]string) error {
+	triggers, _, err := conn.EventTriggers.List(ctx, projectID, appID)
+	if err != nil {
+		return err
+	}
+
+	for i := range triggers {
+		if triggers[i].Name != names["pause"] && triggers[i].Name != names["resume"] {
+			continue
+		}
+
+		if resp, err := conn.EventTriggers.Delete(ctx, projectID, appID, triggers[i].ID); err != nil && !isRealmNotFound(resp) {
+			return err
+		}
+	}
+
+	leftovers := []struct {
+		path  string
+		names []string
+	}{
+		{realmFunctionsPath, []string{names["pause"], names["resume"]}},
+		{realmValuesPath, []string{names["value"]}},
+		{realmSecretsPath, []string{names["secret"]}},
+	}
+
+	for _, leftover := range leftovers {
+		// functions, values and secrets all have an _id and a name.
+		var objects []realmSecret
+		if _, err := listRealmObjects(ctx, conn, leftover.path, projectID, appID, &objects); err != nil {
+			return err
+		}
+
+		for i := range objects {
+			for _, name := range leftover.names {
+				if objects[i].Name != name {
+					continue
+				}
+
+				if resp, err := deleteRealmObject(ctx, conn, leftover.path, projectID, appID, objects[i].ID); err != nil && !isRealmNotFound(resp) {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func expandClusterPauseScheduleTrigger(d *schema.ResourceData, action string) *realm.EventTriggerRequest {
+	return &realm.EventTriggerRequest{
+		Name:       clusterPauseScheduleNames(d.Get("cluster_name").(string))[action],
+		Type:       "SCHEDULED",
+		FunctionID: d.Get(action + "_function_id").(string),
+		Disabled:   pointy.Bool(d.Get("disabled").(bool)),
+		Config: &realm.EventTriggerConfig{
+			Schedule: d.Get(action + "_schedule").(string),
+		},
+	}
+}
+
+func expandClusterPauseScheduleFunction(d *schema.ResourceData, meta interface{}, action string) *realmFunction {
+	baseURL := defaultAtlasBaseURL
+	if config := meta.(*MongoDBClient).Config; config != nil && config.BaseURL != "" {
+		baseURL = config.BaseURL
+	}
+
+	clusterName := d.Get("cluster_name").(string)
+	names := clusterPauseScheduleNames(clusterName)
+
+	return &realmFunction{
+		Name:    names[action],
+		Private: true,
+		Source:  clusterPauseScheduleFunctionSource(baseURL, d.Get("project_id").(string), clusterName, d.Get("public_key").(string), names["value"], action == "pause"),
+	}
+}
+
+// clusterPauseScheduleFunctionSource returns the source of the function pausing or resuming the cluster
+// through the Atlas Administration API, authenticated with the API key of the pause schedule.
+func clusterPauseScheduleFunctionSource(baseURL, projectID, clusterName, publicKey, privateKeyValue string, paused bool) string {
+	url := fmt.Sprintf("%sapi/atlas/v2/groups/%s/clusters/%s", strings.TrimSuffix(baseURL, "/")+"/", projectID, clusterName)
+
+	return fmt.Sprintf(`exports = async function() {
+  const response = await context.http.patch({
+    url: %[1]q,
+    username: %[2]q,
+    password: context.values.get(%[3]q),
+    digestAuth: true,
+    headers: { "Accept": [%[6]q], "Content-Type": [%[6]q] },
+    body: JSON.stringify({ paused: %[4]t }),
+  });
+
+  if (response.statusCode !== 200) {
+    throw new Error("error updating cluster %[5]s: " + response.body.text());
+  }
+};
+`, url, publicKey, privateKeyValue, paused, clusterName, clusterPauseScheduleMediaType)
+}
+
+func createRealmFunction(ctx context.Context, conn *realm.Client, projectID, appID string, function *realmFunction) (*realmFunction, *realm.Response, error) {
+	root := new(realmFunction)
+	resp, err := doRealmRequest(ctx, conn, http.MethodPost, fmt.Sprintf(realmFunctionsPath, projectID, appID), function, root)
+
+	return root, resp, err
+}
+
+func updateRealmFunction(ctx context.Context, conn *realm.Client, projectID, appID string, function *realmFunction) (*realm.Response, error) {
+	return doRealmRequest(ctx, conn, http.MethodPut, fmt.Sprintf(realmFunctionsPath, projectID, appID)+"/"+function.ID, function, nil)
+}
+
+func createRealmSecret(ctx context.Context, conn *realm.Client, projectID, appID string, secret *realmSecret) (*realmSecret, *realm.Response, error) {
+	root := new(realmSecret)
+	resp, err := doRealmRequest(ctx, conn, http.MethodPost, fmt.Sprintf(realmSecretsPath, projectID, appID), secret, root)
+
+	return root, resp, err
+}
+
+func updateRealmSecret(ctx context.Context, conn *realm.Client, projectID, appID string, secret *realmSecret) (*realm.Response, error) {
+	return doRealmRequest(ctx, conn, http.MethodPut, fmt.Sprintf(realmSecretsPath, projectID, appID)+"/"+secret.ID, secret, nil)
+}
+
+func createRealmValue(ctx context.Context, conn *realm.Client, projectID, appID string, value *realmValue) (*realmValue, *realm.Response, error) {
+	root := new(realmValue)
+	resp, err := doRealmRequest(ctx, conn, http.MethodPost, fmt.Sprintf(realmValuesPath, projectID, appID), value, root)
+
+	return root, resp, err
+}
+
+func listRealmObjects(ctx context.Context, conn *realm.Client, path, projectID, appID string, v interface{}) (*realm.Response, error) {
+	return doRealmRequest(ctx, conn, http.MethodGet, fmt.Sprintf(path, projectID, appID), nil, v)
+}
+
+func deleteRealmObject(ctx context.Context, conn *realm.Client, path, projectID, appID, id string) (*realm.Response, error) {
+	return doRealmRequest(ctx, conn, http.MethodDelete, fmt.Sprintf(path, projectID, appID)+"/"+id, nil, nil)
+}
+
+func doRealmRequest(ctx context.Context, conn *realm.Client, method, path string, body, v interface{}) (*realm.Response, error) {
+	req, err := conn.NewRequest(ctx, method, path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return conn.Do(ctx, req, v)
+}
+
+func isRealmNotFound(resp *realm.Response) bool {
+	return resp != nil && resp.Response != nil && resp.StatusCode == http.StatusNotFound
+}
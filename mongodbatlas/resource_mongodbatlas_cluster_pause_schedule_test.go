@@ -0,0 +1,263 @@
+package mongodbatlas
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestClusterPauseScheduleNames(t *testing.T) {
+	expected := map[string]string{
+		"pause":  "pause_dev_cluster_2527e050",
+		"resume": "resume_dev_cluster_2527e050",
+		"secret": "pause_schedule_dev_cluster_2527e050_private_key",
+		"value":  "pause_schedule_dev_cluster_2527e050_private_key",
+	}
+
+	got := clusterPauseScheduleNames("dev-cluster")
+	if diff := deep.Equal(expected, got); diff != nil {
+		t.Fatalf("Bad clusterPauseScheduleNames return \n got = %#v\nwant = %#v \ndiff = %#v", got, expected, diff)
+	}
+
+	// the hyphens are replaced, the names of clusters differing by a hyphen must not collide.
+	if clusterPauseScheduleNames("dev-cluster")["pause"] == clusterPauseScheduleNames("dev_cluster")["pause"] {
+		t.Error("the names of the dev-cluster and dev_cluster pause schedules collide")
+	}
+}
+
+func TestClusterPauseScheduleFunctionSource(t *testing.T) {
+	source := clusterPauseScheduleFunctionSource("https://cloud.mongodb.com", "project-id", "dev-cluster", "public-key", "private_key_value", true)
+
+	for _, want := range []string{
+		`url: "https://cloud.mongodb.com/api/atlas/v2/groups/project-id/clusters/dev-cluster"`,
+		`"Accept": ["application/vnd.atlas.2023-02-01+json"]`,
+		`username: "public-key"`,
+		`password: context.values.get("private_key_value")`,
+		`body: JSON.stringify({ paused: true })`,
+	} {
+		if !strings.Contains(source, want) {
+			t.Errorf("Bad function source, expected it to contain %s:\n%s", want, source)
+		}
+	}
+}
+
+func TestAccConfigRSClusterPauseSchedule_basic(t *testing.T) {
+	SkipTestForCI(t)
+	var (
+		resourceName = "mongodbatlas_cluster_pause_schedule.test"
+		projectID    = os.Getenv("MONGODB_ATLAS_PROJECT_ID")
+		appID        = os.Getenv("MONGODB_REALM_APP_ID")
+		clusterName  = acctest.RandomWithPrefix("test-acc")
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheckBasic(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckMongoDBAtlasClusterPauseScheduleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongoDBAtlasClusterPauseScheduleConfig(projectID, appID, clusterName, "0 20 * * 1-5", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMongoDBAtlasClusterPauseScheduleExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "pause_schedule", "0 20 * * 1-5"),
+					resource.TestCheckResourceAttr(resourceName, "resume_schedule", "0 7 * * 1-5"),
+					resource.TestCheckResourceAttr(resourceName, "disabled", "false"),
+					resource.TestCheckResourceAttrSet(resourceName, "pause_function_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "resume_function_id"),
+				),
+			},
+			{
+				Config: testAccMongoDBAtlasClusterPauseScheduleConfig(projectID, appID, clusterName, "0 22 * * 1-5", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMongoDBAtlasClusterPauseScheduleExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "pause_schedule", "0 22 * * 1-5"),
+					resource.TestCheckResourceAttr(resourceName, "disabled", "true"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportStateId:           fmt.Sprintf("%s--%s--%s", projectID, appID, clusterName),
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"public_key", "private_key"},
+			},
+		},
+	})
+}
+
+func testAccCheckMongoDBAtlasClusterPauseScheduleExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn, err := testAccProvider.Meta().(*MongoDBClient).GetRealmClient(context.Background())
+		if err != nil {
+			return err
+		}
+
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("not found: %s", resourceName)
+		}
+
+		ids := decodeStateID(rs.Primary.ID)
+		for _, action := range []string{"pause", "resume"} {
+			if _, _, err := conn.EventTriggers.Get(context.Background(), ids["project_id"], ids["app_id"], rs.Primary.Attributes[action+"_trigger_id"]); err != nil {
+				return fmt.Errorf("%s trigger of cluster (%s) does not exist: %s", action, ids["cluster_name"], err)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckMongoDBAtlasClusterPauseScheduleDestroy(s *terraform.State) error {
+	conn, err := testAccProvider.Meta().(*MongoDBClient).GetRealmClient(context.Background())
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "mongodbatlas_cluster_pause_schedule" {
+			continue
+		}
+
+		ids := decodeStateID(rs.Primary.ID)
+		for _, action := range []string{"pause", "resume"} {
+			if _, _, err := conn.EventTriggers.Get(context.Background(), ids["project_id"], ids["app_id"], rs.Primary.Attributes[action+"_trigger_id"]); err == nil {
+				return fmt.Errorf("%s trigger of cluster (%s) still exists", action, ids["cluster_name"])
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccMongoDBAtlasClusterPauseScheduleConfig(projectID, appID, clusterName, pauseSchedule string, disabled bool) string {
+	return fmt.Sprintf(`
+		resource "mongodbatlas_project_api_key" "test" {
+			description = "Pause schedule of %[3]s"
+			project_id  = %[1]q
+
+			project_assignment {
+				project_id = %[1]q
+				role_names = ["GROUP_CLUSTER_MANAGER"]
+			}
+		}
+
+		resource "mongodbatlas_cluster_pause_schedule" "test" {
+			project_id      = %[1]q
+			app_id          = %[2]q
+			cluster_name    = %[3]q
+			pause_schedule  = %[4]q
+			resume_schedule = "0 7 * * 1-5"
+			public_key      = mongodbatlas_project_api_key.test.public_key
+			private_key     = mongodbatlas_project_api_key.test.private_key
+			disabled        = %[5]t
+		}
+	`, projectID, appID, clusterName, pauseSchedule, disabled)
+}
+
+func TestMockConfigRSClusterPauseSchedule_crud(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		mock      = newAtlasMockServer(t)
+		client    = mock.client()
+		projectID = mock.newProject("test-mock")
+		appID     = "test-mock-app"
+		r         = resourceMongoDBAtlasClusterPauseSchedule()
+		config    = map[string]interface{}{
+			"project_id":      projectID,
+			"app_id":          appID,
+			"cluster_name":    "test-mock-cluster",
+			"pause_schedule":  "0 20 * * 1-5",
+			"resume_schedule": "0 7 * * 1-5",
+			"public_key":      "test-mock-public-key",
+			"private_key":     "test-mock-private-key",
+		}
+	)
+
+	conn, err := client.GetRealmClient(ctx)
+	if err != nil {
+		t.Fatalf("Bad realm client: %s", err)
+	}
+
+	// countObjects returns how many triggers, functions, values and secrets the app has.
+	countObjects := func() map[string]int {
+		t.Helper()
+
+		triggers, _, err := conn.EventTriggers.List(ctx, projectID, appID)
+		if err != nil {
+			t.Fatalf("Bad list triggers: %s", err)
+		}
+
+		counts := map[string]int{"triggers": len(triggers)}
+		for name, path := range map[string]string{"functions": realmFunctionsPath, "values": realmValuesPath, "secrets": realmSecretsPath} {
+			var objects []realmSecret
+			if _, err := listRealmObjects(ctx, conn, path, projectID, appID, &objects); err != nil {
+				t.Fatalf("Bad list %s: %s", name, err)
+			}
+			counts[name] = len(objects)
+		}
+
+		return counts
+	}
+	expected := map[string]int{"triggers": 2, "functions": 2, "values": 1, "secrets": 1}
+
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("Bad create cluster pause schedule: %v", diags)
+	}
+	if got := d.Get("pause_schedule").(string); got != "0 20 * * 1-5" {
+		t.Errorf("Bad pause_schedule after create: got %s", got)
+	}
+	if diff := deep.Equal(expected, countObjects()); diff != nil {
+		t.Fatalf("Bad objects after create: %v", diff)
+	}
+
+	// a trigger deleted outside of Terraform drops the schedule from the state, the next create must replace
+	// the objects left behind instead of colliding with their names.
+	if _, err := conn.EventTriggers.Delete(ctx, projectID, appID, d.Get("pause_trigger_id").(string)); err != nil {
+		t.Fatalf("Bad delete trigger: %s", err)
+	}
+	if diags := r.ReadContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("Bad read cluster pause schedule: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Fatalf("expected the cluster pause schedule to be removed from the state, got ID %s", d.Id())
+	}
+
+	d = schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("Bad create cluster pause schedule after the trigger was deleted: %v", diags)
+	}
+	if diff := deep.Equal(expected, countObjects()); diff != nil {
+		t.Fatalf("Bad objects after the schedule was created again: %v", diff)
+	}
+
+	imported := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	imported.SetId(fmt.Sprintf("%s--%s--%s", projectID, appID, "test-mock-cluster"))
+	if _, err := r.Importer.StateContext(ctx, imported, client); err != nil {
+		t.Fatalf("Bad import cluster pause schedule: %s", err)
+	}
+	if diags := r.ReadContext(ctx, imported, client); diags.HasError() {
+		t.Fatalf("Bad read imported cluster pause schedule: %v", diags)
+	}
+	for _, attr := range []string{"pause_trigger_id", "resume_trigger_id", "pause_function_id", "resume_function_id", "secret_id", "value_id", "resume_schedule"} {
+		if got, want := imported.Get(attr), d.Get(attr); got != want {
+			t.Errorf("Bad imported %s: got %v, want %v", attr, got, want)
+		}
+	}
+
+	if diags := r.DeleteContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("Bad delete cluster pause schedule: %v", diags)
+	}
+	if diff := deep.Equal(map[string]int{"triggers": 0, "functions": 0, "values": 0, "secrets": 0}, countObjects()); diff != nil {
+		t.Fatalf("Bad objects after delete: %v", diff)
+	}
+}
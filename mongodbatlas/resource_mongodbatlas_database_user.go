@@ -4,14 +4,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 	"net/http"
 	"regexp"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	matlas "go.mongodb.org/atlas/mongodbatlas"
+	"go.mongodb.org/atlas-sdk/v20230201002/admin"
 )
 
 func resourceMongoDBAtlasDatabaseUser() *schema.Resource {
@@ -23,6 +26,11 @@ func resourceMongoDBAtlasDatabaseUser() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceMongoDBAtlasDatabaseUserImportState,
 		},
+		CustomizeDiff: customdiff.Sequence(
+			customizeDiffDefaultLabels,
+			resourceDatabaseUserDeleteAfterDateCustomizeDiff,
+			resourceDatabaseUserPasswordGeneratorCustomizeDiff,
+		),
 		Schema: map[string]*schema.Schema{
 			"project_id": {
 				Type:     schema.TypeString,
@@ -51,6 +59,26 @@ func resourceMongoDBAtlasDatabaseUser() *schema.Resource {
 				Sensitive:     true,
 				ConflictsWith: []string{"x509_type", "ldap_auth_type", "aws_iam_type"},
 			},
+			"password_wo": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: suppressWriteOnly,
+				ConflictsWith:    []string{"password", "x509_type", "ldap_auth_type", "aws_iam_type"},
+				// password_version is the only change applying a new password_wo, see suppressWriteOnly.
+				RequiredWith: []string{"password_version"},
+			},
+			"password_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"password_wo"},
+			},
+			"password_generator": databaseUserPasswordGeneratorSchema(),
+			"generated_password": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
 			"x509_type": {
 				Type:         schema.TypeString,
 				Optional:     true,
@@ -117,6 +145,13 @@ func resourceMongoDBAtlasDatabaseUser() *schema.Resource {
 					},
 				},
 			},
+			"delete_after_date": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validation.IsRFC3339Time,
+				DiffSuppressFunc: suppressEquivalentRFC3339Time,
+			},
+			"labels_all": labelsAllSchema(),
 			"scopes": {
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -139,7 +174,7 @@ func resourceMongoDBAtlasDatabaseUser() *schema.Resource {
 
 func resourceMongoDBAtlasDatabaseUserRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// Get client connection.
-	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 	ids := decodeStateID(d.Id())
 	projectID := ids["project_id"]
 	username := ids["username"]
@@ -153,12 +188,24 @@ func resourceMongoDBAtlasDatabaseUserRead(ctx context.Context, d *schema.Resourc
 		}
 	}
 
-	dbUser, resp, err := conn.DatabaseUsers.Get(context.Background(), authDatabaseName, projectID, username)
+	dbUser, resp, err := connV2.DatabaseUsersApi.GetDatabaseUser(ctx, projectID, authDatabaseName, username).Execute()
 	if err != nil {
 		// case 404
 		// deleted in the backend case
 		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			deleteAfterDate := d.Get("delete_after_date").(string)
 			d.SetId("")
+
+			if isDatabaseUserExpired(deleteAfterDate) {
+				log.Printf("[INFO] database user %s expired on %s, removing it from the state", username, deleteAfterDate)
+				return diag.Diagnostics{{
+					Severity: diag.Warning,
+					Summary:  fmt.Sprintf("Database user %q expired", username),
+					Detail: fmt.Sprintf("Atlas deleted the database user %s of project %s on its delete_after_date (%s). "+
+						"Set a later delete_after_date to create it again or remove the resource.", username, projectID, deleteAfterDate),
+				}}
+			}
+
 			return nil
 		}
 
@@ -179,19 +226,19 @@ func resourceMongoDBAtlasDatabaseUserRead(ctx context.Context, d *schema.Resourc
 		}
 	}
 
-	if err := d.Set("x509_type", dbUser.X509Type); err != nil {
+	if err := d.Set("x509_type", dbUser.GetX509Type()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `x509_type` for database user (%s): %s", d.Id(), err))
 	}
 
-	if err := d.Set("aws_iam_type", dbUser.AWSIAMType); err != nil {
+	if err := d.Set("aws_iam_type", dbUser.GetAwsIAMType()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `aws_iam_type` for database user (%s): %s", d.Id(), err))
 	}
 
-	if err := d.Set("oidc_auth_type", dbUser.OIDCAuthType); err != nil {
+	if err := d.Set("oidc_auth_type", dbUser.GetOidcAuthType()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `oidc_auth_type` for database user (%s): %s", d.Id(), err))
 	}
 
-	if err := d.Set("ldap_auth_type", dbUser.LDAPAuthType); err != nil {
+	if err := d.Set("ldap_auth_type", dbUser.GetLdapAuthType()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `ldap_auth_type` for database user (%s): %s", d.Id(), err))
 	}
 
@@ -199,7 +246,7 @@ func resourceMongoDBAtlasDatabaseUserRead(ctx context.Context, d *schema.Resourc
 		return diag.FromErr(fmt.Errorf("error setting `roles` for database user (%s): %s", d.Id(), err))
 	}
 
-	if err := d.Set("labels", flattenLabels(dbUser.Labels)); err != nil {
+	if err := setLabelsWithDefaults(d, meta, fromComponentLabels(dbUser.Labels)); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `labels` for database user (%s): %s", d.Id(), err))
 	}
 
@@ -207,6 +254,10 @@ func resourceMongoDBAtlasDatabaseUserRead(ctx context.Context, d *schema.Resourc
 		return diag.FromErr(fmt.Errorf("error setting `scopes` for database user (%s): %s", d.Id(), err))
 	}
 
+	if err := d.Set("delete_after_date", timeToString(dbUser.DeleteAfterDate)); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting `delete_after_date` for database user (%s): %s", d.Id(), err))
+	}
+
 	d.SetId(encodeStateID(map[string]string{
 		"project_id":         projectID,
 		"username":           username,
@@ -218,7 +269,7 @@ func resourceMongoDBAtlasDatabaseUserRead(ctx context.Context, d *schema.Resourc
 
 func resourceMongoDBAtlasDatabaseUserCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// Get client connection.
-	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 	projectID := d.Get("project_id").(string)
 
 	dbName, dbNameOk := d.GetOk("database_name")
@@ -234,25 +285,52 @@ func resourceMongoDBAtlasDatabaseUserCreate(ctx context.Context, d *schema.Resou
 		authDatabaseName = authDBName.(string)
 	}
 
-	dbUserReq := &matlas.DatabaseUser{
+	dbUserReq := &admin.CloudDatabaseUser{
 		Roles:        expandRoles(d),
-		GroupID:      projectID,
+		GroupId:      projectID,
 		Username:     d.Get("username").(string),
-		Password:     d.Get("password").(string),
-		X509Type:     d.Get("x509_type").(string),
-		AWSIAMType:   d.Get("aws_iam_type").(string),
-		OIDCAuthType: d.Get("oidc_auth_type").(string),
-		LDAPAuthType: d.Get("ldap_auth_type").(string),
+		X509Type:     stringPtrOrNil(d.Get("x509_type").(string)),
+		AwsIAMType:   stringPtrOrNil(d.Get("aws_iam_type").(string)),
+		OidcAuthType: stringPtrOrNil(d.Get("oidc_auth_type").(string)),
+		LdapAuthType: stringPtrOrNil(d.Get("ldap_auth_type").(string)),
 		DatabaseName: authDatabaseName,
-		Labels:       expandLabelSliceFromSetSchema(d),
+		Labels:       toComponentLabels(expandLabelsWithDefaults(d, meta)),
 		Scopes:       expandScopes(d),
 	}
 
-	dbUserRes, _, err := conn.DatabaseUsers.Create(ctx, projectID, dbUserReq)
+	if password := d.Get("password").(string); password != "" {
+		dbUserReq.Password = &password
+	}
+
+	if password := getWriteOnlyString(d, "password_wo"); password != "" {
+		dbUserReq.Password = &password
+	}
+
+	var generatedPassword string
+	if generator := expandPasswordGenerator(d); generator != nil {
+		password, err := generator.generate()
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error creating database user: %s", err))
+		}
+		generatedPassword = password
+		dbUserReq.Password = &generatedPassword
+	}
+
+	if v, ok := d.GetOk("delete_after_date"); ok {
+		dbUserReq.DeleteAfterDate = parseDeleteAfterDate(v.(string))
+	}
+
+	dbUserRes, _, err := connV2.DatabaseUsersApi.CreateDatabaseUser(ctx, projectID, dbUserReq).Execute()
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error creating database user: %s", err))
 	}
 
+	if generatedPassword != "" {
+		if err := d.Set("generated_password", generatedPassword); err != nil {
+			return diag.FromErr(fmt.Errorf("error setting `generated_password` for database user (%s): %s", dbUserRes.Username, err))
+		}
+	}
+
 	d.SetId(encodeStateID(map[string]string{
 		"project_id":         projectID,
 		"username":           dbUserRes.Username,
@@ -264,51 +342,87 @@ func resourceMongoDBAtlasDatabaseUserCreate(ctx context.Context, d *schema.Resou
 
 func resourceMongoDBAtlasDatabaseUserUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// Get client connection.
-	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 	ids := decodeStateID(d.Id())
 	projectID := ids["project_id"]
 	username := ids["username"]
 	authDatabaseName := ids["auth_database_name"]
 
-	dbUser, _, err := conn.DatabaseUsers.Get(ctx, authDatabaseName, projectID, username)
+	dbUser, _, err := connV2.DatabaseUsersApi.GetDatabaseUser(ctx, projectID, authDatabaseName, username).Execute()
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error getting database user information to update it: %s", err))
 	}
 
 	if d.HasChange("password") {
-		dbUser.Password = d.Get("password").(string)
+		dbUser.Password = admin.PtrString(d.Get("password").(string))
+	}
+
+	// password_wo is never stored in the state, its changes are applied when password_version changes.
+	if d.HasChange("password_version") {
+		password := getWriteOnlyString(d, "password_wo")
+		if password == "" {
+			return diag.FromErr(fmt.Errorf("error updating database user(%s): `password_version` changed but `password_wo` is not set", username))
+		}
+		dbUser.Password = &password
+	}
+
+	// the password is regenerated when the password_generator block is added or one of its keepers changes.
+	var generatedPassword *string
+	if generator := expandPasswordGenerator(d); generator != nil && isPasswordGeneratorRotated(d) {
+		password, err := generator.generate()
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error updating database user(%s): %s", username, err))
+		}
+		dbUser.Password = &password
+		generatedPassword = &password
 	}
 
 	if d.HasChange("roles") {
 		dbUser.Roles = expandRoles(d)
 	}
 
-	if d.HasChange("labels") {
-		dbUser.Labels = expandLabelSliceFromSetSchema(d)
+	if d.HasChange("labels") || d.HasChange("labels_all") {
+		dbUser.Labels = toComponentLabels(expandLabelsWithDefaults(d, meta))
 	}
 
 	if d.HasChange("scopes") {
 		dbUser.Scopes = expandScopes(d)
 	}
 
-	_, _, err = conn.DatabaseUsers.Update(ctx, projectID, username, dbUser)
+	// removing delete_after_date replaces the user, see resourceDatabaseUserDeleteAfterDateCustomizeDiff.
+	if d.HasChange("delete_after_date") {
+		dbUser.DeleteAfterDate = parseDeleteAfterDate(d.Get("delete_after_date").(string))
+	}
+
+	_, _, err = connV2.DatabaseUsersApi.UpdateDatabaseUser(ctx, projectID, authDatabaseName, username, dbUser).Execute()
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error updating database user(%s): %s", username, err))
 	}
 
+	if generatedPassword != nil {
+		if err := d.Set("generated_password", *generatedPassword); err != nil {
+			return diag.FromErr(fmt.Errorf("error setting `generated_password` for database user (%s): %s", username, err))
+		}
+	}
+
 	return resourceMongoDBAtlasDatabaseUserRead(ctx, d, meta)
 }
 
 func resourceMongoDBAtlasDatabaseUserDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// Get client connection.
-	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 	ids := decodeStateID(d.Id())
 	projectID := ids["project_id"]
 	username := ids["username"]
 	authDatabaseName := ids["auth_database_name"]
 
-	_, err := conn.DatabaseUsers.Delete(ctx, authDatabaseName, projectID, username)
+	_, resp, err := connV2.DatabaseUsersApi.DeleteDatabaseUser(ctx, projectID, authDatabaseName, username).Execute()
 	if err != nil {
+		// a temporary user may have expired since it was last read.
+		if resp != nil && resp.StatusCode == http.StatusNotFound && isDatabaseUserExpired(d.Get("delete_after_date").(string)) {
+			return nil
+		}
+
 		return diag.FromErr(fmt.Errorf("error deleting database user (%s): %s", username, err))
 	}
 
@@ -316,19 +430,19 @@ func resourceMongoDBAtlasDatabaseUserDelete(ctx context.Context, d *schema.Resou
 }
 
 func resourceMongoDBAtlasDatabaseUserImportState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 
 	projectID, username, authDatabaseName, err := splitDatabaseUserImportID(d.Id())
 	if err != nil {
 		return nil, err
 	}
 
-	u, _, err := conn.DatabaseUsers.Get(ctx, *authDatabaseName, *projectID, *username)
+	u, _, err := connV2.DatabaseUsersApi.GetDatabaseUser(ctx, *projectID, *authDatabaseName, *username).Execute()
 	if err != nil {
 		return nil, fmt.Errorf("couldn't import user(%s) in project(%s), error: %s", *username, *projectID, err)
 	}
 
-	if err := d.Set("project_id", u.GroupID); err != nil {
+	if err := d.Set("project_id", u.GroupId); err != nil {
 		return nil, fmt.Errorf("error setting `project_id` for database user (%s): %s", d.Id(), err)
 	}
 
@@ -361,20 +475,26 @@ func splitDatabaseUserImportID(id string) (projectID, username, authDatabaseName
 	return
 }
 
-func expandRoles(d *schema.ResourceData) []matlas.Role {
-	var roles []matlas.Role
-
+func expandRoles(d *schema.ResourceData) []admin.DatabaseUserRole {
 	if v, ok := d.GetOk("roles"); ok {
-		if rs := v.(*schema.Set); rs.Len() > 0 {
-			roles = make([]matlas.Role, rs.Len())
-
-			for k, r := range rs.List() {
-				roleMap := r.(map[string]interface{})
-				roles[k] = matlas.Role{
-					RoleName:       roleMap["role_name"].(string),
-					DatabaseName:   roleMap["database_name"].(string),
-					CollectionName: roleMap["collection_name"].(string),
-				}
+		return expandRolesSet(v.(*schema.Set))
+	}
+
+	return nil
+}
+
+func expandRolesSet(rs *schema.Set) []admin.DatabaseUserRole {
+	var roles []admin.DatabaseUserRole
+
+	if rs.Len() > 0 {
+		roles = make([]admin.DatabaseUserRole, rs.Len())
+
+		for k, r := range rs.List() {
+			roleMap := r.(map[string]interface{})
+			roles[k] = admin.DatabaseUserRole{
+				RoleName:       roleMap["role_name"].(string),
+				DatabaseName:   roleMap["database_name"].(string),
+				CollectionName: stringPtrOrNil(roleMap["collection_name"].(string)),
 			}
 		}
 	}
@@ -382,20 +502,20 @@ func expandRoles(d *schema.ResourceData) []matlas.Role {
 	return roles
 }
 
-func flattenRoles(roles []matlas.Role) []interface{} {
+func flattenRoles(roles []admin.DatabaseUserRole) []interface{} {
 	roleList := make([]interface{}, 0)
 	for _, v := range roles {
 		roleList = append(roleList, map[string]interface{}{
 			"role_name":       v.RoleName,
 			"database_name":   v.DatabaseName,
-			"collection_name": v.CollectionName,
+			"collection_name": v.GetCollectionName(),
 		})
 	}
 
 	return roleList
 }
 
-func flattenScopes(l []matlas.Scope) []map[string]interface{} {
+func flattenScopes(l []admin.UserScope) []map[string]interface{} {
 	scopes := make([]map[string]interface{}, len(l))
 	for i, v := range l {
 		scopes[i] = map[string]interface{}{
@@ -407,12 +527,15 @@ func flattenScopes(l []matlas.Scope) []map[string]interface{} {
 	return scopes
 }
 
-func expandScopes(d *schema.ResourceData) []matlas.Scope {
-	list := d.Get("scopes").(*schema.Set)
-	res := []matlas.Scope{}
+func expandScopes(d *schema.ResourceData) []admin.UserScope {
+	return expandScopesSet(d.Get("scopes").(*schema.Set))
+}
+
+func expandScopesSet(list *schema.Set) []admin.UserScope {
+	res := []admin.UserScope{}
 	for _, val := range list.List() {
 		v := val.(map[string]interface{})
-		scope := matlas.Scope{
+		scope := admin.UserScope{
 			Type: v["type"].(string),
 			Name: v["name"].(string),
 		}
@@ -421,3 +544,87 @@ func expandScopes(d *schema.ResourceData) []matlas.Scope {
 
 	return res
 }
+
+// resourceDatabaseUserDeleteAfterDateCustomizeDiff rejects a delete_after_date that isn't in the future, Atlas would
+// refuse it, and replaces the user when delete_after_date is removed since a temporary user can't be made permanent.
+func resourceDatabaseUserDeleteAfterDateCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange("delete_after_date") || !d.NewValueKnown("delete_after_date") {
+		return nil
+	}
+
+	oldValue, newValue := d.GetChange("delete_after_date")
+	if newValue.(string) == "" {
+		if d.Id() != "" && oldValue.(string) != "" {
+			return d.ForceNew("delete_after_date")
+		}
+		return nil
+	}
+
+	deleteAfterDate, err := time.Parse(time.RFC3339, newValue.(string))
+	if err != nil {
+		return fmt.Errorf("`delete_after_date` must be a date in RFC 3339 format: %s", err)
+	}
+
+	if !deleteAfterDate.After(time.Now()) {
+		return fmt.Errorf("`delete_after_date` must be in the future, got %s", newValue)
+	}
+
+	return nil
+}
+
+// parseDeleteAfterDate returns nil for an empty or invalid date, the date is validated by the schema and at plan time.
+func parseDeleteAfterDate(v string) *time.Time {
+	deleteAfterDate, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return nil
+	}
+
+	return &deleteAfterDate
+}
+
+// isDatabaseUserExpired reports whether a temporary user was deleted by Atlas on its delete_after_date.
+func isDatabaseUserExpired(deleteAfterDate string) bool {
+	if t := parseDeleteAfterDate(deleteAfterDate); t != nil {
+		return !t.After(time.Now())
+	}
+
+	return false
+}
+
+// suppressEquivalentRFC3339Time suppresses the difference between two representations of the same date,
+// e.g. 2030-01-01T02:00:00+02:00 in the configuration and 2030-01-01T00:00:00Z returned by Atlas.
+func suppressEquivalentRFC3339Time(k, old, new string, d *schema.ResourceData) bool {
+	oldTime, err := time.Parse(time.RFC3339, old)
+	if err != nil {
+		return false
+	}
+
+	newTime, err := time.Parse(time.RFC3339, new)
+	if err != nil {
+		return false
+	}
+
+	return oldTime.Equal(newTime)
+}
+
+// getWriteOnlyString returns the value of a write-only attribute from the configuration, the attribute is never
+// stored in the plan or the state, see suppressWriteOnly.
+func getWriteOnlyString(d *schema.ResourceData, key string) string {
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return ""
+	}
+
+	v := rawConfig.GetAttr(key)
+	if v.IsNull() || !v.IsKnown() {
+		return ""
+	}
+
+	return v.AsString()
+}
+
+// suppressWriteOnly suppresses any difference of a write-only attribute so its value, e.g. a password read from a
+// vault, is kept out of the plan and the state. Its changes are applied when the version attribute paired with it changes.
+func suppressWriteOnly(k, old, new string, d *schema.ResourceData) bool {
+	return true
+}
@@ -0,0 +1,215 @@
+package mongodbatlas
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	passwordLowerChars   = "abcdefghijklmnopqrstuvwxyz"
+	passwordUpperChars   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	passwordNumericChars = "0123456789"
+	// passwordSpecialChars doesn't include the characters that must be escaped in a connection string, e.g. @ : / ?
+	passwordSpecialChars = "!#$%&*()-_=+[]{}<>.,;~"
+
+	defaultGeneratedPasswordLength = 32
+)
+
+var passwordOverrideSpecialRegexp = regexp.MustCompile(`^[!-~]+$`)
+
+// passwordGenerator describes how to generate the password of a database user, from its password_generator block.
+type passwordGenerator struct {
+	special        string
+	length         int
+	lower          bool
+	upper          bool
+	numeric        bool
+	specialEnabled bool
+}
+
+func databaseUserPasswordGeneratorSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: []string{"password", "password_wo", "x509_type", "ldap_auth_type", "aws_iam_type"},
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"length": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      defaultGeneratedPasswordLength,
+					ValidateFunc: validation.IntBetween(8, 256),
+				},
+				"lower": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+				"upper": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+				"numeric": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+				"special": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+				"override_special": {
+					Type:     schema.TypeString,
+					Optional: true,
+					// the characters are picked byte by byte.
+					ValidateFunc: validation.StringMatch(passwordOverrideSpecialRegexp, "must only contain printable ASCII characters other than space"),
+				},
+				"keepers": {
+					Type:     schema.TypeMap,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+			},
+		},
+	}
+}
+
+// expandPasswordGenerator returns nil when the database user doesn't have a password_generator block.
+func expandPasswordGenerator(d *schema.ResourceData) *passwordGenerator {
+	list, ok := d.Get("password_generator").([]interface{})
+	if !ok || len(list) == 0 || list[0] == nil {
+		return nil
+	}
+
+	tfMap := list[0].(map[string]interface{})
+	generator := &passwordGenerator{
+		length:         tfMap["length"].(int),
+		lower:          tfMap["lower"].(bool),
+		upper:          tfMap["upper"].(bool),
+		numeric:        tfMap["numeric"].(bool),
+		specialEnabled: tfMap["special"].(bool),
+		special:        passwordSpecialChars,
+	}
+
+	if v := tfMap["override_special"].(string); v != "" {
+		generator.special = v
+	}
+
+	return generator
+}
+
+// charsets returns the character sets the password is generated from, the password has at least a
+// character of each of them.
+func (g *passwordGenerator) charsets() []string {
+	var charsets []string
+
+	if g.lower {
+		charsets = append(charsets, passwordLowerChars)
+	}
+	if g.upper {
+		charsets = append(charsets, passwordUpperChars)
+	}
+	if g.numeric {
+		charsets = append(charsets, passwordNumericChars)
+	}
+	if g.specialEnabled {
+		charsets = append(charsets, g.special)
+	}
+
+	return charsets
+}
+
+// generate returns a random password of g.length characters using a cryptographically secure generator.
+func (g *passwordGenerator) generate() (string, error) {
+	charsets := g.charsets()
+	if len(charsets) == 0 {
+		return "", fmt.Errorf("`password_generator` must enable at least one of lower, upper, numeric or special")
+	}
+	if len(charsets) > g.length {
+		return "", fmt.Errorf("`password_generator` length must be at least %d to include all the enabled characters", len(charsets))
+	}
+
+	all := ""
+	for _, charset := range charsets {
+		all += charset
+	}
+
+	password := make([]byte, 0, g.length)
+	for _, charset := range charsets {
+		c, err := randomChar(charset)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, c)
+	}
+
+	for len(password) < g.length {
+		c, err := randomChar(all)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, c)
+	}
+
+	// shuffle so the first characters aren't always from the same character sets.
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", err
+		}
+		password[i], password[j.Int64()] = password[j.Int64()], password[i]
+	}
+
+	return string(password), nil
+}
+
+func randomChar(charset string) (byte, error) {
+	i, err := rand.Int(rand.Reader, big.NewInt(int64(len(charset))))
+	if err != nil {
+		return 0, fmt.Errorf("error generating password: %s", err)
+	}
+
+	return charset[i.Int64()], nil
+}
+
+// resourceDatabaseUserPasswordGeneratorCustomizeDiff plans a new generated_password when the password of an existing
+// user is rotated, see isPasswordGeneratorRotated.
+func resourceDatabaseUserPasswordGeneratorCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !isPasswordGeneratorRotated(d) {
+		return nil
+	}
+
+	if list, ok := d.Get("password_generator").([]interface{}); !ok || len(list) == 0 {
+		return nil
+	}
+
+	return d.SetNewComputed("generated_password")
+}
+
+// passwordGeneratorChange is implemented by both schema.ResourceData and schema.ResourceDiff.
+type passwordGeneratorChange interface {
+	GetChange(key string) (interface{}, interface{})
+	HasChange(key string) bool
+}
+
+// isPasswordGeneratorRotated reports whether a new password must be generated for an existing user, i.e. when the
+// password_generator block is added or one of its keepers changes. The other arguments of the block only apply to
+// the next generated password.
+func isPasswordGeneratorRotated(d passwordGeneratorChange) bool {
+	if old, _ := d.GetChange("password_generator"); old == nil || len(old.([]interface{})) == 0 {
+		return d.HasChange("password_generator")
+	}
+
+	return d.HasChange("password_generator.0.keepers")
+}
@@ -0,0 +1,213 @@
+package mongodbatlas
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestPasswordGenerator_generate(t *testing.T) {
+	testCases := []struct {
+		name      string
+		generator passwordGenerator
+		charsets  []string
+		wantErr   bool
+	}{
+		{
+			name:      "defaults",
+			generator: passwordGenerator{length: 32, lower: true, upper: true, numeric: true, special: passwordSpecialChars},
+			charsets:  []string{passwordLowerChars, passwordUpperChars, passwordNumericChars},
+		},
+		{
+			name: "special",
+			generator: passwordGenerator{length: 8, lower: true, upper: true, numeric: true, specialEnabled: true,
+				special: passwordSpecialChars},
+			charsets: []string{passwordLowerChars, passwordUpperChars, passwordNumericChars, passwordSpecialChars},
+		},
+		{
+			name:      "override special",
+			generator: passwordGenerator{length: 64, numeric: true, specialEnabled: true, special: "-_"},
+			charsets:  []string{passwordNumericChars, "-_"},
+		},
+		{
+			name:      "no characters",
+			generator: passwordGenerator{length: 32, special: passwordSpecialChars},
+			wantErr:   true,
+		},
+		{
+			name:      "shorter than the character sets",
+			generator: passwordGenerator{length: 2, lower: true, upper: true, numeric: true, special: passwordSpecialChars},
+			wantErr:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			password, err := tc.generator.generate()
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got password %q", password)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if len(password) != tc.generator.length {
+				t.Errorf("bad password length, expected %d, got %d", tc.generator.length, len(password))
+			}
+
+			all := strings.Join(tc.charsets, "")
+			for _, c := range password {
+				if !strings.ContainsRune(all, c) {
+					t.Errorf("password %q contains %q which isn't in the enabled characters", password, c)
+				}
+			}
+
+			for _, charset := range tc.charsets {
+				if !strings.ContainsAny(password, charset) {
+					t.Errorf("password %q doesn't contain any character of %q", password, charset)
+				}
+			}
+		})
+	}
+
+	first, _ := testCases[0].generator.generate()
+	second, _ := testCases[0].generator.generate()
+	if first == second {
+		t.Errorf("two generated passwords are the same: %q", first)
+	}
+}
+
+func TestPasswordGenerator_overrideSpecial(t *testing.T) {
+	validate := databaseUserPasswordGeneratorSchema().Elem.(*schema.Resource).Schema["override_special"].ValidateFunc
+
+	for value, wantErr := range map[string]bool{
+		"-_":                 false,
+		passwordSpecialChars: false,
+		"-é":                 true,
+		"- ":                 true,
+		"\t":                 true,
+	} {
+		if _, errs := validate(value, "override_special"); (len(errs) > 0) != wantErr {
+			t.Errorf("Bad override_special validation of %q, got errors %v, want error %t", value, errs, wantErr)
+		}
+	}
+}
+
+func TestMockConfigRSDatabaseUser_passwordGenerator(t *testing.T) {
+	var (
+		mock         = newAtlasMockServer(t)
+		projectID    = mock.newProject("test-mock")
+		resourceName = "mongodbatlas_database_user.test"
+		username     = "test-mock-generated-user"
+		usersPath    = fmt.Sprintf("%s/groups/%s/databaseUsers", mockAtlasV2Path, projectID)
+		passwords    []string
+	)
+
+	// testGeneratedPassword checks the generated password is the one sent by the last request with method.
+	testGeneratedPassword := func(method string, length int) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			rs, ok := s.RootModule().Resources[resourceName]
+			if !ok {
+				return fmt.Errorf("not found: %s", resourceName)
+			}
+
+			password := rs.Primary.Attributes["generated_password"]
+			if len(password) != length {
+				return fmt.Errorf("bad generated_password length, expected %d, got %d", length, len(password))
+			}
+
+			bodies := mock.requestBodies(method, usersPath)
+			if len(bodies) == 0 {
+				return fmt.Errorf("no %s request for the database user", method)
+			}
+			if body, _ := bodies[len(bodies)-1].(map[string]interface{}); body["password"] != password {
+				return fmt.Errorf("the generated_password isn't the password sent by %s", method)
+			}
+
+			passwords = append(passwords, password)
+
+			return nil
+		}
+	}
+
+	testMockResourceUnitTest(t, mock, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: testMockMongoDBAtlasDatabaseUserPasswordGeneratorConfig(projectID, username, "read", "2023-01", defaultGeneratedPasswordLength),
+				Check:  testGeneratedPassword(http.MethodPost, defaultGeneratedPasswordLength),
+			},
+			{
+				// changing another argument keeps the password.
+				Config: testMockMongoDBAtlasDatabaseUserPasswordGeneratorConfig(projectID, username, "readWrite", "2023-01", defaultGeneratedPasswordLength),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrWith(resourceName, "generated_password", func(value string) error {
+						if value != passwords[0] {
+							return fmt.Errorf("the password was regenerated without any change of the keepers")
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				// so does changing the other arguments of the block, they apply to the next password.
+				Config: testMockMongoDBAtlasDatabaseUserPasswordGeneratorConfig(projectID, username, "readWrite", "2023-01", 40),
+				Check: resource.TestCheckResourceAttrWith(resourceName, "generated_password", func(value string) error {
+					if value != passwords[0] {
+						return fmt.Errorf("the password was regenerated without any change of the keepers")
+					}
+					return nil
+				}),
+			},
+			{
+				Config: testMockMongoDBAtlasDatabaseUserPasswordGeneratorConfig(projectID, username, "readWrite", "2023-02", 40),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+						plancheck.ExpectUnknownValue(resourceName, tfjsonpath.New("generated_password")),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					testGeneratedPassword(http.MethodPatch, 40),
+					func(*terraform.State) error {
+						if passwords[0] == passwords[1] {
+							return fmt.Errorf("the password wasn't regenerated when the keepers changed")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testMockMongoDBAtlasDatabaseUserPasswordGeneratorConfig(projectID, username, roleName, rotation string, length int) string {
+	return fmt.Sprintf(`
+		resource "mongodbatlas_database_user" "test" {
+			project_id         = %[1]q
+			username           = %[2]q
+			auth_database_name = "admin"
+
+			password_generator {
+				length = %[5]d
+				keepers = {
+					rotation = %[4]q
+				}
+			}
+
+			roles {
+				role_name     = %[3]q
+				database_name = "admin"
+			}
+		}
+	`, projectID, username, roleName, rotation, length)
+}
@@ -4,11 +4,19 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/go-test/deep"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	matlas "go.mongodb.org/atlas/mongodbatlas"
 )
@@ -891,3 +899,358 @@ func testAccMongoDBAtlasDatabaseUserWithLDAPAuthTypeConfig(projectName, orgID, r
 		}
 	`, projectName, orgID, roleName, username, keyLabel, valueLabel)
 }
+
+func TestMockConfigRSDatabaseUser_basic(t *testing.T) {
+	var (
+		mock         = newAtlasMockServer(t)
+		projectID    = mock.newProject("test-mock")
+		resourceName = "mongodbatlas_database_user.test"
+		username     = "test-mock-user"
+		userPath     = fmt.Sprintf("%s/groups/%s/databaseUsers/admin/%s", mockAtlasV2Path, projectID, username)
+	)
+
+	testMockResourceUnitTest(t, mock, resource.TestCase{
+		CheckDestroy: func(*terraform.State) error {
+			if mock.get(userPath) != nil {
+				return fmt.Errorf("database user (%s) still exists", username)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testMockMongoDBAtlasDatabaseUserConfig(projectID, username, "read"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "project_id", projectID),
+					resource.TestCheckResourceAttr(resourceName, "username", username),
+					resource.TestCheckResourceAttr(resourceName, "auth_database_name", "admin"),
+					resource.TestCheckResourceAttr(resourceName, "roles.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "roles.0.role_name", "read"),
+					func(*terraform.State) error {
+						if got := mock.requestCount(http.MethodPost, mockAtlasV2Path+"/groups/"+projectID+"/databaseUsers"); got != 1 {
+							return fmt.Errorf("expected the database user to be created with the Atlas SDK, got %d requests", got)
+						}
+						if _, ok := mock.get(userPath)["password"]; ok {
+							return fmt.Errorf("mock server must not return the password")
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: testMockMongoDBAtlasDatabaseUserConfig(projectID, username, "readWrite"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "roles.0.role_name", "readWrite"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportStateIdFunc:       testAccCheckMongoDBAtlasDatabaseUserImportStateIDFunc(resourceName),
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+		},
+	})
+}
+
+func TestMockConfigRSDatabaseUser_deleteAfterDate(t *testing.T) {
+	var (
+		mock         = newAtlasMockServer(t)
+		projectID    = mock.newProject("test-mock")
+		resourceName = "mongodbatlas_database_user.test"
+		username     = "test-mock-temporary-user"
+		tomorrow     = time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	)
+
+	testMockResourceUnitTest(t, mock, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:      testMockMongoDBAtlasDatabaseUserDeleteAfterDateConfig(projectID, username, time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`delete_after_date` must be in the future"),
+			},
+			{
+				Config: testMockMongoDBAtlasDatabaseUserDeleteAfterDateConfig(projectID, username, tomorrow.Format(time.RFC3339)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "delete_after_date", tomorrow.Format(time.RFC3339)),
+				),
+			},
+			{
+				// the same date in another time zone isn't a change.
+				Config:   testMockMongoDBAtlasDatabaseUserDeleteAfterDateConfig(projectID, username, tomorrow.In(time.FixedZone("", 2*60*60)).Format(time.RFC3339)),
+				PlanOnly: true,
+			},
+			{
+				// a temporary user can't be made permanent.
+				Config: testMockMongoDBAtlasDatabaseUserConfig(projectID, username, "read"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "delete_after_date", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestMockConfigRSDatabaseUser_expired(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		mock      = newAtlasMockServer(t)
+		client    = mock.client()
+		projectID = mock.newProject("test-mock")
+		r         = resourceMongoDBAtlasDatabaseUser()
+		config    = map[string]interface{}{
+			"project_id":         projectID,
+			"username":           "test-mock-expired-user",
+			"auth_database_name": "admin",
+			"delete_after_date":  time.Now().Add(-time.Hour).UTC().Format(time.RFC3339),
+			"roles": []interface{}{
+				map[string]interface{}{"role_name": "read", "database_name": "admin"},
+			},
+		}
+	)
+
+	// the user expired and was deleted by Atlas since it was last read.
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	d.SetId(encodeStateID(map[string]string{
+		"project_id":         projectID,
+		"username":           "test-mock-expired-user",
+		"auth_database_name": "admin",
+	}))
+
+	diags := r.ReadContext(ctx, d, client)
+	if diags.HasError() {
+		t.Fatalf("Bad read of expired database user: %v", diags)
+	}
+	if len(diags) != 1 || diags[0].Severity != diag.Warning || !strings.Contains(diags[0].Summary, "expired") {
+		t.Errorf("Bad read of expired database user, expected a warning, got %v", diags)
+	}
+	if d.Id() != "" {
+		t.Error("an expired database user must be removed from the state")
+	}
+
+	d.SetId(encodeStateID(map[string]string{
+		"project_id":         projectID,
+		"username":           "test-mock-expired-user",
+		"auth_database_name": "admin",
+	}))
+	if diags := r.DeleteContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("Bad delete of expired database user: %v", diags)
+	}
+
+	config["delete_after_date"] = ""
+	permanent := schema.TestResourceDataRaw(t, r.Schema, config)
+	permanent.SetId(d.Id())
+	if diags := r.DeleteContext(ctx, permanent, client); !diags.HasError() {
+		t.Error("the deletion of a missing permanent database user must fail")
+	}
+}
+
+func TestMockConfigRSDatabaseUser_passwordWriteOnly(t *testing.T) {
+	var (
+		mock         = newAtlasMockServer(t)
+		projectID    = mock.newProject("test-mock")
+		resourceName = "mongodbatlas_database_user.test"
+		username     = "test-mock-write-only-user"
+		usersPath    = fmt.Sprintf("%s/groups/%s/databaseUsers", mockAtlasV2Path, projectID)
+	)
+
+	testLastPassword := func(method, password string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			bodies := mock.requestBodies(method, usersPath)
+			if len(bodies) == 0 {
+				return fmt.Errorf("no %s request for the database user", method)
+			}
+
+			body, _ := bodies[len(bodies)-1].(map[string]interface{})
+			if got := body["password"]; got != password {
+				return fmt.Errorf("bad password sent by %s, expected %q, got %v", method, password, got)
+			}
+
+			return nil
+		}
+	}
+
+	testMockResourceUnitTest(t, mock, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				// a later password_wo would never be sent without a password_version to change along with it.
+				Config: fmt.Sprintf(`
+					resource "mongodbatlas_database_user" "test" {
+						project_id         = %[1]q
+						username           = %[2]q
+						password_wo        = "first-password"
+						auth_database_name = "admin"
+
+						roles {
+							role_name     = "read"
+							database_name = "admin"
+						}
+					}
+				`, projectID, username),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`"password_wo": all of\s+` + "`password_version,password_wo`"),
+			},
+			{
+				Config: testMockMongoDBAtlasDatabaseUserPasswordWriteOnlyConfig(projectID, username, "first-password", 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr(resourceName, "password_wo"),
+					resource.TestCheckResourceAttr(resourceName, "password_version", "1"),
+					testLastPassword(http.MethodPost, "first-password"),
+				),
+			},
+			{
+				// password_wo isn't in the state, a new value alone isn't a change.
+				Config:   testMockMongoDBAtlasDatabaseUserPasswordWriteOnlyConfig(projectID, username, "second-password", 1),
+				PlanOnly: true,
+			},
+			{
+				Config: testMockMongoDBAtlasDatabaseUserPasswordWriteOnlyConfig(projectID, username, "second-password", 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr(resourceName, "password_wo"),
+					resource.TestCheckResourceAttr(resourceName, "password_version", "2"),
+					testLastPassword(http.MethodPatch, "second-password"),
+				),
+			},
+		},
+	})
+}
+
+func testMockMongoDBAtlasDatabaseUserPasswordWriteOnlyConfig(projectID, username, password string, version int) string {
+	return fmt.Sprintf(`
+		resource "mongodbatlas_database_user" "test" {
+			project_id         = %[1]q
+			username           = %[2]q
+			password_wo        = %[3]q
+			password_version   = %[4]d
+			auth_database_name = "admin"
+
+			roles {
+				role_name     = "read"
+				database_name = "admin"
+			}
+		}
+	`, projectID, username, password, version)
+}
+
+func testMockMongoDBAtlasDatabaseUserDeleteAfterDateConfig(projectID, username, deleteAfterDate string) string {
+	return fmt.Sprintf(`
+		resource "mongodbatlas_database_user" "test" {
+			project_id         = %[1]q
+			username           = %[2]q
+			password           = "test-mock-password"
+			auth_database_name = "admin"
+			delete_after_date  = %[3]q
+
+			roles {
+				role_name     = "read"
+				database_name = "admin"
+			}
+		}
+	`, projectID, username, deleteAfterDate)
+}
+
+func testMockMongoDBAtlasDatabaseUserConfig(projectID, username, roleName string) string {
+	return fmt.Sprintf(`
+		resource "mongodbatlas_database_user" "test" {
+			project_id         = %[1]q
+			username           = %[2]q
+			password           = "test-mock-password"
+			auth_database_name = "admin"
+
+			roles {
+				role_name     = %[3]q
+				database_name = "admin"
+			}
+		}
+	`, projectID, username, roleName)
+}
+
+func TestMockConfigRSDatabaseUser_crud(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		mock      = newAtlasMockServer(t)
+		client    = mock.client()
+		projectID = mock.newProject("test-mock")
+		r         = resourceMongoDBAtlasDatabaseUser()
+		config    = map[string]interface{}{
+			"project_id":         projectID,
+			"username":           "test-mock-user",
+			"password":           "test-mock-password",
+			"auth_database_name": "admin",
+			"roles": []interface{}{
+				map[string]interface{}{"role_name": "read", "database_name": "admin"},
+			},
+			"labels": []interface{}{
+				map[string]interface{}{"key": "env", "value": "test"},
+			},
+		}
+	)
+
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("Bad create database user: %v", diags)
+	}
+
+	ids := decodeStateID(d.Id())
+	if ids["project_id"] != projectID || ids["username"] != "test-mock-user" || ids["auth_database_name"] != "admin" {
+		t.Fatalf("Bad database user ID: %#v", ids)
+	}
+
+	if got := d.Get("labels").(*schema.Set).Len(); got != 1 {
+		t.Errorf("Bad labels after create: got %d, want 1", got)
+	}
+
+	if got := mock.requestCount(http.MethodPost, mockAtlasV2Path+"/groups/"+projectID+"/databaseUsers"); got != 1 {
+		t.Errorf("expected the database user to be created with the Atlas SDK, got %d requests", got)
+	}
+
+	config["roles"] = []interface{}{
+		map[string]interface{}{"role_name": "readWrite", "database_name": "admin"},
+	}
+	updated := schema.TestResourceDataRaw(t, r.Schema, config)
+	updated.SetId(d.Id())
+
+	if diags := r.UpdateContext(ctx, updated, client); diags.HasError() {
+		t.Fatalf("Bad update database user: %v", diags)
+	}
+
+	stored := mock.get(fmt.Sprintf("%s/groups/%s/databaseUsers/admin/test-mock-user", mockAtlasV1Path, projectID))
+	if stored == nil {
+		t.Fatal("database user not found in mock server")
+	}
+	if _, ok := stored["password"]; ok {
+		t.Error("mock server must not return the password")
+	}
+
+	roles := flattenRoles(expandRoles(updated))
+	if diff := deep.Equal(roles, []interface{}{
+		map[string]interface{}{"role_name": "readWrite", "database_name": "admin", "collection_name": ""},
+	}); diff != nil {
+		t.Errorf("Bad roles after update: %v", diff)
+	}
+
+	imported := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	imported.SetId(fmt.Sprintf("%s-test-mock-user-admin", projectID))
+	if _, err := r.Importer.StateContext(ctx, imported, client); err != nil {
+		t.Fatalf("Bad import database user: %s", err)
+	}
+	if imported.Id() != d.Id() {
+		t.Errorf("Bad imported ID: got %s, want %s", imported.Id(), d.Id())
+	}
+
+	if diags := r.DeleteContext(ctx, updated, client); diags.HasError() {
+		t.Fatalf("Bad delete database user: %v", diags)
+	}
+
+	if diags := r.ReadContext(ctx, updated, client); diags.HasError() {
+		t.Fatalf("Bad read of deleted database user: %v", diags)
+	}
+	if updated.Id() != "" {
+		t.Error("a deleted database user must be removed from the state")
+	}
+}
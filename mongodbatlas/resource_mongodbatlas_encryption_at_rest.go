@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/mwielbut/pointy"
 	"github.com/spf13/cast"
@@ -196,6 +197,10 @@ func resourceMongoDBAtlasEncryptionAtRest() *schema.Resource {
 				},
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+		},
 	}
 }
 
@@ -239,22 +244,8 @@ func resourceMongoDBAtlasEncryptionAtRestCreate(ctx context.Context, d *schema.R
 		encryptionAtRestReq.GoogleCloudKms = expandGCPKmsConfig(gcpC.([]interface{}))
 	}
 
-	for i := 0; i < 5; i++ {
-		_, _, err := conn.EncryptionsAtRest.Create(ctx, encryptionAtRestReq)
-		if err != nil {
-			if strings.Contains(err.Error(), "CANNOT_ASSUME_ROLE") || strings.Contains(err.Error(), "INVALID_AWS_CREDENTIALS") ||
-				strings.Contains(err.Error(), "CLOUD_PROVIDER_ACCESS_ROLE_NOT_AUTHORIZED") {
-				log.Printf("warning issue performing authorize EncryptionsAtRest not done try again: %s \n", err.Error())
-				log.Println("retrying ")
-				time.Sleep(10 * time.Second)
-				encryptionAtRestReq.GroupID = d.Get("project_id").(string)
-				continue
-			}
-		}
-		if err != nil {
-			return diag.FromErr(fmt.Errorf(errorCreateEncryptionAtRest, err))
-		}
-		break
+	if err := saveEncryptionAtRest(ctx, conn, encryptionAtRestReq, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(fmt.Errorf(errorCreateEncryptionAtRest, err))
 	}
 
 	d.SetId(d.Get("project_id").(string))
@@ -458,14 +449,34 @@ func resourceMongoDBAtlasEncryptionAtRestUpdate(ctx context.Context, d *schema.R
 		encrypt.GoogleCloudKms = expandGCPKmsConfig(d.Get("google_cloud_kms_config").([]interface{}))
 	}
 
-	_, _, err = conn.EncryptionsAtRest.Create(ctx, encrypt)
-	if err != nil {
+	if err := saveEncryptionAtRest(ctx, conn, encrypt, d.Timeout(schema.TimeoutUpdate)); err != nil {
 		return diag.FromErr(fmt.Errorf("error updating encryption at rest (%s): %s", projectID, err))
 	}
 
 	return resourceMongoDBAtlasEncryptionAtRestRead(ctx, d, meta)
 }
 
+// saveEncryptionAtRest creates or updates the encryption at rest configuration, retrying while the
+// cloud provider doesn't accept the credentials yet, e.g. because a new IAM role is still propagating.
+func saveEncryptionAtRest(ctx context.Context, conn *matlas.Client, encryptionAtRestReq *matlas.EncryptionAtRest, timeout time.Duration) error {
+	projectID := encryptionAtRestReq.GroupID
+
+	return retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		// the client clears the project ID of the request once it has been used to build the path.
+		encryptionAtRestReq.GroupID = projectID
+		_, _, err := conn.EncryptionsAtRest.Create(ctx, encryptionAtRestReq)
+		if err != nil {
+			if strings.Contains(err.Error(), "CANNOT_ASSUME_ROLE") || strings.Contains(err.Error(), "INVALID_AWS_CREDENTIALS") ||
+				strings.Contains(err.Error(), "CLOUD_PROVIDER_ACCESS_ROLE_NOT_AUTHORIZED") {
+				log.Printf("warning issue performing authorize EncryptionsAtRest not done try again: %s \n", err.Error())
+				return retry.RetryableError(err)
+			}
+			return retry.NonRetryableError(err)
+		}
+		return nil
+	})
+}
+
 func resourceMongoDBAtlasEncryptionAtRestDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	conn := meta.(*MongoDBClient).Atlas
 
@@ -15,7 +15,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/spf13/cast"
-	matlas "go.mongodb.org/atlas/mongodbatlas"
+	"go.mongodb.org/atlas-sdk/v20230201002/admin"
 )
 
 const (
@@ -101,13 +101,13 @@ func resourceMongoDBAtlasNetworkContainer() *schema.Resource {
 
 func resourceMongoDBAtlasNetworkContainerCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// Get client connection.
-	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 	projectID := d.Get("project_id").(string)
 	providerName := d.Get("provider_name").(string)
 
-	containerRequest := &matlas.Container{
-		AtlasCIDRBlock: d.Get("atlas_cidr_block").(string),
-		ProviderName:   providerName,
+	containerRequest := &admin.CloudProviderContainer{
+		AtlasCidrBlock: admin.PtrString(d.Get("atlas_cidr_block").(string)),
+		ProviderName:   admin.PtrString(providerName),
 	}
 
 	if providerName == "AWS" {
@@ -116,7 +116,7 @@ func resourceMongoDBAtlasNetworkContainerCreate(ctx context.Context, d *schema.R
 			return diag.FromErr(fmt.Errorf("`region_name` must be set when `provider_name` is AWS"))
 		}
 
-		containerRequest.RegionName = region
+		containerRequest.RegionName = admin.PtrString(region)
 	}
 
 	if providerName == "AZURE" {
@@ -125,7 +125,7 @@ func resourceMongoDBAtlasNetworkContainerCreate(ctx context.Context, d *schema.R
 			return diag.FromErr(fmt.Errorf("`region` must be set when `provider_name` is AZURE"))
 		}
 
-		containerRequest.Region = region
+		containerRequest.Region = admin.PtrString(region)
 	}
 
 	if providerName == "GCP" {
@@ -135,14 +135,14 @@ func resourceMongoDBAtlasNetworkContainerCreate(ctx context.Context, d *schema.R
 		}
 	}
 
-	container, _, err := conn.Containers.Create(ctx, projectID, containerRequest)
+	container, _, err := connV2.NetworkPeeringApi.CreatePeeringContainer(ctx, projectID, containerRequest).Execute()
 	if err != nil {
 		return diag.FromErr(fmt.Errorf(errorContainterCreate, err))
 	}
 
 	d.SetId(encodeStateID(map[string]string{
 		"project_id":   projectID,
-		"container_id": container.ID,
+		"container_id": container.GetId(),
 	}))
 
 	return resourceMongoDBAtlasNetworkContainerRead(ctx, d, meta)
@@ -150,12 +150,12 @@ func resourceMongoDBAtlasNetworkContainerCreate(ctx context.Context, d *schema.R
 
 func resourceMongoDBAtlasNetworkContainerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// Get client connection.
-	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 	ids := decodeStateID(d.Id())
 	projectID := ids["project_id"]
 	containerID := ids["container_id"]
 
-	container, resp, err := conn.Containers.Get(ctx, projectID, containerID)
+	container, resp, err := connV2.NetworkPeeringApi.GetPeeringContainer(ctx, projectID, containerID).Execute()
 	if err != nil {
 		if resp != nil && resp.StatusCode == http.StatusNotFound {
 			d.SetId("")
@@ -165,43 +165,43 @@ func resourceMongoDBAtlasNetworkContainerRead(ctx context.Context, d *schema.Res
 		return diag.FromErr(fmt.Errorf(errorContainerRead, containerID, err))
 	}
 
-	if err = d.Set("region_name", container.RegionName); err != nil {
+	if err = d.Set("region_name", container.GetRegionName()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `region_name` for Network Container (%s): %s", containerID, err))
 	}
 
-	if err = d.Set("region", container.Region); err != nil {
+	if err = d.Set("region", container.GetRegion()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `region` for Network Container (%s): %s", containerID, err))
 	}
 
-	if err = d.Set("azure_subscription_id", container.AzureSubscriptionID); err != nil {
+	if err = d.Set("azure_subscription_id", container.GetAzureSubscriptionId()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `azure_subscription_id` for Network Container (%s): %s", containerID, err))
 	}
 
-	if err = d.Set("provisioned", container.Provisioned); err != nil {
+	if err = d.Set("provisioned", container.GetProvisioned()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `provisioned` for Network Container (%s): %s", containerID, err))
 	}
 
-	if err = d.Set("gcp_project_id", container.GCPProjectID); err != nil {
+	if err = d.Set("gcp_project_id", container.GetGcpProjectId()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `gcp_project_id` for Network Container (%s): %s", containerID, err))
 	}
 
-	if err = d.Set("network_name", container.NetworkName); err != nil {
+	if err = d.Set("network_name", container.GetNetworkName()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `network_name` for Network Container (%s): %s", containerID, err))
 	}
 
-	if err = d.Set("gcp_project_id", container.GCPProjectID); err != nil {
+	if err = d.Set("gcp_project_id", container.GetGcpProjectId()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `gcp_project_id` for Network Container (%s): %s", containerID, err))
 	}
 
-	if err = d.Set("vpc_id", container.VPCID); err != nil {
+	if err = d.Set("vpc_id", container.GetVpcId()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `vpc_id` for Network Container (%s): %s", containerID, err))
 	}
 
-	if err = d.Set("vnet_name", container.VNetName); err != nil {
+	if err = d.Set("vnet_name", container.GetVnetName()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `vnet_name` for Network Container (%s): %s", containerID, err))
 	}
 
-	if err = d.Set("container_id", container.ID); err != nil {
+	if err = d.Set("container_id", container.GetId()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `container_id` for Network Container (%s): %s", containerID, err))
 	}
 
@@ -214,35 +214,35 @@ func resourceMongoDBAtlasNetworkContainerRead(ctx context.Context, d *schema.Res
 
 func resourceMongoDBAtlasNetworkContainerUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// Get client connection.
-	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 	ids := decodeStateID(d.Id())
 	projectID := ids["project_id"]
 	containerID := ids["container_id"]
 
-	container := new(matlas.Container)
+	container := new(admin.CloudProviderContainer)
 
 	if d.HasChange("atlas_cidr_block") {
-		container.AtlasCIDRBlock = d.Get("atlas_cidr_block").(string)
-		container.ProviderName = d.Get("provider_name").(string)
+		container.AtlasCidrBlock = admin.PtrString(d.Get("atlas_cidr_block").(string))
+		container.ProviderName = admin.PtrString(d.Get("provider_name").(string))
 	}
 
 	if d.HasChange("provider_name") {
-		container.ProviderName = d.Get("provider_name").(string)
+		container.ProviderName = admin.PtrString(d.Get("provider_name").(string))
 	}
 
 	if d.HasChange("region_name") {
 		region, _ := valRegion(d.Get("region_name"))
-		container.RegionName = region
+		container.RegionName = admin.PtrString(region)
 	}
 
 	if d.HasChange("region") {
 		region, _ := valRegion(d.Get("region"))
-		container.Region = region
+		container.Region = admin.PtrString(region)
 	}
 
 	// Has changes
-	if !reflect.DeepEqual(container, matlas.Container{}) {
-		_, _, err := conn.Containers.Update(ctx, projectID, containerID, container)
+	if !reflect.DeepEqual(container, &admin.CloudProviderContainer{}) {
+		_, _, err := connV2.NetworkPeeringApi.UpdatePeeringContainer(ctx, projectID, containerID, container).Execute()
 		if err != nil {
 			return diag.FromErr(fmt.Errorf(errorContainerUpdate, containerID, err))
 		}
@@ -253,12 +253,12 @@ func resourceMongoDBAtlasNetworkContainerUpdate(ctx context.Context, d *schema.R
 
 func resourceMongoDBAtlasNetworkContainerDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// Get client connection.
-	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 
 	stateConf := &retry.StateChangeConf{
 		Pending:    []string{"provisioned_container"},
 		Target:     []string{"deleted"},
-		Refresh:    resourceNetworkContainerRefreshFunc(ctx, d, conn),
+		Refresh:    resourceNetworkContainerRefreshFunc(ctx, d, connV2),
 		Timeout:    1 * time.Hour,
 		MinTimeout: 10 * time.Second,
 		Delay:      2 * time.Minute,
@@ -274,7 +274,7 @@ func resourceMongoDBAtlasNetworkContainerDelete(ctx context.Context, d *schema.R
 }
 
 func resourceMongoDBAtlasNetworkContainerImportState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 
 	parts := strings.SplitN(d.Id(), "-", 2)
 	if len(parts) != 2 {
@@ -284,56 +284,56 @@ func resourceMongoDBAtlasNetworkContainerImportState(ctx context.Context, d *sch
 	projectID := parts[0]
 	containerID := parts[1]
 
-	u, _, err := conn.Containers.Get(ctx, projectID, containerID)
+	u, _, err := connV2.NetworkPeeringApi.GetPeeringContainer(ctx, projectID, containerID).Execute()
 	if err != nil {
 		return nil, fmt.Errorf("couldn't import container %s in project %s, error: %s", containerID, projectID, err)
 	}
 
 	d.SetId(encodeStateID(map[string]string{
 		"project_id":   projectID,
-		"container_id": u.ID,
+		"container_id": u.GetId(),
 	}))
 
 	if err := d.Set("project_id", projectID); err != nil {
 		log.Printf("[WARN] Error setting project_id for (%s): %s", containerID, err)
 	}
 
-	if err := d.Set("provider_name", u.ProviderName); err != nil {
+	if err := d.Set("provider_name", u.GetProviderName()); err != nil {
 		log.Printf("[WARN] Error setting provider_name for (%s): %s", containerID, err)
 	}
 
-	if err := d.Set("atlas_cidr_block", u.AtlasCIDRBlock); err != nil {
+	if err := d.Set("atlas_cidr_block", u.GetAtlasCidrBlock()); err != nil {
 		log.Printf("[WARN] Error setting atlas_cidr_block for (%s): %s", containerID, err)
 	}
 
-	if err = d.Set("container_id", u.ID); err != nil {
+	if err = d.Set("container_id", u.GetId()); err != nil {
 		log.Printf("[WARN] Error setting container_id (%s): %s", containerID, err)
 	}
 
 	return []*schema.ResourceData{d}, nil
 }
 
-func resourceNetworkContainerRefreshFunc(ctx context.Context, d *schema.ResourceData, client *matlas.Client) retry.StateRefreshFunc {
+func resourceNetworkContainerRefreshFunc(ctx context.Context, d *schema.ResourceData, client *admin.APIClient) retry.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		ids := decodeStateID(d.Id())
 		projectID := ids["project_id"]
 		containerID := ids["container_id"]
 
 		var err error
-		container, res, err := client.Containers.Get(ctx, projectID, containerID)
+		container, res, err := client.NetworkPeeringApi.GetPeeringContainer(ctx, projectID, containerID).Execute()
 		if err != nil {
-			if res.StatusCode == 404 {
+			if res != nil && res.StatusCode == 404 {
 				return "", "deleted", nil
 			}
 
 			return nil, "", err
 		}
 
-		if *container.Provisioned && err == nil {
+		if container.GetProvisioned() && err == nil {
 			return nil, "provisioned_container", nil
 		}
 
-		_, err = client.Containers.Delete(ctx, projectID, containerID)
+		_, _, err = client.NetworkPeeringApi.DeletePeeringContainer(ctx, projectID, containerID).Execute()
 		if err != nil {
 			return nil, "provisioned_container", nil
 		}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
@@ -363,3 +365,50 @@ func testAccMongoDBAtlasNetworkContainerConfigGCPWithRegions(projectName, orgID,
 		}
 	`, projectName, orgID, cidrBlock, providerName)
 }
+
+func TestMockNetworkRSNetworkContainer_basic(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		mock      = newAtlasMockServer(t)
+		client    = mock.client()
+		projectID = mock.newProject("test-mock")
+		r         = resourceMongoDBAtlasNetworkContainer()
+		config    = map[string]interface{}{
+			"project_id":       projectID,
+			"atlas_cidr_block": "192.168.208.0/21",
+			"provider_name":    "AWS",
+			"region_name":      "US_EAST_1",
+		}
+	)
+
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("Bad create network container: %v", diags)
+	}
+
+	containerID := d.Get("container_id").(string)
+	if containerID == "" {
+		t.Fatal("container_id must be set after create")
+	}
+
+	if got := mock.requestCount(http.MethodPost, mockAtlasV2Path+"/groups/"+projectID+"/containers"); got != 1 {
+		t.Errorf("expected the network container to be created with the Atlas SDK, got %d requests", got)
+	}
+
+	if got := d.Get("region_name").(string); got != "US_EAST_1" {
+		t.Errorf("Bad region_name: got %q", got)
+	}
+
+	config["atlas_cidr_block"] = "192.168.216.0/21"
+	updated := schema.TestResourceDataRaw(t, r.Schema, config)
+	updated.SetId(d.Id())
+
+	if diags := r.UpdateContext(ctx, updated, client); diags.HasError() {
+		t.Fatalf("Bad update network container: %v", diags)
+	}
+
+	container := mock.get(fmt.Sprintf("%s/groups/%s/containers/%s", mockAtlasV1Path, projectID, containerID))
+	if got := fmt.Sprint(container["atlasCidrBlock"]); got != "192.168.216.0/21" {
+		t.Errorf("Bad atlasCidrBlock after update: got %q", got)
+	}
+}
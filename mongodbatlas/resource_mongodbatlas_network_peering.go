@@ -14,7 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	matlas "go.mongodb.org/atlas/mongodbatlas"
+	"go.mongodb.org/atlas-sdk/v20230201002/admin"
 )
 
 const (
@@ -150,19 +150,24 @@ func resourceMongoDBAtlasNetworkPeering() *schema.Resource {
 				Computed: true,
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(1 * time.Hour),
+			Update: schema.DefaultTimeout(1 * time.Hour),
+			Delete: schema.DefaultTimeout(1 * time.Hour),
+		},
 	}
 }
 
 func resourceMongoDBAtlasNetworkPeeringCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// Get client connection.
-	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 	projectID := d.Get("project_id").(string)
 	providerName := d.Get("provider_name").(string)
 
 	// Get the required ones
-	peerRequest := &matlas.Peer{
-		ContainerID:  getEncodedID(d.Get("container_id").(string), "container_id"),
-		ProviderName: providerName,
+	peerRequest := &admin.BaseNetworkPeeringConnectionSettings{
+		ContainerId:  getEncodedID(d.Get("container_id").(string), "container_id"),
+		ProviderName: admin.PtrString(providerName),
 	}
 
 	if providerName == "AWS" {
@@ -186,10 +191,10 @@ func resourceMongoDBAtlasNetworkPeeringCreate(ctx context.Context, d *schema.Res
 			return diag.FromErr(errors.New("`vpc_id` must be set when `provider_name` is `AWS`"))
 		}
 
-		peerRequest.AccepterRegionName = region
-		peerRequest.AWSAccountID = awsAccountID.(string)
-		peerRequest.RouteTableCIDRBlock = rtCIDR.(string)
-		peerRequest.VpcID = vpcID.(string)
+		peerRequest.AccepterRegionName = admin.PtrString(region)
+		peerRequest.AwsAccountId = admin.PtrString(awsAccountID.(string))
+		peerRequest.RouteTableCidrBlock = admin.PtrString(rtCIDR.(string))
+		peerRequest.VpcId = admin.PtrString(vpcID.(string))
 	}
 
 	if providerName == "GCP" {
@@ -203,8 +208,8 @@ func resourceMongoDBAtlasNetworkPeeringCreate(ctx context.Context, d *schema.Res
 			return diag.FromErr(errors.New("`network_name` must be set when `provider_name` is `GCP`"))
 		}
 
-		peerRequest.GCPProjectID = gcpProjectID.(string)
-		peerRequest.NetworkName = networkName.(string)
+		peerRequest.GcpProjectId = admin.PtrString(gcpProjectID.(string))
+		peerRequest.NetworkName = admin.PtrString(networkName.(string))
 	}
 
 	if providerName == "AZURE" {
@@ -228,13 +233,13 @@ func resourceMongoDBAtlasNetworkPeeringCreate(ctx context.Context, d *schema.Res
 			return diag.FromErr(errors.New("`vnet_name` must be set when `provider_name` is `AZURE`"))
 		}
 
-		peerRequest.AzureDirectoryID = azureDirectoryID.(string)
-		peerRequest.AzureSubscriptionID = azureSubscriptionID.(string)
-		peerRequest.ResourceGroupName = resourceGroupName.(string)
-		peerRequest.VNetName = vnetName.(string)
+		peerRequest.AzureDirectoryId = admin.PtrString(azureDirectoryID.(string))
+		peerRequest.AzureSubscriptionId = admin.PtrString(azureSubscriptionID.(string))
+		peerRequest.ResourceGroupName = admin.PtrString(resourceGroupName.(string))
+		peerRequest.VnetName = admin.PtrString(vnetName.(string))
 	}
 
-	peer, _, err := conn.Peers.Create(ctx, projectID, peerRequest)
+	peer, _, err := connV2.NetworkPeeringApi.CreatePeeringConnection(ctx, projectID, peerRequest).Execute()
 	if err != nil {
 		return diag.FromErr(fmt.Errorf(errorPeersCreate, err))
 	}
@@ -242,8 +247,8 @@ func resourceMongoDBAtlasNetworkPeeringCreate(ctx context.Context, d *schema.Res
 	stateConf := &retry.StateChangeConf{
 		Pending:    []string{"INITIATING", "FINALIZING", "ADDING_PEER", "WAITING_FOR_USER"},
 		Target:     []string{"AVAILABLE", "PENDING_ACCEPTANCE"},
-		Refresh:    resourceNetworkPeeringRefreshFunc(ctx, peer.ID, projectID, peerRequest.ContainerID, conn),
-		Timeout:    1 * time.Hour,
+		Refresh:    resourceNetworkPeeringRefreshFunc(ctx, peer.GetId(), projectID, peerRequest.ContainerId, connV2),
+		Timeout:    d.Timeout(schema.TimeoutCreate),
 		MinTimeout: 10 * time.Second,
 		Delay:      30 * time.Second,
 	}
@@ -256,7 +261,7 @@ func resourceMongoDBAtlasNetworkPeeringCreate(ctx context.Context, d *schema.Res
 
 	d.SetId(encodeStateID(map[string]string{
 		"project_id":    projectID,
-		"peer_id":       peer.ID,
+		"peer_id":       peer.GetId(),
 		"provider_name": providerName,
 	}))
 
@@ -265,13 +270,13 @@ func resourceMongoDBAtlasNetworkPeeringCreate(ctx context.Context, d *schema.Res
 
 func resourceMongoDBAtlasNetworkPeeringRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// Get client connection.
-	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 	ids := decodeStateID(d.Id())
 	projectID := ids["project_id"]
 	peerID := ids["peer_id"]
 	providerName := ids["provider_name"]
 
-	peer, resp, err := conn.Peers.Get(ctx, projectID, peerID)
+	peer, resp, err := connV2.NetworkPeeringApi.GetPeeringConnection(ctx, projectID, peerID).Execute()
 	if err != nil {
 		if resp != nil && resp.StatusCode == http.StatusNotFound {
 			d.SetId("")
@@ -286,104 +291,104 @@ func resourceMongoDBAtlasNetworkPeeringRead(ctx context.Context, d *schema.Resou
 	 * the API returns it as a null value, so this causes the issue mentioned.
 	 */
 	var acepterRegionName string
-	if peer.AccepterRegionName != "" {
-		acepterRegionName = peer.AccepterRegionName
+	if peer.GetAccepterRegionName() != "" {
+		acepterRegionName = peer.GetAccepterRegionName()
 	} else {
-		container, _, err := conn.Containers.Get(ctx, projectID, peer.ContainerID)
+		container, _, err := connV2.NetworkPeeringApi.GetPeeringContainer(ctx, projectID, peer.ContainerId).Execute()
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		acepterRegionName = strings.ToLower(strings.ReplaceAll(container.RegionName, "_", "-"))
+		acepterRegionName = strings.ToLower(strings.ReplaceAll(container.GetRegionName(), "_", "-"))
 	}
 
 	if err := d.Set("accepter_region_name", acepterRegionName); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `accepter_region_name` for Network Peering Connection (%s): %s", peerID, err))
 	}
 
-	if err := d.Set("aws_account_id", peer.AWSAccountID); err != nil {
+	if err := d.Set("aws_account_id", peer.GetAwsAccountId()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `aws_account_id` for Network Peering Connection (%s): %s", peerID, err))
 	}
 
-	if err := d.Set("route_table_cidr_block", peer.RouteTableCIDRBlock); err != nil {
+	if err := d.Set("route_table_cidr_block", peer.GetRouteTableCidrBlock()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `route_table_cidr_block` for Network Peering Connection (%s): %s", peerID, err))
 	}
 
-	if err := d.Set("vpc_id", peer.VpcID); err != nil {
+	if err := d.Set("vpc_id", peer.GetVpcId()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `vpc_id` for Network Peering Connection (%s): %s", peerID, err))
 	}
 
-	if err := d.Set("connection_id", peer.ConnectionID); err != nil {
+	if err := d.Set("connection_id", peer.GetConnectionId()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `connection_id` for Network Peering Connection (%s): %s", peerID, err))
 	}
 
-	if err := d.Set("error_state_name", peer.ErrorStateName); err != nil {
+	if err := d.Set("error_state_name", peer.GetErrorStateName()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `error_state_name` for Network Peering Connection (%s): %s", peerID, err))
 	}
 
-	if err := d.Set("atlas_id", peer.ID); err != nil {
+	if err := d.Set("atlas_id", peer.GetId()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `atlas_id` for Network Peering Connection (%s): %s", peerID, err))
 	}
 
-	if err := d.Set("status_name", peer.StatusName); err != nil {
+	if err := d.Set("status_name", peer.GetStatusName()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `status_name` for Network Peering Connection (%s): %s", peerID, err))
 	}
 
-	if err := d.Set("azure_directory_id", peer.AzureDirectoryID); err != nil {
+	if err := d.Set("azure_directory_id", peer.GetAzureDirectoryId()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `azure_directory_id` for Network Peering Connection (%s): %s", peerID, err))
 	}
 
-	if err := d.Set("azure_subscription_id", peer.AzureSubscriptionID); err != nil {
+	if err := d.Set("azure_subscription_id", peer.GetAzureSubscriptionId()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `azure_subscription_id` for Network Peering Connection (%s): %s", peerID, err))
 	}
 
-	if err := d.Set("resource_group_name", peer.ResourceGroupName); err != nil {
+	if err := d.Set("resource_group_name", peer.GetResourceGroupName()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `resource_group_name` for Network Peering Connection (%s): %s", peerID, err))
 	}
 
-	if err := d.Set("vnet_name", peer.VNetName); err != nil {
+	if err := d.Set("vnet_name", peer.GetVnetName()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `vnet_name` for Network Peering Connection (%s): %s", peerID, err))
 	}
 
-	if err := d.Set("error_state", peer.ErrorState); err != nil {
+	if err := d.Set("error_state", peer.GetErrorState()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `error_state` for Network Peering Connection (%s): %s", peerID, err))
 	}
 
-	if err := d.Set("status", peer.Status); err != nil {
+	if err := d.Set("status", peer.GetStatus()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `status` for Network Peering Connection (%s): %s", peerID, err))
 	}
 
-	if err := d.Set("gcp_project_id", peer.GCPProjectID); err != nil {
+	if err := d.Set("gcp_project_id", peer.GetGcpProjectId()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `gcp_project_id` for Network Peering Connection (%s): %s", peerID, err))
 	}
 
-	if err := d.Set("network_name", peer.NetworkName); err != nil {
+	if err := d.Set("network_name", peer.GetNetworkName()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `network_name` for Network Peering Connection (%s): %s", peerID, err))
 	}
 
-	if err := d.Set("error_message", peer.ErrorMessage); err != nil {
+	if err := d.Set("error_message", peer.GetErrorMessage()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `error_message` for Network Peering Connection (%s): %s", peerID, err))
 	}
 
-	if err := d.Set("peer_id", peer.ID); err != nil {
+	if err := d.Set("peer_id", peer.GetId()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `peer_id` for Network Peering Connection (%s): %s", peerID, err))
 	}
 
 	// If provider name is GCP we need to get the parameters to configure the the reciprocal connection
 	//  between Mongo and Google
-	container := &matlas.Container{}
+	container := &admin.CloudProviderContainer{}
 
 	if strings.EqualFold(providerName, "GCP") {
-		container, _, err = conn.Containers.Get(ctx, projectID, peer.ContainerID)
+		container, _, err = connV2.NetworkPeeringApi.GetPeeringContainer(ctx, projectID, peer.ContainerId).Execute()
 		if err != nil {
 			return diag.FromErr(err)
 		}
 	}
 
-	if err := d.Set("atlas_gcp_project_id", container.GCPProjectID); err != nil {
+	if err := d.Set("atlas_gcp_project_id", container.GetGcpProjectId()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `atlas_gcp_project_id` for Network Peering Connection (%s): %s", peerID, err))
 	}
 
-	if err := d.Set("atlas_vpc_name", container.NetworkName); err != nil {
+	if err := d.Set("atlas_vpc_name", container.GetNetworkName()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting `atlas_vpc_name` for Network Peering Connection (%s): %s", peerID, err))
 	}
 
@@ -392,49 +397,49 @@ func resourceMongoDBAtlasNetworkPeeringRead(ctx context.Context, d *schema.Resou
 
 func resourceMongoDBAtlasNetworkPeeringUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// Get client connection.
-	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 	ids := decodeStateID(d.Id())
 	projectID := ids["project_id"]
 	peerID := ids["peer_id"]
 
 	// All the request to update the peer require the ProviderName and ContainerID attribute.
-	peer := &matlas.Peer{
-		ProviderName: ids["provider_name"],
-		ContainerID:  getEncodedID(d.Get("container_id").(string), "container_id"),
+	peer := &admin.BaseNetworkPeeringConnectionSettings{
+		ProviderName: admin.PtrString(ids["provider_name"]),
+		ContainerId:  getEncodedID(d.Get("container_id").(string), "container_id"),
 	}
 
 	// Depending of the Provider name the request will be set
-	switch peer.ProviderName {
+	switch peer.GetProviderName() {
 	case "GCP":
-		peer.GCPProjectID = d.Get("gcp_project_id").(string)
-		peer.NetworkName = d.Get("network_name").(string)
+		peer.GcpProjectId = admin.PtrString(d.Get("gcp_project_id").(string))
+		peer.NetworkName = admin.PtrString(d.Get("network_name").(string))
 	case "AZURE":
 		if d.HasChange("azure_directory_id") {
-			peer.AzureDirectoryID = d.Get("azure_directory_id").(string)
+			peer.AzureDirectoryId = admin.PtrString(d.Get("azure_directory_id").(string))
 		}
 
 		if d.HasChange("azure_subscription_id") {
-			peer.AzureSubscriptionID = d.Get("azure_subscription_id").(string)
+			peer.AzureSubscriptionId = admin.PtrString(d.Get("azure_subscription_id").(string))
 		}
 
 		if d.HasChange("resource_group_name") {
-			peer.ResourceGroupName = d.Get("resource_group_name").(string)
+			peer.ResourceGroupName = admin.PtrString(d.Get("resource_group_name").(string))
 		}
 
 		if d.HasChange("vnet_name") {
-			peer.VNetName = d.Get("vnet_name").(string)
+			peer.VnetName = admin.PtrString(d.Get("vnet_name").(string))
 		}
 	default: // AWS by default
 		region, _ := valRegion(d.Get("accepter_region_name"), "network_peering")
-		peer.AccepterRegionName = region
-		peer.AWSAccountID = d.Get("aws_account_id").(string)
-		peer.RouteTableCIDRBlock = d.Get("route_table_cidr_block").(string)
-		peer.VpcID = d.Get("vpc_id").(string)
+		peer.AccepterRegionName = admin.PtrString(region)
+		peer.AwsAccountId = admin.PtrString(d.Get("aws_account_id").(string))
+		peer.RouteTableCidrBlock = admin.PtrString(d.Get("route_table_cidr_block").(string))
+		peer.VpcId = admin.PtrString(d.Get("vpc_id").(string))
 	}
 
 	// Has changes
-	if !reflect.DeepEqual(peer, matlas.Peer{}) {
-		_, _, err := conn.Peers.Update(ctx, projectID, peerID, peer)
+	if !reflect.DeepEqual(peer, &admin.BaseNetworkPeeringConnectionSettings{}) {
+		_, _, err := connV2.NetworkPeeringApi.UpdatePeeringConnection(ctx, projectID, peerID, peer).Execute()
 		if err != nil {
 			return diag.FromErr(fmt.Errorf(errorPeersUpdate, peerID, err))
 		}
@@ -443,8 +448,8 @@ func resourceMongoDBAtlasNetworkPeeringUpdate(ctx context.Context, d *schema.Res
 	stateConf := &retry.StateChangeConf{
 		Pending:    []string{"INITIATING", "FINALIZING", "ADDING_PEER", "WAITING_FOR_USER"},
 		Target:     []string{"AVAILABLE", "PENDING_ACCEPTANCE"},
-		Refresh:    resourceNetworkPeeringRefreshFunc(ctx, peerID, projectID, "", conn),
-		Timeout:    d.Timeout(schema.TimeoutCreate),
+		Refresh:    resourceNetworkPeeringRefreshFunc(ctx, peerID, projectID, "", connV2),
+		Timeout:    d.Timeout(schema.TimeoutUpdate),
 		MinTimeout: 30 * time.Second,
 		Delay:      1 * time.Minute,
 	}
@@ -460,12 +465,12 @@ func resourceMongoDBAtlasNetworkPeeringUpdate(ctx context.Context, d *schema.Res
 
 func resourceMongoDBAtlasNetworkPeeringDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// Get client connection.
-	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 	ids := decodeStateID(d.Id())
 	projectID := ids["project_id"]
 	peerID := ids["peer_id"]
 
-	_, err := conn.Peers.Delete(ctx, projectID, peerID)
+	_, _, err := connV2.NetworkPeeringApi.DeletePeeringConnection(ctx, projectID, peerID).Execute()
 	if err != nil {
 		return diag.FromErr(fmt.Errorf(errorPeersDelete, peerID, err))
 	}
@@ -475,8 +480,8 @@ func resourceMongoDBAtlasNetworkPeeringDelete(ctx context.Context, d *schema.Res
 	stateConf := &retry.StateChangeConf{
 		Pending:    []string{"AVAILABLE", "INITIATING", "PENDING_ACCEPTANCE", "FINALIZING", "ADDING_PEER", "WAITING_FOR_USER", "TERMINATING", "DELETING"},
 		Target:     []string{"DELETED"},
-		Refresh:    resourceNetworkPeeringRefreshFunc(ctx, peerID, projectID, "", conn),
-		Timeout:    1 * time.Hour,
+		Refresh:    resourceNetworkPeeringRefreshFunc(ctx, peerID, projectID, "", connV2),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
 		MinTimeout: 30 * time.Second,
 		Delay:      10 * time.Second, // Wait 10 secs before starting
 	}
@@ -491,7 +496,7 @@ func resourceMongoDBAtlasNetworkPeeringDelete(ctx context.Context, d *schema.Res
 }
 
 func resourceMongoDBAtlasNetworkPeeringImportState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	conn := meta.(*MongoDBClient).Atlas
+	connV2 := meta.(*MongoDBClient).AtlasV2
 
 	parts := strings.SplitN(d.Id(), "-", 3)
 	if len(parts) != 3 {
@@ -502,7 +507,7 @@ func resourceMongoDBAtlasNetworkPeeringImportState(ctx context.Context, d *schem
 	peerID := parts[1]
 	providerName := parts[2]
 
-	peer, _, err := conn.Peers.Get(ctx, projectID, peerID)
+	peer, _, err := connV2.NetworkPeeringApi.GetPeeringConnection(ctx, projectID, peerID).Execute()
 	if err != nil {
 		return nil, fmt.Errorf("couldn't import peer %s in project %s, error: %s", peerID, projectID, err)
 	}
@@ -511,7 +516,7 @@ func resourceMongoDBAtlasNetworkPeeringImportState(ctx context.Context, d *schem
 		log.Printf("[WARN] Error setting project_id for (%s): %s", peerID, err)
 	}
 
-	if err := d.Set("container_id", peer.ContainerID); err != nil {
+	if err := d.Set("container_id", peer.ContainerId); err != nil {
 		log.Printf("[WARN] Error setting container_id for (%s): %s", peerID, err)
 	}
 
@@ -521,16 +526,16 @@ func resourceMongoDBAtlasNetworkPeeringImportState(ctx context.Context, d *schem
 
 	d.SetId(encodeStateID(map[string]string{
 		"project_id":    projectID,
-		"peer_id":       peer.ID,
+		"peer_id":       peer.GetId(),
 		"provider_name": providerName,
 	}))
 
 	return []*schema.ResourceData{d}, nil
 }
 
-func resourceNetworkPeeringRefreshFunc(ctx context.Context, peerID, projectID, containerID string, client *matlas.Client) retry.StateRefreshFunc {
+func resourceNetworkPeeringRefreshFunc(ctx context.Context, peerID, projectID, containerID string, client *admin.APIClient) retry.StateRefreshFunc {
 	return func() (interface{}, string, error) {
-		c, resp, err := client.Peers.Get(ctx, projectID, peerID)
+		c, resp, err := client.NetworkPeeringApi.GetPeeringConnection(ctx, projectID, peerID).Execute()
 		if err != nil {
 			if resp != nil && resp.StatusCode == 404 {
 				return "", "DELETED", nil
@@ -541,10 +546,10 @@ func resourceNetworkPeeringRefreshFunc(ctx context.Context, peerID, projectID, c
 			return nil, "", err
 		}
 
-		status := c.Status
+		status := c.GetStatus()
 
-		if len(c.StatusName) > 0 {
-			status = c.StatusName
+		if len(c.GetStatusName()) > 0 {
+			status = c.GetStatusName()
 		}
 
 		log.Printf("[DEBUG] status for MongoDB Network Peering Connection: %s: %s", peerID, status)
@@ -554,13 +559,13 @@ func resourceNetworkPeeringRefreshFunc(ctx context.Context, peerID, projectID, c
 		 * is right, and the Mongo parameters used on the Google side to configure the reciprocal connection
 		 * are now available. */
 		if status == "WAITING_FOR_USER" {
-			container, _, err := client.Containers.Get(ctx, projectID, containerID)
+			container, _, err := client.NetworkPeeringApi.GetPeeringContainer(ctx, projectID, containerID).Execute()
 
 			if err != nil {
 				return nil, "", fmt.Errorf(errorContainerRead, containerID, err)
 			}
 
-			if *container.Provisioned {
+			if container.GetProvisioned() {
 				return container, "PENDING_ACCEPTANCE", nil
 			}
 		}
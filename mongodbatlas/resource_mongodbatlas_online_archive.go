@@ -33,6 +33,10 @@ func resourceMongoDBAtlasOnlineArchive() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceMongoDBAtlasOnlineArchiveImportState,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(3 * time.Hour),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 	}
 }
 
@@ -203,7 +207,7 @@ func resourceMongoDBAtlasOnlineArchiveCreate(ctx context.Context, d *schema.Reso
 			Pending:    []string{"PENDING", "ARCHIVING", "PAUSING", "PAUSED", "ORPHANED", "REPEATING"},
 			Target:     []string{"IDLE", "ACTIVE"},
 			Refresh:    resourceOnlineRefreshFunc(ctx, projectID, outputRequest.ClusterName, outputRequest.ID, conn),
-			Timeout:    3 * time.Hour,
+			Timeout:    d.Timeout(schema.TimeoutCreate),
 			MinTimeout: 1 * time.Minute,
 			Delay:      3 * time.Minute,
 		}
@@ -290,6 +294,21 @@ func resourceMongoDBAtlasOnlineArchiveDelete(ctx context.Context, d *schema.Reso
 
 		return diag.FromErr(fmt.Errorf(errorOnlineArchivesDelete, err, atlasID))
 	}
+
+	stateConf := &retry.StateChangeConf{
+		Pending:    []string{"PENDING", "ARCHIVING", "IDLE", "ACTIVE", "PAUSING", "PAUSED", "ORPHANED", "REPEATING"},
+		Target:     []string{"DELETED"},
+		Refresh:    resourceOnlineRefreshFunc(ctx, projectID, clusterName, atlasID, conn),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		MinTimeout: 10 * time.Second,
+	}
+
+	// Wait, catching any errors
+	_, err = stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf(errorOnlineArchivesDelete, err, atlasID))
+	}
+
 	return nil
 }
 
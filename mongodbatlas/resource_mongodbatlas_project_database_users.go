@@ -0,0 +1,384 @@
+package mongodbatlas
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"go.mongodb.org/atlas-sdk/v20230201002/admin"
+)
+
+const (
+	errorProjectDatabaseUsersCreate  = "error creating the database users of the project (%s): %s"
+	errorProjectDatabaseUsersRead    = "error reading the database users of the project (%s): %s"
+	errorProjectDatabaseUsersUpdate  = "error updating the database users of the project (%s): %s"
+	errorProjectDatabaseUsersDelete  = "error deleting the database users of the project (%s): %s"
+	errorProjectDatabaseUsersSetting = "error setting `%s` for the database users of the project (%s): %s"
+)
+
+// resourceMongoDBAtlasProjectDatabaseUsers manages all the database users of a project: the users that aren't
+// declared in users, e.g. created in the Atlas UI, are read in the state so that their deletion is planned.
+func resourceMongoDBAtlasProjectDatabaseUsers() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceMongoDBAtlasProjectDatabaseUsersCreate,
+		ReadContext:   resourceMongoDBAtlasProjectDatabaseUsersRead,
+		UpdateContext: resourceMongoDBAtlasProjectDatabaseUsersUpdate,
+		DeleteContext: resourceMongoDBAtlasProjectDatabaseUsersDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"users": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"username": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"auth_database_name": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "admin",
+						},
+						"password": {
+							Type:      schema.TypeString,
+							Optional:  true,
+							Sensitive: true,
+						},
+						"x509_type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "NONE",
+							ValidateFunc: validation.StringInSlice([]string{"NONE", "MANAGED", "CUSTOMER"}, false),
+						},
+						"ldap_auth_type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "NONE",
+							ValidateFunc: validation.StringInSlice([]string{"NONE", "USER", "GROUP"}, false),
+						},
+						"aws_iam_type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "NONE",
+							ValidateFunc: validation.StringInSlice([]string{"NONE", "USER", "ROLE"}, false),
+						},
+						"oidc_auth_type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "NONE",
+							ValidateFunc: validation.StringInSlice([]string{"NONE", "IDP_GROUP"}, false),
+						},
+						"roles": {
+							Type:     schema.TypeSet,
+							Required: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"role_name": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"database_name": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"collection_name": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+								},
+							},
+						},
+						"scopes": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"type": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringInSlice([]string{"CLUSTER", "DATA_LAKE"}, false),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceMongoDBAtlasProjectDatabaseUsersCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	connV2 := meta.(*MongoDBClient).AtlasV2
+	projectID := d.Get("project_id").(string)
+
+	// the declared users that already exist in the project are updated to the configuration, the project can't
+	// have other users: they must be imported first rather than deleted without being planned.
+	if err := applyProjectDatabaseUsers(ctx, connV2, projectID, nil, d.Get("users").(*schema.Set)); err != nil {
+		return diag.FromErr(fmt.Errorf(errorProjectDatabaseUsersCreate, projectID, err))
+	}
+
+	d.SetId(projectID)
+
+	return resourceMongoDBAtlasProjectDatabaseUsersRead(ctx, d, meta)
+}
+
+func resourceMongoDBAtlasProjectDatabaseUsersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	connV2 := meta.(*MongoDBClient).AtlasV2
+	projectID := d.Id()
+
+	dbUsers, resp, err := listProjectDatabaseUsers(ctx, connV2, projectID)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			d.SetId("")
+			return nil
+		}
+
+		return diag.FromErr(fmt.Errorf(errorProjectDatabaseUsersRead, projectID, err))
+	}
+
+	if err := d.Set("project_id", projectID); err != nil {
+		return diag.FromErr(fmt.Errorf(errorProjectDatabaseUsersSetting, "project_id", projectID, err))
+	}
+
+	// Atlas doesn't return the passwords, the users keep the password of the state.
+	passwords := make(map[string]string)
+	if v, ok := d.GetOk("users"); ok {
+		for _, u := range v.(*schema.Set).List() {
+			tfMap := u.(map[string]interface{})
+			passwords[projectDatabaseUserKey(tfMap["auth_database_name"].(string), tfMap["username"].(string))] = tfMap["password"].(string)
+		}
+	}
+
+	if err := d.Set("users", flattenProjectDatabaseUsers(dbUsers, passwords)); err != nil {
+		return diag.FromErr(fmt.Errorf(errorProjectDatabaseUsersSetting, "users", projectID, err))
+	}
+
+	return nil
+}
+
+func resourceMongoDBAtlasProjectDatabaseUsersUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	connV2 := meta.(*MongoDBClient).AtlasV2
+	projectID := d.Id()
+
+	if d.HasChange("users") {
+		oldUsers, newUsers := d.GetChange("users")
+		if err := applyProjectDatabaseUsers(ctx, connV2, projectID, oldUsers.(*schema.Set), newUsers.(*schema.Set)); err != nil {
+			return diag.FromErr(fmt.Errorf(errorProjectDatabaseUsersUpdate, projectID, err))
+		}
+	}
+
+	return resourceMongoDBAtlasProjectDatabaseUsersRead(ctx, d, meta)
+}
+
+func resourceMongoDBAtlasProjectDatabaseUsersDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	connV2 := meta.(*MongoDBClient).AtlasV2
+	projectID := d.Id()
+
+	for _, u := range d.Get("users").(*schema.Set).List() {
+		dbUser := expandProjectDatabaseUser(projectID, u.(map[string]interface{}))
+
+		_, resp, err := connV2.DatabaseUsersApi.DeleteDatabaseUser(ctx, projectID, dbUser.DatabaseName, dbUser.Username).Execute()
+		if err != nil {
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				continue
+			}
+
+			return diag.FromErr(fmt.Errorf(errorProjectDatabaseUsersDelete, projectID, err))
+		}
+	}
+
+	return nil
+}
+
+// applyProjectDatabaseUsers makes the users of the project match desired: it creates the missing users, updates
+// the users that differ and deletes the users that aren't declared. A password is only sent when it changed since
+// previous, the users of the last apply, as Atlas doesn't return the passwords to compare them with. previous is nil
+// when the resource is created, the users that aren't declared are then an error instead of being deleted.
+func applyProjectDatabaseUsers(ctx context.Context, connV2 *admin.APIClient, projectID string, previous, desired *schema.Set) error {
+	current, _, err := listProjectDatabaseUsers(ctx, connV2, projectID)
+	if err != nil {
+		return err
+	}
+
+	currentUsers := make(map[string]*admin.CloudDatabaseUser, len(current))
+	for i := range current {
+		currentUsers[projectDatabaseUserKey(current[i].DatabaseName, current[i].Username)] = &current[i]
+	}
+
+	previousPasswords := make(map[string]string)
+	if previous != nil {
+		for _, u := range previous.List() {
+			tfMap := u.(map[string]interface{})
+			previousPasswords[projectDatabaseUserKey(tfMap["auth_database_name"].(string), tfMap["username"].(string))] = tfMap["password"].(string)
+		}
+	}
+
+	declared := make(map[string]bool)
+	for _, u := range desired.List() {
+		tfMap := u.(map[string]interface{})
+		declared[projectDatabaseUserKey(tfMap["auth_database_name"].(string), tfMap["username"].(string))] = true
+	}
+
+	if previous == nil {
+		var undeclared []string
+		for i := range current {
+			if key := projectDatabaseUserKey(current[i].DatabaseName, current[i].Username); !declared[key] {
+				undeclared = append(undeclared, key)
+			}
+		}
+
+		if len(undeclared) > 0 {
+			return fmt.Errorf("the project already has database users that aren't declared (%s), "+
+				"import them with `terraform import` before applying the configuration", strings.Join(undeclared, ", "))
+		}
+	}
+
+	for _, u := range desired.List() {
+		dbUser := expandProjectDatabaseUser(projectID, u.(map[string]interface{}))
+		key := projectDatabaseUserKey(dbUser.DatabaseName, dbUser.Username)
+
+		currentUser, exists := currentUsers[key]
+		if !exists {
+			log.Printf("[INFO] Creating database user %s of project %s", key, projectID)
+			if _, _, err := connV2.DatabaseUsersApi.CreateDatabaseUser(ctx, projectID, dbUser).Execute(); err != nil {
+				return fmt.Errorf("error creating database user %s: %s", key, err)
+			}
+			continue
+		}
+
+		passwordChanged := dbUser.GetPassword() != "" && dbUser.GetPassword() != previousPasswords[key]
+		if !passwordChanged {
+			dbUser.Password = nil
+			if !isProjectDatabaseUserChanged(dbUser, currentUser) {
+				continue
+			}
+		}
+
+		log.Printf("[INFO] Updating database user %s of project %s", key, projectID)
+		if _, _, err := connV2.DatabaseUsersApi.UpdateDatabaseUser(ctx, projectID, dbUser.DatabaseName, dbUser.Username, dbUser).Execute(); err != nil {
+			return fmt.Errorf("error updating database user %s: %s", key, err)
+		}
+	}
+
+	for i := range current {
+		key := projectDatabaseUserKey(current[i].DatabaseName, current[i].Username)
+		if declared[key] {
+			continue
+		}
+
+		log.Printf("[INFO] Deleting database user %s of project %s, it isn't declared", key, projectID)
+		_, resp, err := connV2.DatabaseUsersApi.DeleteDatabaseUser(ctx, projectID, current[i].DatabaseName, current[i].Username).Execute()
+		if err != nil && (resp == nil || resp.StatusCode != http.StatusNotFound) {
+			return fmt.Errorf("error deleting database user %s: %s", key, err)
+		}
+	}
+
+	return nil
+}
+
+func listProjectDatabaseUsers(ctx context.Context, connV2 *admin.APIClient, projectID string) ([]admin.CloudDatabaseUser, *http.Response, error) {
+	var dbUsers []admin.CloudDatabaseUser
+
+	for pageNum := 1; ; pageNum++ {
+		page, resp, err := connV2.DatabaseUsersApi.ListDatabaseUsers(ctx, projectID).PageNum(pageNum).ItemsPerPage(listItemsPerPage).Execute()
+		if err != nil {
+			return nil, resp, err
+		}
+
+		dbUsers = append(dbUsers, page.Results...)
+		if len(page.Results) < listItemsPerPage {
+			break
+		}
+	}
+
+	return dbUsers, nil, nil
+}
+
+// projectDatabaseUserKey identifies a database user in its project, e.g. admin/app-user.
+func projectDatabaseUserKey(authDatabaseName, username string) string {
+	return authDatabaseName + "/" + username
+}
+
+// isProjectDatabaseUserChanged compares the attributes of the users managed by mongodbatlas_project_database_users.
+func isProjectDatabaseUserChanged(desired, current *admin.CloudDatabaseUser) bool {
+	return desired.GetX509Type() != current.GetX509Type() ||
+		desired.GetLdapAuthType() != current.GetLdapAuthType() ||
+		desired.GetAwsIAMType() != current.GetAwsIAMType() ||
+		desired.GetOidcAuthType() != current.GetOidcAuthType() ||
+		databaseUserRolesKey(desired.Roles) != databaseUserRolesKey(current.Roles) ||
+		databaseUserScopesKey(desired.Scopes) != databaseUserScopesKey(current.Scopes)
+}
+
+func databaseUserRolesKey(roles []admin.DatabaseUserRole) string {
+	keys := make([]string, len(roles))
+	for i := range roles {
+		keys[i] = fmt.Sprintf("%s@%s.%s", roles[i].RoleName, roles[i].DatabaseName, roles[i].GetCollectionName())
+	}
+	sort.Strings(keys)
+
+	return strings.Join(keys, ",")
+}
+
+func databaseUserScopesKey(scopes []admin.UserScope) string {
+	keys := make([]string, len(scopes))
+	for i := range scopes {
+		keys[i] = scopes[i].Type + ":" + scopes[i].Name
+	}
+	sort.Strings(keys)
+
+	return strings.Join(keys, ",")
+}
+
+func expandProjectDatabaseUser(projectID string, tfMap map[string]interface{}) *admin.CloudDatabaseUser {
+	return &admin.CloudDatabaseUser{
+		GroupId:      projectID,
+		Username:     tfMap["username"].(string),
+		DatabaseName: tfMap["auth_database_name"].(string),
+		Password:     stringPtrOrNil(tfMap["password"].(string)),
+		X509Type:     stringPtrOrNil(tfMap["x509_type"].(string)),
+		LdapAuthType: stringPtrOrNil(tfMap["ldap_auth_type"].(string)),
+		AwsIAMType:   stringPtrOrNil(tfMap["aws_iam_type"].(string)),
+		OidcAuthType: stringPtrOrNil(tfMap["oidc_auth_type"].(string)),
+		Roles:        expandRolesSet(tfMap["roles"].(*schema.Set)),
+		Scopes:       expandScopesSet(tfMap["scopes"].(*schema.Set)),
+	}
+}
+
+func flattenProjectDatabaseUsers(dbUsers []admin.CloudDatabaseUser, passwords map[string]string) []map[string]interface{} {
+	users := make([]map[string]interface{}, len(dbUsers))
+
+	for i := range dbUsers {
+		users[i] = map[string]interface{}{
+			"username":           dbUsers[i].Username,
+			"auth_database_name": dbUsers[i].DatabaseName,
+			"password":           passwords[projectDatabaseUserKey(dbUsers[i].DatabaseName, dbUsers[i].Username)],
+			"x509_type":          dbUsers[i].GetX509Type(),
+			"ldap_auth_type":     dbUsers[i].GetLdapAuthType(),
+			"aws_iam_type":       dbUsers[i].GetAwsIAMType(),
+			"oidc_auth_type":     dbUsers[i].GetOidcAuthType(),
+			"roles":              flattenRoles(dbUsers[i].Roles),
+			"scopes":             flattenScopes(dbUsers[i].Scopes),
+		}
+	}
+
+	return users
+}
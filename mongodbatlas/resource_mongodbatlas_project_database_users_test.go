@@ -0,0 +1,289 @@
+package mongodbatlas
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"go.mongodb.org/atlas-sdk/v20230201002/admin"
+)
+
+func TestIsProjectDatabaseUserChanged(t *testing.T) {
+	current := &admin.CloudDatabaseUser{
+		Username:     "app-user",
+		DatabaseName: "admin",
+		X509Type:     admin.PtrString("NONE"),
+		Roles: []admin.DatabaseUserRole{
+			{RoleName: "read", DatabaseName: "admin"},
+			{RoleName: "readWrite", DatabaseName: "app", CollectionName: admin.PtrString("orders")},
+		},
+		Scopes: []admin.UserScope{{Name: "cluster0", Type: "CLUSTER"}},
+	}
+
+	testCases := []struct {
+		desired *admin.CloudDatabaseUser
+		name    string
+		want    bool
+	}{
+		{
+			name: "same user, other order",
+			desired: &admin.CloudDatabaseUser{
+				X509Type: admin.PtrString("NONE"),
+				Roles: []admin.DatabaseUserRole{
+					{RoleName: "readWrite", DatabaseName: "app", CollectionName: admin.PtrString("orders")},
+					{RoleName: "read", DatabaseName: "admin"},
+				},
+				Scopes: []admin.UserScope{{Name: "cluster0", Type: "CLUSTER"}},
+			},
+		},
+		{
+			name: "other role",
+			desired: &admin.CloudDatabaseUser{
+				X509Type: admin.PtrString("NONE"),
+				Roles:    []admin.DatabaseUserRole{{RoleName: "read", DatabaseName: "admin"}},
+				Scopes:   []admin.UserScope{{Name: "cluster0", Type: "CLUSTER"}},
+			},
+			want: true,
+		},
+		{
+			name: "no scopes",
+			desired: &admin.CloudDatabaseUser{
+				X509Type: admin.PtrString("NONE"),
+				Roles:    current.Roles,
+			},
+			want: true,
+		},
+		{
+			name: "other authentication",
+			desired: &admin.CloudDatabaseUser{
+				X509Type: admin.PtrString("MANAGED"),
+				Roles:    current.Roles,
+				Scopes:   current.Scopes,
+			},
+			want: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isProjectDatabaseUserChanged(tc.desired, current); got != tc.want {
+				t.Errorf("isProjectDatabaseUserChanged() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMockConfigRSProjectDatabaseUsers_basic(t *testing.T) {
+	var (
+		mock         = newAtlasMockServer(t)
+		projectID    = mock.newProject("test-mock")
+		resourceName = "mongodbatlas_project_database_users.test"
+		usersPath    = fmt.Sprintf("%s/groups/%s/databaseUsers", mockAtlasV2Path, projectID)
+	)
+
+	seedUser := func(username string) {
+		mock.seed(usersPath, map[string]interface{}{
+			"groupId":      projectID,
+			"username":     username,
+			"databaseName": "admin",
+			"roles":        []interface{}{map[string]interface{}{"roleName": "atlasAdmin", "databaseName": "admin"}},
+		})
+	}
+
+	testUserDeleted := func(username string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			if mock.get(usersPath+"/admin/"+username) != nil {
+				return fmt.Errorf("the undeclared database user %s wasn't deleted", username)
+			}
+			return nil
+		}
+	}
+
+	// a user created in the Atlas UI before the resource.
+	seedUser("manual-user")
+
+	testMockResourceUnitTest(t, mock, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:      testMockMongoDBAtlasProjectDatabaseUsersConfig(projectID, "read"),
+				ExpectError: regexp.MustCompile(`database users that aren't declared \(admin/manual-user\), import them`),
+			},
+			{
+				// the creation failed without any change, the user is imported and its deletion planned.
+				Config:             testMockMongoDBAtlasProjectDatabaseUsersConfig(projectID, "read"),
+				ResourceName:       resourceName,
+				ImportState:        true,
+				ImportStateId:      projectID,
+				ImportStatePersist: true,
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 || states[0].Attributes["users.#"] != "1" {
+						return fmt.Errorf("expected only the undeclared user of the project to be imported")
+					}
+					return nil
+				},
+			},
+			{
+				Config: testMockMongoDBAtlasProjectDatabaseUsersConfig(projectID, "read"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "project_id", projectID),
+					resource.TestCheckResourceAttr(resourceName, "users.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "users.*", map[string]string{
+						"username":           "app-user",
+						"auth_database_name": "admin",
+						"password":           "test-mock-password",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "users.*", map[string]string{
+						"username":  "report-user",
+						"roles.#":   "1",
+						"scopes.#":  "1",
+						"x509_type": "NONE",
+					}),
+					testUserDeleted("manual-user"),
+				),
+			},
+			{
+				// a user created out of band is planned for deletion.
+				PreConfig: func() { seedUser("out-of-band-user") },
+				Config:    testMockMongoDBAtlasProjectDatabaseUsersConfig(projectID, "read"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "users.#", "2"),
+					testUserDeleted("out-of-band-user"),
+				),
+			},
+			{
+				Config: testMockMongoDBAtlasProjectDatabaseUsersConfig(projectID, "readWrite"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "users.*.roles.*", map[string]string{
+						"role_name":     "readWrite",
+						"database_name": "reports",
+					}),
+					func(*terraform.State) error {
+						// the password didn't change, it isn't sent again.
+						for _, body := range mock.requestBodies(http.MethodPatch, usersPath) {
+							if password, ok := body.(map[string]interface{})["password"]; ok {
+								return fmt.Errorf("unexpected password update: %v", password)
+							}
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     projectID,
+				ImportStateVerify: true,
+				// Atlas doesn't return the passwords.
+				ImportStateVerifyIgnore: []string{"users"},
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 || states[0].Attributes["users.#"] != "2" {
+						return fmt.Errorf("expected the 2 users of the project to be imported")
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccConfigRSProjectDatabaseUsers_basic(t *testing.T) {
+	SkipTestForCI(t)
+	var (
+		resourceName = "mongodbatlas_project_database_users.test"
+		orgID        = os.Getenv("MONGODB_ATLAS_ORG_ID")
+		projectName  = acctest.RandomWithPrefix("test-acc")
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheckBasic(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckMongoDBAtlasProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongoDBAtlasProjectDatabaseUsersConfig(orgID, projectName, "read"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "project_id"),
+					resource.TestCheckResourceAttr(resourceName, "users.#", "2"),
+				),
+			},
+			{
+				Config: testAccMongoDBAtlasProjectDatabaseUsersConfig(orgID, projectName, "readWrite"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "users.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "users.*.roles.*", map[string]string{
+						"role_name":     "readWrite",
+						"database_name": "reports",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testMockMongoDBAtlasProjectDatabaseUsersConfig(projectID, roleName string) string {
+	return fmt.Sprintf(`
+		resource "mongodbatlas_project_database_users" "test" {
+			project_id = %[1]q
+			%[2]s
+		}
+	`, projectID, testProjectDatabaseUsersBlocks(roleName))
+}
+
+func testAccMongoDBAtlasProjectDatabaseUsersConfig(orgID, projectName, roleName string) string {
+	return fmt.Sprintf(`
+		resource "mongodbatlas_project" "test" {
+			name   = %[2]q
+			org_id = %[1]q
+		}
+
+		resource "mongodbatlas_project_database_users" "test" {
+			project_id = mongodbatlas_project.test.id
+			%[3]s
+		}
+	`, orgID, projectName, testProjectDatabaseUsersBlocks(roleName))
+}
+
+func testProjectDatabaseUsersBlocks(roleName string) string {
+	return fmt.Sprintf(`
+			users {
+				username = "app-user"
+				password = "test-mock-password"
+
+				roles {
+					role_name     = "readWriteAnyDatabase"
+					database_name = "admin"
+				}
+			}
+
+			users {
+				username = "report-user"
+				password = "test-mock-password"
+
+				roles {
+					role_name     = %[1]q
+					database_name = "reports"
+				}
+
+				scopes {
+					name = "reports-cluster"
+					type = "CLUSTER"
+				}
+			}
+	`, roleName)
+}
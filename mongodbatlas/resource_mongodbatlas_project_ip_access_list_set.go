@@ -0,0 +1,435 @@
+package mongodbatlas
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"go.mongodb.org/atlas-sdk/v20230201002/admin"
+)
+
+const (
+	errorAccessListSetCreate  = "error creating the IP access list of the project (%s): %s"
+	errorAccessListSetRead    = "error reading the IP access list of the project (%s): %s"
+	errorAccessListSetUpdate  = "error updating the IP access list of the project (%s): %s"
+	errorAccessListSetDelete  = "error deleting the IP access list of the project (%s): %s"
+	errorAccessListSetSetting = "error setting `%s` for the IP access list of the project (%s): %s"
+)
+
+// resourceMongoDBAtlasProjectIPAccessListSet manages the whole IP access list of a project: all the entries are added
+// or updated with a single request before the undeclared entries are removed, so that an entry is never missing while
+// the list changes.
+func resourceMongoDBAtlasProjectIPAccessListSet() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceMongoDBAtlasProjectIPAccessListSetCreate,
+		ReadContext:   resourceMongoDBAtlasProjectIPAccessListSetRead,
+		UpdateContext: resourceMongoDBAtlasProjectIPAccessListSetUpdate,
+		DeleteContext: resourceMongoDBAtlasProjectIPAccessListSetDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: resourceProjectIPAccessListSetCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"entries": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cidr_block": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.IsCIDRNetwork(0, 128),
+						},
+						"ip_address": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.IsIPAddress,
+						},
+						// You must configure VPC peering for your project before you can add an AWS security group to the access list.
+						"aws_security_group": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"comment": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"delete_after_date": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.IsRFC3339Time,
+						},
+					},
+				},
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(45 * time.Minute),
+			Update: schema.DefaultTimeout(45 * time.Minute),
+			Delete: schema.DefaultTimeout(45 * time.Minute),
+		},
+	}
+}
+
+func resourceMongoDBAtlasProjectIPAccessListSetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	connV2 := meta.(*MongoDBClient).AtlasV2
+	projectID := d.Get("project_id").(string)
+
+	// the entries that already exist in the project and aren't declared must be imported first rather than
+	// removed without being planned.
+	if err := checkProjectIPAccessListSetUndeclaredEntries(ctx, connV2, projectID, d.Get("entries").(*schema.Set)); err != nil {
+		return diag.FromErr(fmt.Errorf(errorAccessListSetCreate, projectID, err))
+	}
+
+	if err := applyProjectIPAccessListSet(ctx, connV2, projectID, d.Get("entries").(*schema.Set), d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(fmt.Errorf(errorAccessListSetCreate, projectID, err))
+	}
+
+	d.SetId(projectID)
+
+	return resourceMongoDBAtlasProjectIPAccessListSetRead(ctx, d, meta)
+}
+
+func resourceMongoDBAtlasProjectIPAccessListSetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	connV2 := meta.(*MongoDBClient).AtlasV2
+	projectID := d.Id()
+
+	entries, resp, err := listProjectIPAccessListEntries(ctx, connV2, projectID)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			d.SetId("")
+			return nil
+		}
+
+		return diag.FromErr(fmt.Errorf(errorAccessListSetRead, projectID, err))
+	}
+
+	if err := d.Set("project_id", projectID); err != nil {
+		return diag.FromErr(fmt.Errorf(errorAccessListSetSetting, "project_id", projectID, err))
+	}
+
+	var declared []interface{}
+	if v, ok := d.GetOk("entries"); ok {
+		declared = v.(*schema.Set).List()
+	}
+
+	if err := d.Set("entries", flattenProjectIPAccessListSetEntries(entries, declared)); err != nil {
+		return diag.FromErr(fmt.Errorf(errorAccessListSetSetting, "entries", projectID, err))
+	}
+
+	return nil
+}
+
+func resourceMongoDBAtlasProjectIPAccessListSetUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	connV2 := meta.(*MongoDBClient).AtlasV2
+	projectID := d.Id()
+
+	if d.HasChange("entries") {
+		if err := applyProjectIPAccessListSet(ctx, connV2, projectID, d.Get("entries").(*schema.Set), d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(fmt.Errorf(errorAccessListSetUpdate, projectID, err))
+		}
+	}
+
+	return resourceMongoDBAtlasProjectIPAccessListSetRead(ctx, d, meta)
+}
+
+func resourceMongoDBAtlasProjectIPAccessListSetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	connV2 := meta.(*MongoDBClient).AtlasV2
+	projectID := d.Id()
+
+	for _, e := range d.Get("entries").(*schema.Set).List() {
+		entry := expandProjectIPAccessListSetEntry(e.(map[string]interface{}))
+		if isIPAccessListEntryExpired(entry) {
+			continue
+		}
+
+		if err := deleteProjectIPAccessListEntry(ctx, connV2, projectID, projectIPAccessListEntryValue(entry), d.Timeout(schema.TimeoutDelete)); err != nil {
+			return diag.FromErr(fmt.Errorf(errorAccessListSetDelete, projectID, err))
+		}
+	}
+
+	return nil
+}
+
+// applyProjectIPAccessListSet makes the IP access list of the project match desired. The declared entries are added
+// or updated in place, e.g. their comment, with a single request and the undeclared entries are removed afterwards.
+// The temporary entries that expired are skipped, Atlas already removed them.
+func applyProjectIPAccessListSet(ctx context.Context, connV2 *admin.APIClient, projectID string, desired *schema.Set, timeout time.Duration) error {
+	entries := make([]admin.NetworkPermissionEntry, 0, desired.Len())
+	declared := make(map[string]bool, desired.Len())
+	for _, e := range desired.List() {
+		entry := expandProjectIPAccessListSetEntry(e.(map[string]interface{}))
+		declared[projectIPAccessListEntryKey(entry)] = true
+
+		if !isIPAccessListEntryExpired(entry) {
+			entries = append(entries, *entry)
+		}
+	}
+
+	if len(entries) > 0 {
+		log.Printf("[INFO] Adding %d entries to the IP access list of project %s", len(entries), projectID)
+
+		err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+			_, _, err := connV2.ProjectIPAccessListApi.CreateProjectIpAccessList(ctx, projectID, &entries).Execute()
+			if err != nil {
+				if isRetryableIPAccessListError(err) {
+					return retry.RetryableError(err)
+				}
+				return retry.NonRetryableError(err)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	current, _, err := listProjectIPAccessListEntries(ctx, connV2, projectID)
+	if err != nil {
+		return err
+	}
+
+	for i := range current {
+		if declared[projectIPAccessListEntryKey(&current[i])] {
+			continue
+		}
+
+		value := projectIPAccessListEntryValue(&current[i])
+		log.Printf("[INFO] Removing %s from the IP access list of project %s, it isn't declared", value, projectID)
+
+		if err := deleteProjectIPAccessListEntry(ctx, connV2, projectID, value, timeout); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkProjectIPAccessListSetUndeclaredEntries returns an error if the IP access list of the project has entries
+// that aren't in desired.
+func checkProjectIPAccessListSetUndeclaredEntries(ctx context.Context, connV2 *admin.APIClient, projectID string, desired *schema.Set) error {
+	current, _, err := listProjectIPAccessListEntries(ctx, connV2, projectID)
+	if err != nil {
+		return err
+	}
+
+	declared := make(map[string]bool, desired.Len())
+	for _, e := range desired.List() {
+		declared[projectIPAccessListEntryKey(expandProjectIPAccessListSetEntry(e.(map[string]interface{})))] = true
+	}
+
+	var undeclared []string
+	for i := range current {
+		if key := projectIPAccessListEntryKey(&current[i]); !declared[key] {
+			undeclared = append(undeclared, key)
+		}
+	}
+
+	if len(undeclared) > 0 {
+		return fmt.Errorf("the IP access list already has entries that aren't declared (%s), "+
+			"import them with `terraform import` before applying the configuration", strings.Join(undeclared, ", "))
+	}
+
+	return nil
+}
+
+func deleteProjectIPAccessListEntry(ctx context.Context, connV2 *admin.APIClient, projectID, value string, timeout time.Duration) error {
+	return retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		_, resp, err := connV2.ProjectIPAccessListApi.DeleteProjectIpAccessList(ctx, projectID, value).Execute()
+		if err != nil {
+			switch {
+			case resp != nil && resp.StatusCode == http.StatusNotFound:
+				return nil
+			case isRetryableIPAccessListError(err):
+				return retry.RetryableError(err)
+			default:
+				return retry.NonRetryableError(fmt.Errorf("error removing %s: %s", value, err))
+			}
+		}
+
+		return nil
+	})
+}
+
+func isRetryableIPAccessListError(err error) bool {
+	return strings.Contains(err.Error(), "500") ||
+		strings.Contains(err.Error(), "Unexpected error") ||
+		strings.Contains(err.Error(), "UNEXPECTED_ERROR")
+}
+
+func listProjectIPAccessListEntries(ctx context.Context, connV2 *admin.APIClient, projectID string) ([]admin.NetworkPermissionEntry, *http.Response, error) {
+	var entries []admin.NetworkPermissionEntry
+
+	for pageNum := 1; ; pageNum++ {
+		page, resp, err := connV2.ProjectIPAccessListApi.ListProjectIpAccessLists(ctx, projectID).PageNum(pageNum).ItemsPerPage(listItemsPerPage).Execute()
+		if err != nil {
+			return nil, resp, err
+		}
+
+		entries = append(entries, page.Results...)
+		if len(page.Results) < listItemsPerPage {
+			break
+		}
+	}
+
+	return entries, nil, nil
+}
+
+// projectIPAccessListEntryKey identifies an entry of the IP access list: Atlas stores an IP address as a CIDR block
+// of a single address, e.g. 10.0.0.1 as 10.0.0.1/32.
+func projectIPAccessListEntryKey(entry *admin.NetworkPermissionEntry) string {
+	switch {
+	case entry.GetCidrBlock() != "":
+		return entry.GetCidrBlock()
+	case entry.GetIpAddress() != "":
+		if strings.Contains(entry.GetIpAddress(), ":") {
+			return entry.GetIpAddress() + "/128"
+		}
+		return entry.GetIpAddress() + "/32"
+	default:
+		return entry.GetAwsSecurityGroup()
+	}
+}
+
+// projectIPAccessListEntryValue returns the value identifying the entry in the path of the requests.
+func projectIPAccessListEntryValue(entry *admin.NetworkPermissionEntry) string {
+	switch {
+	case entry.GetIpAddress() != "":
+		return entry.GetIpAddress()
+	case entry.GetCidrBlock() != "":
+		return entry.GetCidrBlock()
+	default:
+		return entry.GetAwsSecurityGroup()
+	}
+}
+
+// isIPAccessListEntryExpired reports whether a temporary entry was removed by Atlas on its delete_after_date.
+func isIPAccessListEntryExpired(entry *admin.NetworkPermissionEntry) bool {
+	return entry.DeleteAfterDate != nil && !entry.DeleteAfterDate.After(time.Now())
+}
+
+func expandProjectIPAccessListSetEntry(tfMap map[string]interface{}) *admin.NetworkPermissionEntry {
+	return &admin.NetworkPermissionEntry{
+		CidrBlock:        stringPtrOrNil(tfMap["cidr_block"].(string)),
+		IpAddress:        stringPtrOrNil(tfMap["ip_address"].(string)),
+		AwsSecurityGroup: stringPtrOrNil(tfMap["aws_security_group"].(string)),
+		Comment:          stringPtrOrNil(tfMap["comment"].(string)),
+		DeleteAfterDate:  parseDeleteAfterDate(tfMap["delete_after_date"].(string)),
+	}
+}
+
+// flattenProjectIPAccessListSetEntries returns the entries of the access list in the form they're declared in, an IP
+// address being returned by Atlas both as an IP address and a CIDR block and a date in UTC. The declared temporary entries that expired
+// are kept as they're declared so that they're not planned again.
+func flattenProjectIPAccessListSetEntries(entries []admin.NetworkPermissionEntry, declared []interface{}) []map[string]interface{} {
+	declaredEntries := make(map[string]map[string]interface{}, len(declared))
+	for _, e := range declared {
+		tfMap := e.(map[string]interface{})
+		declaredEntries[projectIPAccessListEntryKey(expandProjectIPAccessListSetEntry(tfMap))] = tfMap
+	}
+
+	results := make([]map[string]interface{}, 0, len(entries))
+	listed := make(map[string]bool, len(entries))
+	for i := range entries {
+		key := projectIPAccessListEntryKey(&entries[i])
+		listed[key] = true
+
+		result := map[string]interface{}{
+			"cidr_block":         entries[i].GetCidrBlock(),
+			"ip_address":         "",
+			"aws_security_group": entries[i].GetAwsSecurityGroup(),
+			"comment":            entries[i].GetComment(),
+			"delete_after_date":  timeToString(entries[i].DeleteAfterDate),
+		}
+
+		tfMap, ok := declaredEntries[key]
+		if ok && tfMap["ip_address"].(string) != "" {
+			result["cidr_block"] = ""
+			result["ip_address"] = entries[i].GetIpAddress()
+		} else if result["cidr_block"] == "" {
+			result["ip_address"] = entries[i].GetIpAddress()
+		}
+
+		// the entries are hashed with their delete_after_date, which keeps the time zone it's declared in.
+		if ok && suppressEquivalentRFC3339Time("delete_after_date", tfMap["delete_after_date"].(string), result["delete_after_date"].(string), nil) {
+			result["delete_after_date"] = tfMap["delete_after_date"]
+		}
+
+		results = append(results, result)
+	}
+
+	for key, tfMap := range declaredEntries {
+		if !listed[key] && isIPAccessListEntryExpired(expandProjectIPAccessListSetEntry(tfMap)) {
+			results = append(results, tfMap)
+		}
+	}
+
+	return results
+}
+
+// resourceProjectIPAccessListSetCustomizeDiff validates the entries at plan time: each entry has exactly one of
+// cidr_block, ip_address or aws_security_group, appears once and has a delete_after_date in the future when it's added.
+func resourceProjectIPAccessListSetCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange("entries") || !d.NewValueKnown("entries") {
+		return nil
+	}
+
+	oldEntries, newEntries := d.GetChange("entries")
+
+	previous := make(map[string]bool)
+	for _, e := range oldEntries.(*schema.Set).List() {
+		tfMap := e.(map[string]interface{})
+		entry := expandProjectIPAccessListSetEntry(tfMap)
+		previous[projectIPAccessListEntryKey(entry)+"@"+tfMap["delete_after_date"].(string)] = true
+	}
+
+	keys := make(map[string]bool)
+	for _, e := range newEntries.(*schema.Set).List() {
+		tfMap := e.(map[string]interface{})
+		entry := expandProjectIPAccessListSetEntry(tfMap)
+		key := projectIPAccessListEntryKey(entry)
+
+		set := 0
+		for _, attribute := range []string{"cidr_block", "ip_address", "aws_security_group"} {
+			if tfMap[attribute].(string) != "" {
+				set++
+			}
+		}
+		if set != 1 {
+			return fmt.Errorf("each entry must have exactly one of cidr_block, ip_address or aws_security_group, got %d in %v", set, tfMap)
+		}
+
+		if keys[key] {
+			return fmt.Errorf("the IP access list has %s more than once", key)
+		}
+		keys[key] = true
+
+		deleteAfterDate := tfMap["delete_after_date"].(string)
+		if deleteAfterDate == "" {
+			continue
+		}
+
+		if entry.GetAwsSecurityGroup() != "" {
+			return fmt.Errorf("the entry of the AWS security group %s can't be temporary, remove its delete_after_date", key)
+		}
+
+		// a temporary entry that expired since it was added stays in the state and isn't added again.
+		if !previous[key+"@"+deleteAfterDate] && isIPAccessListEntryExpired(entry) {
+			return fmt.Errorf("`delete_after_date` of %s must be in the future, got %s", key, deleteAfterDate)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,308 @@
+package mongodbatlas
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"go.mongodb.org/atlas-sdk/v20230201002/admin"
+)
+
+func TestFlattenProjectIPAccessListSetEntries(t *testing.T) {
+	expired := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	deleteAfterDate := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	entries := []admin.NetworkPermissionEntry{
+		{CidrBlock: admin.PtrString("10.0.0.0/16"), Comment: admin.PtrString("office")},
+		{CidrBlock: admin.PtrString("203.0.113.10/32"), IpAddress: admin.PtrString("203.0.113.10")},
+		{CidrBlock: admin.PtrString("198.51.100.7/32"), IpAddress: admin.PtrString("198.51.100.7")},
+		{AwsSecurityGroup: admin.PtrString("sg-0123456789")},
+		{CidrBlock: admin.PtrString("192.0.2.128/25"), DeleteAfterDate: &deleteAfterDate},
+	}
+
+	declared := []interface{}{
+		testIPAccessListSetEntry("", "203.0.113.10", "", ""),
+		testIPAccessListSetEntry("192.0.2.128/25", "", "", "2030-01-01T02:00:00+02:00"),
+		testIPAccessListSetEntry("", "192.0.2.1", "", expired),
+	}
+
+	expected := []map[string]interface{}{
+		testIPAccessListSetEntry("10.0.0.0/16", "", "office", ""),
+		// declared as an IP address.
+		testIPAccessListSetEntry("", "203.0.113.10", "", ""),
+		// undeclared, Atlas returns it as an IP address and a CIDR block.
+		testIPAccessListSetEntry("198.51.100.7/32", "", "", ""),
+		{"cidr_block": "", "ip_address": "", "aws_security_group": "sg-0123456789", "comment": "", "delete_after_date": ""},
+		// declared in another time zone.
+		testIPAccessListSetEntry("192.0.2.128/25", "", "", "2030-01-01T02:00:00+02:00"),
+		// expired and removed by Atlas.
+		testIPAccessListSetEntry("", "192.0.2.1", "", expired),
+	}
+
+	if diff := deep.Equal(flattenProjectIPAccessListSetEntries(entries, declared), expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestProjectIPAccessListEntryKey(t *testing.T) {
+	testCases := map[string]*admin.NetworkPermissionEntry{
+		"10.0.0.0/16":         {CidrBlock: admin.PtrString("10.0.0.0/16")},
+		"203.0.113.10/32":     {IpAddress: admin.PtrString("203.0.113.10")},
+		"2001:db8::1/128":     {IpAddress: admin.PtrString("2001:db8::1")},
+		"sg-0123456789":       {AwsSecurityGroup: admin.PtrString("sg-0123456789")},
+		"198.51.100.7/32":     {IpAddress: admin.PtrString("198.51.100.7"), CidrBlock: admin.PtrString("198.51.100.7/32")},
+		"2001:db8:1::/48":     {CidrBlock: admin.PtrString("2001:db8:1::/48")},
+		"203.0.113.128/25":    {CidrBlock: admin.PtrString("203.0.113.128/25")},
+		"sg-0123456789abcdef": {AwsSecurityGroup: admin.PtrString("sg-0123456789abcdef")},
+	}
+
+	for want, entry := range testCases {
+		if got := projectIPAccessListEntryKey(entry); got != want {
+			t.Errorf("projectIPAccessListEntryKey(%v) = %s, want %s", entry, got, want)
+		}
+	}
+}
+
+func TestMockConfigRSProjectIPAccessListSet_basic(t *testing.T) {
+	var (
+		mock           = newAtlasMockServer(t)
+		projectID      = mock.newProject("test-mock")
+		resourceName   = "mongodbatlas_project_ip_access_list_set.test"
+		accessListPath = fmt.Sprintf("%s/groups/%s/accessList", mockAtlasV2Path, projectID)
+		tomorrow       = time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second).Format(time.RFC3339)
+		yesterday      = time.Now().Add(-24 * time.Hour).UTC().Truncate(time.Second).Format(time.RFC3339)
+	)
+
+	// an entry added in the Atlas UI before the resource.
+	mock.seed(accessListPath, map[string]interface{}{"groupId": projectID, "cidrBlock": "192.168.0.0/24", "comment": "manual"})
+
+	testRequests := func(posts, deletes int) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			if got := mock.requestCount(http.MethodPost, accessListPath); got != posts {
+				return fmt.Errorf("expected %d requests adding entries, got %d", posts, got)
+			}
+			if got := mock.requestCount(http.MethodDelete, accessListPath); got != deletes {
+				return fmt.Errorf("expected %d requests removing entries, got %d", deletes, got)
+			}
+			return nil
+		}
+	}
+
+	testMockResourceUnitTest(t, mock, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config:      testMockMongoDBAtlasProjectIPAccessListSetConfig(projectID, tomorrow, "office", testIPAccessListSetBlock("", "203.0.113.10", "vpn", "")),
+				ExpectError: regexp.MustCompile(`entries that aren't declared \(192.168.0.0/24\), import them`),
+			},
+			{
+				// the creation failed without any change, the entry is imported and its removal planned.
+				Config:             testMockMongoDBAtlasProjectIPAccessListSetConfig(projectID, tomorrow, "office", testIPAccessListSetBlock("", "203.0.113.10", "vpn", "")),
+				ResourceName:       resourceName,
+				ImportState:        true,
+				ImportStateId:      projectID,
+				ImportStatePersist: true,
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 || states[0].Attributes["entries.#"] != "1" {
+						return fmt.Errorf("expected only the undeclared entry of the access list to be imported")
+					}
+					return nil
+				},
+			},
+			{
+				Config: testMockMongoDBAtlasProjectIPAccessListSetConfig(projectID, tomorrow, "office", testIPAccessListSetBlock("", "203.0.113.10", "vpn", "")),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "project_id", projectID),
+					resource.TestCheckResourceAttr(resourceName, "entries.#", "3"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "entries.*", map[string]string{
+						"ip_address": "203.0.113.10",
+						"cidr_block": "",
+						"comment":    "vpn",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "entries.*", map[string]string{
+						"ip_address":        "198.51.100.7",
+						"delete_after_date": tomorrow,
+					}),
+					// all the entries are added with one request, the undeclared entry is removed.
+					testRequests(1, 1),
+				),
+			},
+			{
+				// changing a comment doesn't remove the entry.
+				Config: testMockMongoDBAtlasProjectIPAccessListSetConfig(projectID, tomorrow, "headquarters", testIPAccessListSetBlock("", "203.0.113.10", "vpn", "")),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "entries.*", map[string]string{
+						"cidr_block": "10.0.0.0/16",
+						"comment":    "headquarters",
+					}),
+					testRequests(2, 1),
+				),
+			},
+			{
+				Config: testMockMongoDBAtlasProjectIPAccessListSetConfig(projectID, tomorrow, "headquarters", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "entries.#", "2"),
+					testRequests(3, 2),
+					func(*terraform.State) error {
+						if mock.get(accessListPath+"/203.0.113.10") != nil {
+							return fmt.Errorf("the entry removed from the configuration is still in the access list")
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config:      testMockMongoDBAtlasProjectIPAccessListSetConfig(projectID, tomorrow, "headquarters", testIPAccessListSetBlock("", "192.0.2.1", "", yesterday)),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`delete_after_date` of 192.0.2.1/32 must be in the future"),
+			},
+			{
+				Config:      testMockMongoDBAtlasProjectIPAccessListSetConfig(projectID, tomorrow, "headquarters", testIPAccessListSetBlock("192.0.2.0/24", "192.0.2.1", "", "")),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("each entry must have exactly one of cidr_block, ip_address or aws_security_group"),
+			},
+			{
+				Config:      testMockMongoDBAtlasProjectIPAccessListSetConfig(projectID, tomorrow, "headquarters", testIPAccessListSetBlock("10.0.0.0/16", "", "duplicate", "")),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("the IP access list has 10.0.0.0/16 more than once"),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     projectID,
+				ImportStateVerify: true,
+				// the imported IP addresses are read as CIDR blocks.
+				ImportStateVerifyIgnore: []string{"entries"},
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 || states[0].Attributes["entries.#"] != "2" {
+						return fmt.Errorf("expected the 2 entries of the access list to be imported")
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccProjectRSProjectIPAccessListSet_basic(t *testing.T) {
+	SkipTestForCI(t)
+	var (
+		resourceName = "mongodbatlas_project_ip_access_list_set.test"
+		orgID        = os.Getenv("MONGODB_ATLAS_ORG_ID")
+		projectName  = acctest.RandomWithPrefix("test-acc")
+		tomorrow     = time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second).Format(time.RFC3339)
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheckBasic(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckMongoDBAtlasProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongoDBAtlasProjectIPAccessListSetConfig(orgID, projectName, "office", tomorrow),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "project_id"),
+					resource.TestCheckResourceAttr(resourceName, "entries.#", "2"),
+				),
+			},
+			{
+				Config: testAccMongoDBAtlasProjectIPAccessListSetConfig(orgID, projectName, "headquarters", tomorrow),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "entries.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "entries.*", map[string]string{
+						"cidr_block": "10.0.0.0/16",
+						"comment":    "headquarters",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testIPAccessListSetEntry(cidrBlock, ipAddress, comment, deleteAfterDate string) map[string]interface{} {
+	return map[string]interface{}{
+		"cidr_block":         cidrBlock,
+		"ip_address":         ipAddress,
+		"aws_security_group": "",
+		"comment":            comment,
+		"delete_after_date":  deleteAfterDate,
+	}
+}
+
+func testIPAccessListSetBlock(cidrBlock, ipAddress, comment, deleteAfterDate string) string {
+	block := "entries {\n"
+	for _, attribute := range [][2]string{
+		{"cidr_block", cidrBlock},
+		{"ip_address", ipAddress},
+		{"comment", comment},
+		{"delete_after_date", deleteAfterDate},
+	} {
+		if attribute[1] != "" {
+			block += fmt.Sprintf("\t%s = %q\n", attribute[0], attribute[1])
+		}
+	}
+
+	return block + "}\n"
+}
+
+func testMockMongoDBAtlasProjectIPAccessListSetConfig(projectID, deleteAfterDate, comment, extraEntry string) string {
+	return fmt.Sprintf(`
+		resource "mongodbatlas_project_ip_access_list_set" "test" {
+			project_id = %[1]q
+
+			entries {
+				cidr_block = "10.0.0.0/16"
+				comment    = %[2]q
+			}
+
+			entries {
+				ip_address        = "198.51.100.7"
+				comment           = "support"
+				delete_after_date = %[4]q
+			}
+
+			%[3]s
+		}
+	`, projectID, comment, extraEntry, deleteAfterDate)
+}
+
+func testAccMongoDBAtlasProjectIPAccessListSetConfig(orgID, projectName, comment, deleteAfterDate string) string {
+	return fmt.Sprintf(`
+		resource "mongodbatlas_project" "test" {
+			name   = %[2]q
+			org_id = %[1]q
+		}
+
+		resource "mongodbatlas_project_ip_access_list_set" "test" {
+			project_id = mongodbatlas_project.test.id
+
+			entries {
+				cidr_block = "10.0.0.0/16"
+				comment    = %[3]q
+			}
+
+			entries {
+				ip_address        = "198.51.100.7"
+				comment           = "support"
+				delete_after_date = %[4]q
+			}
+		}
+	`, orgID, projectName, comment, deleteAfterDate)
+}
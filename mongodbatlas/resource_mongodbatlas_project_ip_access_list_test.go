@@ -6,6 +6,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
@@ -369,3 +370,88 @@ func testAccMongoDBAtlasProjectIPAccessListConfigSettingMultiple(projectName, or
 	}
 	return config
 }
+
+func TestMockProjectRSProjectIPAccessList_settingIPAddress(t *testing.T) {
+	var (
+		mock         = newAtlasMockServer(t)
+		resourceName = "mongodbatlas_project_ip_access_list.test"
+		orgID        = newMockObjectID()
+		ipAddress    = "179.154.226.10"
+		comment      = "TestMock for ipAddress"
+	)
+
+	testMockResourceUnitTest(t, mock, resource.TestCase{
+		CheckDestroy: func(s *terraform.State) error {
+			for _, rs := range s.RootModule().Resources {
+				if rs.Type != "mongodbatlas_project_ip_access_list" {
+					continue
+				}
+				ids := decodeStateID(rs.Primary.ID)
+				if mock.get(fmt.Sprintf("%s/groups/%s/accessList/%s", mockAtlasV2Path, ids["project_id"], ids["entry"])) != nil {
+					return fmt.Errorf("access list entry (%s) still exists", ids["entry"])
+				}
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongoDBAtlasProjectIPAccessListConfigSettingIPAddress(orgID, "test-mock-project", ipAddress, comment),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "ip_address", ipAddress),
+					resource.TestCheckResourceAttr(resourceName, "cidr_block", ipAddress+"/32"),
+					resource.TestCheckResourceAttr(resourceName, "comment", comment),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportStateIdFunc: testAccCheckMongoDBAtlasProjectIPAccessListImportStateIDFunc(resourceName),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestMockProjectRSProjectIPAccessList_crud(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		mock      = newAtlasMockServer(t)
+		client    = mock.client()
+		projectID = mock.newProject("test-mock")
+		r         = resourceMongoDBAtlasProjectIPAccessList()
+	)
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"project_id": projectID,
+		"cidr_block": "10.1.0.0/16",
+		"comment":    "test mock",
+	})
+	if diags := r.CreateContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("Bad create access list entry: %v", diags)
+	}
+
+	if got := decodeStateID(d.Id())["entry"]; got != "10.1.0.0/16" {
+		t.Errorf("Bad access list entry ID: got %s", got)
+	}
+
+	if d.Get("comment").(string) != "test mock" {
+		t.Errorf("Bad comment: got %s", d.Get("comment"))
+	}
+
+	imported := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	imported.SetId(fmt.Sprintf("%s-10.1.0.0/16", projectID))
+	if _, err := r.Importer.StateContext(ctx, imported, client); err != nil {
+		t.Fatalf("Bad import access list entry: %s", err)
+	}
+	if imported.Id() != d.Id() {
+		t.Errorf("Bad imported ID: got %s, want %s", imported.Id(), d.Id())
+	}
+
+	if diags := r.DeleteContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("Bad delete access list entry: %v", diags)
+	}
+
+	if mock.get(fmt.Sprintf("%s/groups/%s/accessList/10.1.0.0/16", mockAtlasV1Path, projectID)) != nil {
+		t.Error("access list entry must be deleted in the mock server")
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
@@ -605,3 +606,80 @@ func testAccMongoDBAtlasProjectConfigWithLimits(projectName, orgID string, limit
 		}
 	`, projectName, orgID, limitsString)
 }
+
+func TestMockProjectRSProject_basic(t *testing.T) {
+	var (
+		mock         = newAtlasMockServer(t)
+		resourceName = "mongodbatlas_project.test"
+		projectName  = "test-mock-project"
+		orgID        = newMockObjectID()
+	)
+
+	testMockResourceUnitTest(t, mock, resource.TestCase{
+		CheckDestroy: func(s *terraform.State) error {
+			for _, rs := range s.RootModule().Resources {
+				if mock.get(fmt.Sprintf("%s/groups/%s", mockAtlasV1Path, rs.Primary.ID)) != nil {
+					return fmt.Errorf("project (%s) still exists", rs.Primary.ID)
+				}
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMongoDBAtlasProjectConfig(projectName, orgID, nil, nil),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", projectName),
+					resource.TestCheckResourceAttr(resourceName, "org_id", orgID),
+					resource.TestCheckResourceAttr(resourceName, "cluster_count", "0"),
+				),
+			},
+			{
+				Config: testAccMongoDBAtlasProjectConfig(projectName+"-updated", orgID, nil, nil),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", projectName+"-updated"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"with_default_alerts_settings"},
+			},
+		},
+	})
+}
+
+func TestMockProjectRSProject_crud(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		mock   = newAtlasMockServer(t)
+		client = mock.client()
+		orgID  = newMockObjectID()
+		r      = resourceMongoDBAtlasProject()
+	)
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":                     "test-mock-project",
+		"org_id":                   orgID,
+		"is_data_explorer_enabled": false,
+	})
+	if diags := r.CreateContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("Bad create project: %v", diags)
+	}
+
+	if d.Get("name").(string) != "test-mock-project" || d.Get("org_id").(string) != orgID {
+		t.Errorf("Bad project attributes: name=%s org_id=%s", d.Get("name"), d.Get("org_id"))
+	}
+
+	if d.Get("is_data_explorer_enabled").(bool) {
+		t.Error("is_data_explorer_enabled must be read back from the project settings")
+	}
+
+	if diags := r.DeleteContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("Bad delete project: %v", diags)
+	}
+
+	if mock.get(fmt.Sprintf("%s/groups/%s", mockAtlasV1Path, d.Id())) != nil {
+		t.Error("project must be deleted in the mock server")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
@@ -431,3 +432,52 @@ func testAccCheckMongoDBAtlasSearchIndexImportStateIDFunc(resourceName string) r
 		return fmt.Sprintf("%s--%s--%s", ids["project_id"], ids["cluster_name"], ids["index_id"]), nil
 	}
 }
+
+func TestMockClusterRSSearchIndex_basic(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		mock      = newAtlasMockServer(t)
+		client    = mock.client()
+		projectID = mock.newProject("test-mock")
+		r         = resourceMongoDBAtlasSearchIndex()
+	)
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"project_id":       projectID,
+		"cluster_name":     "test-mock-cluster",
+		"name":             "test-mock-index",
+		"database":         "sample_mflix",
+		"collection_name":  "movies",
+		"analyzer":         "lucene.standard",
+		"search_analyzer":  "lucene.standard",
+		"mappings_dynamic": true,
+	})
+	if diags := r.CreateWithoutTimeout(ctx, d, client); diags.HasError() {
+		t.Fatalf("Bad create search index: %v", diags)
+	}
+
+	ids := decodeStateID(d.Id())
+	if ids["index_id"] == "" || ids["cluster_name"] != "test-mock-cluster" {
+		t.Fatalf("Bad search index ID: %#v", ids)
+	}
+
+	if d.Get("collection_name").(string) != "movies" || !d.Get("mappings_dynamic").(bool) {
+		t.Errorf("Bad search index attributes: collection_name=%s mappings_dynamic=%t", d.Get("collection_name"), d.Get("mappings_dynamic"))
+	}
+
+	imported := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	imported.SetId(fmt.Sprintf("%s--test-mock-cluster--%s", projectID, ids["index_id"]))
+	if _, err := r.Importer.StateContext(ctx, imported, client); err != nil {
+		t.Fatalf("Bad import search index: %s", err)
+	}
+	if diags := r.ReadContext(ctx, imported, client); diags.HasError() {
+		t.Fatalf("Bad read imported search index: %v", diags)
+	}
+	if imported.Get("name").(string) != "test-mock-index" {
+		t.Errorf("Bad imported name: got %s", imported.Get("name"))
+	}
+
+	if diags := r.DeleteContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("Bad delete search index: %v", diags)
+	}
+}
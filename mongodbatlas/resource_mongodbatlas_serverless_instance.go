@@ -30,6 +30,11 @@ func resourceMongoDBAtlasServerlessInstance() *schema.Resource {
 			StateContext: resourceMongoDBAtlasServerlessInstanceImportState,
 		},
 		Schema: returnServerlessInstanceSchema(),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(3 * time.Hour),
+			Update: schema.DefaultTimeout(3 * time.Hour),
+			Delete: schema.DefaultTimeout(3 * time.Hour),
+		},
 	}
 }
 
@@ -59,7 +64,7 @@ func resourceMongoDBAtlasServerlessInstanceUpdate(ctx context.Context, d *schema
 			Pending:    []string{"CREATING", "UPDATING", "REPAIRING", "REPEATING", "PENDING"},
 			Target:     []string{"IDLE"},
 			Refresh:    resourceServerlessInstanceRefreshFunc(ctx, d.Get("name").(string), projectID, conn),
-			Timeout:    3 * time.Hour,
+			Timeout:    d.Timeout(schema.TimeoutUpdate),
 			MinTimeout: 1 * time.Minute,
 			Delay:      3 * time.Minute,
 		}
@@ -205,7 +210,7 @@ func resourceMongoDBAtlasServerlessInstanceDelete(ctx context.Context, d *schema
 		Pending:    []string{"IDLE", "CREATING", "UPDATING", "REPAIRING", "DELETING"},
 		Target:     []string{"DELETED"},
 		Refresh:    resourceServerlessInstanceRefreshFunc(ctx, serverlessName, projectID, conn),
-		Timeout:    3 * time.Hour,
+		Timeout:    d.Timeout(schema.TimeoutDelete),
 		MinTimeout: 30 * time.Second,
 		Delay:      1 * time.Minute, // Wait 30 secs before starting
 	}
@@ -324,7 +329,7 @@ func resourceMongoDBAtlasServerlessInstanceCreate(ctx context.Context, d *schema
 		Pending:    []string{"CREATING", "UPDATING", "REPAIRING", "REPEATING", "PENDING"},
 		Target:     []string{"IDLE"},
 		Refresh:    resourceServerlessInstanceRefreshFunc(ctx, d.Get("name").(string), projectID, conn),
-		Timeout:    3 * time.Hour,
+		Timeout:    d.Timeout(schema.TimeoutCreate),
 		MinTimeout: 1 * time.Minute,
 		Delay:      3 * time.Minute,
 	}
@@ -0,0 +1,266 @@
+package mongodbatlas
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	defaultMaxRetries   = 4
+	defaultRetryWaitMin = 1 * time.Second
+	defaultRetryWaitMax = 30 * time.Second
+)
+
+// retryTransport retries requests that Atlas rejected because of rate limiting (429)
+// or a transient server side failure (502, 503, 504), waiting between attempts
+// either the time requested through the Retry-After header or an exponential backoff
+// bounded by waitMin and waitMax. Non-idempotent requests are only retried on 429 and 503,
+// which guarantee that Atlas didn't process them.
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+	waitMin    time.Duration
+	waitMax    time.Duration
+}
+
+// newRetryTransport wraps next with a retryTransport, next is returned as is when retries are disabled.
+func newRetryTransport(next http.RoundTripper, maxRetries int, waitMin, waitMax time.Duration) http.RoundTripper {
+	if maxRetries <= 0 {
+		return next
+	}
+
+	if waitMin <= 0 {
+		waitMin = defaultRetryWaitMin
+	}
+
+	if waitMax < waitMin {
+		waitMax = waitMin
+	}
+
+	return &retryTransport{
+		next:       next,
+		maxRetries: maxRetries,
+		waitMin:    waitMin,
+		waitMax:    waitMax,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// The body must be sent again on every attempt, so keep a copy when the request can't rebuild it.
+	getBody := req.GetBody
+	if req.Body != nil && req.Body != http.NoBody && getBody == nil {
+		body, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		getBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if req.Body != nil && req.Body != http.NoBody && getBody != nil {
+			body, err := getBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq = req.Clone(req.Context())
+			attemptReq.Body = body
+			attemptReq.GetBody = getBody
+		}
+
+		resp, err := t.next.RoundTrip(attemptReq)
+		if attempt >= t.maxRetries || !shouldRetryRequest(req, resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+		if resp != nil {
+			log.Printf("[DEBUG] %s %s returned %d, retrying in %s (attempt %d of %d)", req.Method, req.URL.Path, resp.StatusCode, wait, attempt+1, t.maxRetries)
+			// Drain the body so the connection can be reused.
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+			_ = resp.Body.Close()
+		} else {
+			log.Printf("[DEBUG] %s %s failed: %s, retrying in %s (attempt %d of %d)", req.Method, req.URL.Path, err, wait, attempt+1, t.maxRetries)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff returns how long to wait before the next attempt, preferring the value of the Retry-After header.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return wait
+		}
+	}
+
+	wait := float64(t.waitMin) * math.Pow(2, float64(attempt))
+	if wait > float64(t.waitMax) {
+		return t.waitMax
+	}
+
+	return time.Duration(wait)
+}
+
+func shouldRetryRequest(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	if err != nil {
+		// A request that failed before getting a response may still have been applied,
+		// only retry it when sending it again can't create a duplicate.
+		return isIdempotentMethod(req.Method)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		// The gateway may have forwarded the request to Atlas before failing.
+		return isIdempotentMethod(req.Method)
+	}
+
+	return false
+}
+
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+// parseRetryAfter supports both formats of the Retry-After header, delay in seconds and HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+// rateLimitTransport throttles the requests sent to Atlas with a token bucket refilled at
+// requestsPerMinute and caps how many of them can be in flight at the same time, so that
+// large plans get slower instead of failing because of the Atlas API rate limits.
+// The same instance must be shared by every client of a provider configuration for the
+// limits to apply to all of them.
+type rateLimitTransport struct {
+	next    http.RoundTripper
+	limiter *rate.Limiter
+	slots   chan struct{}
+}
+
+// newRateLimitTransport returns nil when neither a rate nor a concurrency limit is set.
+func newRateLimitTransport(requestsPerMinute, maxConcurrentRequests int) *rateLimitTransport {
+	if requestsPerMinute <= 0 && maxConcurrentRequests <= 0 {
+		return nil
+	}
+
+	t := &rateLimitTransport{}
+	if requestsPerMinute > 0 {
+		// allow a burst of at most one second worth of requests.
+		burst := int(math.Ceil(float64(requestsPerMinute) / 60))
+		t.limiter = rate.NewLimiter(rate.Limit(float64(requestsPerMinute)/60), burst)
+	}
+
+	if maxConcurrentRequests > 0 {
+		t.slots = make(chan struct{}, maxConcurrentRequests)
+	}
+
+	return t
+}
+
+// wrap returns a RoundTripper sending the requests through next once the limits allow it.
+func (t *rateLimitTransport) wrap(next http.RoundTripper) http.RoundTripper {
+	if t == nil {
+		return next
+	}
+
+	return &rateLimitTransport{
+		next:    next,
+		limiter: t.limiter,
+		slots:   t.slots,
+	}
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	release := func() {}
+	if t.slots != nil {
+		select {
+		case t.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		var once sync.Once
+		release = func() {
+			once.Do(func() { <-t.slots })
+		}
+	}
+
+	if t.limiter != nil {
+		if err := t.limiter.Wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.Body == nil {
+		release()
+		return resp, err
+	}
+
+	// The request is in flight until its response has been read.
+	resp.Body = &releaseOnCloseBody{ReadCloser: resp.Body, release: release}
+
+	return resp, nil
+}
+
+type releaseOnCloseBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releaseOnCloseBody) Close() error {
+	defer b.release()
+	return b.ReadCloser.Close()
+}
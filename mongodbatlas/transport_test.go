@@ -0,0 +1,339 @@
+package mongodbatlas
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+)
+
+func TestRetryTransport_retriesRateLimitedRequests(t *testing.T) {
+	var attempts int32
+	var bodies []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+
+		if atomic.AddInt32(&attempts, 1) <= 2 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newRetryTransport(http.DefaultTransport, 3, time.Millisecond, 10*time.Millisecond)}
+
+	req, err := http.NewRequest(http.MethodPost, server.URL, io.NopCloser(strings.NewReader(`{"name":"test"}`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d", http.StatusCreated, resp.StatusCode)
+	}
+
+	expected := []string{`{"name":"test"}`, `{"name":"test"}`, `{"name":"test"}`}
+	if diff := deep.Equal(expected, bodies); diff != nil {
+		t.Fatalf("Bad request bodies \n got = %#v\nwant = %#v \ndiff = %#v", bodies, expected, diff)
+	}
+}
+
+func TestRetryTransport_givesUpAfterMaxRetries(t *testing.T) {
+	testCases := map[string]struct {
+		method           string
+		status           int
+		expectedAttempts int32
+	}{
+		"service unavailable is retried":                {http.MethodGet, http.StatusServiceUnavailable, 3},
+		"gateway timeout is retried":                    {http.MethodGet, http.StatusGatewayTimeout, 3},
+		"internal error is not retried":                 {http.MethodGet, http.StatusInternalServerError, 1},
+		"bad request is not retried":                    {http.MethodGet, http.StatusBadRequest, 1},
+		"not found is not retried":                      {http.MethodGet, http.StatusNotFound, 1},
+		"service unavailable is retried for post":       {http.MethodPost, http.StatusServiceUnavailable, 3},
+		"rate limit is retried for patch":               {http.MethodPatch, http.StatusTooManyRequests, 3},
+		"bad gateway is not retried for post":           {http.MethodPost, http.StatusBadGateway, 1},
+		"gateway timeout is not retried for patch":      {http.MethodPatch, http.StatusGatewayTimeout, 1},
+		"gateway timeout is retried for idempotent put": {http.MethodPut, http.StatusGatewayTimeout, 3},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var attempts int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&attempts, 1)
+				w.WriteHeader(tc.status)
+			}))
+			defer server.Close()
+
+			client := &http.Client{Transport: newRetryTransport(http.DefaultTransport, 2, time.Millisecond, time.Millisecond)}
+
+			req, err := http.NewRequest(tc.method, server.URL, http.NoBody)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tc.status {
+				t.Fatalf("expected status %d, got %d", tc.status, resp.StatusCode)
+			}
+
+			if attempts != tc.expectedAttempts {
+				t.Fatalf("expected %d attempts, got %d", tc.expectedAttempts, attempts)
+			}
+		})
+	}
+}
+
+func TestRetryTransport_stopsWhenContextIsCanceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newRetryTransport(http.DefaultTransport, 5, time.Second, time.Minute)}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, http.NoBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	if _, err := client.Do(req); err == nil {
+		t.Fatal("expected an error when the context is canceled while waiting")
+	}
+
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Fatalf("expected the retry wait to be interrupted, took %s", elapsed)
+	}
+}
+
+func TestRetryTransport_backoff(t *testing.T) {
+	transport := &retryTransport{waitMin: time.Second, waitMax: 5 * time.Second}
+
+	retryAfter := &http.Response{Header: http.Header{"Retry-After": []string{"12"}}}
+
+	got := []time.Duration{
+		transport.backoff(0, nil),
+		transport.backoff(1, nil),
+		transport.backoff(2, nil),
+		transport.backoff(3, nil),
+		transport.backoff(3, retryAfter),
+	}
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 12 * time.Second}
+
+	if diff := deep.Equal(expected, got); diff != nil {
+		t.Fatalf("Bad backoff return \n got = %#v\nwant = %#v \ndiff = %#v", got, expected, diff)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	testCases := map[string]struct {
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		"empty":        {"", 0, false},
+		"seconds":      {"3", 3 * time.Second, true},
+		"negative":     {"-3", 0, false},
+		"invalid":      {"soon", 0, false},
+		"date in past": {time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), 0, true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, ok := parseRetryAfter(tc.value)
+			if ok != tc.ok || got != tc.expected {
+				t.Fatalf("parseRetryAfter(%q) = %s, %t, want %s, %t", tc.value, got, ok, tc.expected, tc.ok)
+			}
+		})
+	}
+
+	date := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	if got, ok := parseRetryAfter(date); !ok || got <= 0 || got > time.Minute {
+		t.Fatalf("parseRetryAfter(%q) = %s, %t, want a wait of up to one minute", date, got, ok)
+	}
+}
+
+func TestConfigNewClient_retriesSharedByClients(t *testing.T) {
+	mock := newAtlasMockServer(t)
+	projectID := mock.newProject("retries")
+
+	client := mock.client(func(c *Config) {
+		c.MaxRetries = 2
+		c.RetryWaitMin = time.Millisecond
+		c.RetryWaitMax = time.Millisecond
+	})
+
+	mock.failNext(http.MethodGet, mockAtlasV1Path+"/groups/"+projectID, http.StatusTooManyRequests, 2, "0")
+	if _, _, err := client.Atlas.Projects.GetOneProject(context.Background(), projectID); err != nil {
+		t.Fatalf("expected the Atlas client to retry rate limited requests: %s", err)
+	}
+
+	mock.failNext(http.MethodGet, mockAtlasV2Path+"/groups/"+projectID, http.StatusServiceUnavailable, 2, "")
+	if _, _, err := client.AtlasV2.ProjectsApi.GetProject(context.Background(), projectID).Execute(); err != nil {
+		t.Fatalf("expected the Atlas SDK client to retry transient failures: %s", err)
+	}
+
+	if got := mock.requestCount(http.MethodGet, mockAtlasV1Path+"/groups/"+projectID); got != 3 {
+		t.Fatalf("expected 3 requests from the Atlas client, got %d", got)
+	}
+
+	if got := mock.requestCount(http.MethodGet, mockAtlasV2Path+"/groups/"+projectID); got != 3 {
+		t.Fatalf("expected 3 requests from the Atlas SDK client, got %d", got)
+	}
+
+	mock.failNext(http.MethodGet, mockAtlasV1Path+"/groups/"+projectID, http.StatusTooManyRequests, 3, "0")
+	if _, _, err := client.Atlas.Projects.GetOneProject(context.Background(), projectID); err == nil {
+		t.Fatal("expected an error once max_retries is exhausted")
+	}
+}
+
+func TestRateLimitTransport_capsConcurrentRequests(t *testing.T) {
+	var inFlight, maxInFlight int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+
+		for {
+			observed := atomic.LoadInt32(&maxInFlight)
+			if current <= observed || atomic.CompareAndSwapInt32(&maxInFlight, observed, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newRateLimitTransport(0, 2).wrap(http.DefaultTransport)}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(server.URL)
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+				return
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight > 2 {
+		t.Fatalf("expected at most 2 concurrent requests, got %d", maxInFlight)
+	}
+}
+
+func TestRateLimitTransport_throttlesRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	// 1200 requests per minute, a request every 50ms after a burst of 20.
+	client := &http.Client{Transport: newRateLimitTransport(1200, 0).wrap(http.DefaultTransport)}
+
+	start := time.Now()
+	for i := 0; i < 25; i++ {
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		resp.Body.Close()
+	}
+
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Fatalf("expected the requests above the burst to be throttled, took %s", elapsed)
+	}
+}
+
+func TestRateLimitTransport_stopsWhenContextIsCanceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newRateLimitTransport(0, 1).wrap(http.DefaultTransport)}
+
+	// hold the only slot by not closing the body.
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, http.NoBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Do(req); err == nil {
+		t.Fatal("expected an error while waiting for a free slot")
+	}
+
+	resp.Body.Close()
+
+	if resp, err = client.Get(server.URL); err != nil {
+		t.Fatalf("expected the slot to be released once the body is closed: %s", err)
+	}
+	resp.Body.Close()
+}
+
+func TestNewRateLimitTransport_disabled(t *testing.T) {
+	if got := newRateLimitTransport(0, 0).wrap(http.DefaultTransport); got != http.DefaultTransport {
+		t.Fatalf("expected the transport to be returned as is when no limit is set, got %#v", got)
+	}
+}
+
+func TestConfigNewClient_rateLimitSharedByClients(t *testing.T) {
+	mock := newAtlasMockServer(t)
+	projectID := mock.newProject("rate-limit")
+
+	client := mock.client(func(c *Config) {
+		c.RequestsPerMinute = 600
+	})
+
+	// the burst is one second worth of requests, the next ones are sent every 100ms whatever the client.
+	start := time.Now()
+	for i := 0; i < 10; i++ {
+		if _, _, err := client.Atlas.Projects.GetOneProject(context.Background(), projectID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 0; i < 5; i++ {
+		if _, _, err := client.AtlasV2.ProjectsApi.GetProject(context.Background(), projectID).Execute(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Fatalf("expected both clients to share the same limit, took %s", elapsed)
+	}
+}